@@ -0,0 +1,9 @@
+export function SignupForm({onSubmit}) {
+	return (
+		<form onSubmit={onSubmit}>
+			<label htmlFor="email">Email</label>
+			<input id="email" name="email" type="email" autoComplete="email" />
+			<button type="submit">Create account</button>
+		</form>
+	);
+}
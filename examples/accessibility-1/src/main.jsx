@@ -0,0 +1,10 @@
+import React from 'react';
+import ReactDOM from 'react-dom/client';
+import {SignupForm} from './components/signup-form.jsx';
+
+if (import.meta.env.DEV) {
+	const {default: axe} = await import('@axe-core/react');
+	await axe(React, ReactDOM, 1000);
+}
+
+ReactDOM.createRoot(document.querySelector('#root')).render(<SignupForm />);
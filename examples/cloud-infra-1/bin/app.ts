@@ -0,0 +1,5 @@
+import {App} from 'aws-cdk-lib';
+import {ApiStack} from '../lib/api-stack';
+
+const app = new App();
+new ApiStack(app, 'ApiStack');
@@ -0,0 +1,54 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+
+/**
+ * Custom scanner, it only needs a scan() method returning rules
+ */
+class FeatureFlagScanner {
+	name = 'feature-flags';
+	watchedFiles = ['flags.json'];
+
+	constructor(rootPath) {
+		this.rootPath = rootPath;
+	}
+
+	async scan() {
+		try {
+			const content = await fs.readFile(
+				path.join(this.rootPath, 'flags.json'),
+				'utf8',
+			);
+			const flags = Object.keys(JSON.parse(content));
+
+			return [
+				{
+					category: 'Feature Flags',
+					rule: `Guard new features behind a flag in flags.json (existing flags: ${flags.join(', ')}).`,
+					files: ['flags.json'],
+				},
+			];
+		} catch {
+			return [];
+		}
+	}
+}
+
+export default {
+	scanners: [FeatureFlagScanner],
+	rules: [
+		{
+			files: '*.graphql',
+			rule: 'The API is defined by a GraphQL schema. Change the schema first, then the resolvers.',
+			severity: 'high',
+		},
+		{
+			files: 'migrations/*.sql',
+			category: 'Database Migrations',
+			rule: 'Add a new numbered SQL migration instead of editing an existing one.',
+		},
+		{
+			files: ['**/*.proto'],
+			rule: 'Regenerate the gRPC clients after changing a .proto file.',
+		},
+	],
+};
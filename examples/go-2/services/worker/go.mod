@@ -0,0 +1,5 @@
+module example.com/workspace/worker
+
+go 1.22
+
+require go.uber.org/zap v1.27.0
@@ -0,0 +1,9 @@
+package main
+
+import "go.uber.org/zap"
+
+func main() {
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+	logger.Info("worker started")
+}
@@ -0,0 +1,13 @@
+import type {CodegenConfig} from '@graphql-codegen/cli';
+
+const config: CodegenConfig = {
+	schema: './src/schema/schema.graphql',
+	documents: ['src/**/*.graphql'],
+	generates: {
+		'./src/gql/': {
+			preset: 'client',
+		},
+	},
+};
+
+export default config;
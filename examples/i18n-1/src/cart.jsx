@@ -0,0 +1,12 @@
+import {useTranslation} from 'react-i18next';
+
+export function Cart({items, user}) {
+	const {t} = useTranslation();
+
+	return (
+		<section>
+			<h1>{t('welcome', {name: user.name})}</h1>
+			{items.length === 0 ? <p>{t('cart.empty')}</p> : <button>{t('cart.checkout')}</button>}
+		</section>
+	);
+}
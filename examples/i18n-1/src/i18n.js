@@ -0,0 +1,15 @@
+import i18next from 'i18next';
+import HttpBackend from 'i18next-http-backend';
+import {initReactI18next} from 'react-i18next';
+
+await i18next
+	.use(HttpBackend)
+	.use(initReactI18next)
+	.init({
+		fallbackLng: 'en',
+		supportedLngs: ['de', 'en', 'es', 'fr'],
+		ns: ['common'],
+		defaultNS: 'common',
+	});
+
+export default i18next;
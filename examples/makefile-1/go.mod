@@ -0,0 +1,3 @@
+module example.com/makefile-1
+
+go 1.22
@@ -0,0 +1,6 @@
+import {Kafka} from 'kafkajs';
+
+export const kafka = new Kafka({
+	clientId: 'orders',
+	brokers: process.env.KAFKA_BROKERS.split(','),
+});
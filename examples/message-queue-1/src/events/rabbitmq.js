@@ -0,0 +1,11 @@
+import amqp from 'amqplib';
+
+export async function consumeInvoices(handleInvoice) {
+	const connection = await amqp.connect(process.env.RABBITMQ_URL);
+	const channel = await connection.createChannel();
+	await channel.assertQueue('invoices');
+	await channel.consume('invoices', async (message) => {
+		await handleInvoice(JSON.parse(message.content.toString()));
+		channel.ack(message);
+	});
+}
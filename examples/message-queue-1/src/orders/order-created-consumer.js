@@ -0,0 +1,13 @@
+import {kafka} from '../events/kafka.js';
+
+const consumer = kafka.consumer({groupId: 'order-emails'});
+
+export async function startOrderCreatedConsumer(sendEmail) {
+	await consumer.connect();
+	await consumer.subscribe({topic: 'order-created'});
+	await consumer.run({
+		async eachMessage({message}) {
+			await sendEmail(JSON.parse(message.value.toString()));
+		},
+	});
+}
@@ -0,0 +1,11 @@
+import {kafka} from '../events/kafka.js';
+
+const producer = kafka.producer();
+
+export async function publishOrderCreated(order) {
+	await producer.connect();
+	await producer.send({
+		topic: 'order-created',
+		messages: [{key: order.id, value: JSON.stringify(order)}],
+	});
+}
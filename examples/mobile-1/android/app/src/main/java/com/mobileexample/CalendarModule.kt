@@ -0,0 +1,14 @@
+package com.mobileexample
+
+import com.facebook.react.bridge.ReactApplicationContext
+import com.facebook.react.bridge.ReactContextBaseJavaModule
+import com.facebook.react.bridge.ReactMethod
+
+class CalendarModule(context: ReactApplicationContext) : ReactContextBaseJavaModule(context) {
+  override fun getName() = "CalendarModule"
+
+  @ReactMethod
+  fun createEvent(name: String, location: String) {
+    android.util.Log.d("CalendarModule", "Creating event $name at $location")
+  }
+}
@@ -0,0 +1,8 @@
+import Foundation
+
+@objc(CalendarModule)
+class CalendarModule: NSObject {
+  @objc func createEvent(_ name: String, location: String) {
+    NSLog("Creating event %@ at %@", name, location)
+  }
+}
@@ -0,0 +1,11 @@
+import {getNodeAutoInstrumentations} from '@opentelemetry/auto-instrumentations-node';
+import {NodeSDK} from '@opentelemetry/sdk-node';
+import * as Sentry from '@sentry/node';
+
+Sentry.init({dsn: process.env.SENTRY_DSN});
+
+const sdk = new NodeSDK({
+	instrumentations: [getNodeAutoInstrumentations()],
+});
+
+sdk.start();
@@ -0,0 +1,9 @@
+import client from 'prom-client';
+
+export const registry = new client.Registry();
+
+export const orderDuration = new client.Histogram({
+	name: 'order_create_duration_seconds',
+	help: 'Time to create an order',
+	registers: [registry],
+});
@@ -0,0 +1,18 @@
+import {trace} from '@opentelemetry/api';
+import {logger} from '../lib/logger.js';
+import {orderDuration} from '../lib/metrics.js';
+
+const tracer = trace.getTracer('orders');
+
+export async function createOrder(order) {
+	return tracer.startActiveSpan('createOrder', async (span) => {
+		const end = orderDuration.startTimer();
+		try {
+			logger.info({orderId: order.id}, 'Order created');
+			return order;
+		} finally {
+			end();
+			span.end();
+		}
+	});
+}
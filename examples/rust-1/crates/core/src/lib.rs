@@ -0,0 +1,14 @@
+use serde::{Deserialize, Serialize};
+use thiserror::Error;
+
+#[derive(Debug, Serialize, Deserialize)]
+pub struct User {
+    pub id: u64,
+    pub name: String,
+}
+
+#[derive(Debug, Error)]
+pub enum CoreError {
+    #[error("user {0} not found")]
+    NotFound(u64),
+}
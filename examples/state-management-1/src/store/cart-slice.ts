@@ -0,0 +1,19 @@
+import {createSlice, type PayloadAction} from '@reduxjs/toolkit';
+
+type CartState = {
+	itemIds: string[];
+};
+
+const initialState: CartState = {itemIds: []};
+
+export const cartSlice = createSlice({
+	name: 'cart',
+	initialState,
+	reducers: {
+		addItem(state, action: PayloadAction<string>) {
+			state.itemIds.push(action.payload);
+		},
+	},
+});
+
+export const {addItem} = cartSlice.actions;
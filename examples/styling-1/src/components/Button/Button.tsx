@@ -0,0 +1,6 @@
+import styled from 'styled-components';
+
+export const Button = styled.button`
+	padding: ${({theme}) => theme.spacing.small};
+	color: ${({theme}) => theme.colors.primary};
+`;
@@ -0,0 +1,5 @@
+import styles from './Card.module.css';
+
+export function Card({children}: {children: React.ReactNode}) {
+	return <div className={styles.card}>{children}</div>;
+}
@@ -0,0 +1,9 @@
+import styled from 'styled-components';
+
+const Title = styled.h1`
+	font-size: 2rem;
+`;
+
+export function Header({title}: {title: string}) {
+	return <Title>{title}</Title>;
+}
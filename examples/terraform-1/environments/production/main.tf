@@ -0,0 +1,25 @@
+terraform {
+  required_version = ">= 1.6"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+
+  backend "s3" {
+    bucket = "example-terraform-state"
+    key    = "production/terraform.tfstate"
+    region = "us-east-1"
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+module "network" {
+  source     = "../../modules/network"
+  cidr_block = var.cidr_block
+}
@@ -0,0 +1,7 @@
+variable "region" {
+  type = string
+}
+
+variable "cidr_block" {
+  type = string
+}
@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    google = {
+      source  = "hashicorp/google"
+      version = "~> 6.0"
+    }
+  }
+
+  backend "gcs" {
+    bucket = "example-terraform-state"
+    prefix = "staging"
+  }
+}
+
+provider "google" {
+  project = "example-staging"
+  region  = "us-central1"
+}
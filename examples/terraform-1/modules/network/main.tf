@@ -0,0 +1,7 @@
+variable "cidr_block" {
+  type = string
+}
+
+resource "aws_vpc" "main" {
+  cidr_block = var.cidr_block
+}
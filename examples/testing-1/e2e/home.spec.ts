@@ -0,0 +1,6 @@
+import {expect, test} from '@playwright/test';
+
+test('shows the home page', async ({page}) => {
+	await page.goto('/');
+	await expect(page).toHaveTitle(/Home/);
+});
@@ -0,0 +1,6 @@
+import {expect, test} from 'vitest';
+import {sum} from './sum.js';
+
+test('adds two numbers', () => {
+	expect(sum(1, 2)).toBe(3);
+});
@@ -0,0 +1,8 @@
+export default {
+	head: {
+		title: 'vue-2',
+	},
+	components: true,
+	buildModules: [],
+	modules: [],
+};
@@ -0,0 +1,55 @@
+import path from 'node:path';
+import process from 'node:process';
+import {CodebaseScanner} from './scanners/codebase-scanner.js';
+import {loadConfig, validateConfig} from './services/config-loader.js';
+import {logger} from './services/logger.js';
+import {packageInfo} from './services/package-info.js';
+import type {ScanOptions, ScanResult} from './types.js';
+
+const apiLogger = logger.getLogger('API');
+
+/**
+ * Create the scanner of a directory, loading its config file
+ * The scanner can be run repeatedly, e.g. to scan again after changes
+ * @param options Scan options
+ */
+export async function createScanner(
+	options: ScanOptions = {},
+): Promise<CodebaseScanner> {
+	const {directory, config, ...scannerOptions} = options;
+	const absolutePath = path.resolve(directory ?? process.cwd());
+
+	const loadedConfig =
+		typeof config === 'object'
+			? validateConfig(config, 'object')
+			: await loadConfig(absolutePath, config);
+
+	if (loadedConfig) {
+		apiLogger.debug(
+			`Loaded ${loadedConfig.scanners?.length ?? 0} custom scanners and ${loadedConfig.rules?.length ?? 0} rules from config`,
+		);
+	}
+
+	return new CodebaseScanner(absolutePath, {
+		...scannerOptions,
+		config: loadedConfig,
+	});
+}
+
+/**
+ * Scan a directory and return the rules found
+ * @param options Scan options
+ * @returns The scanned directory, its rules and what each scanner found
+ */
+export async function scan(options: ScanOptions = {}): Promise<ScanResult> {
+	const directory = path.resolve(options.directory ?? process.cwd());
+	const scanner = await createScanner({...options, directory});
+	const rules = await scanner.scan();
+
+	return {
+		directory,
+		version: packageInfo.getVersion(),
+		rules,
+		scanners: scanner.getReport(),
+	};
+}
@@ -1,6 +1,8 @@
 import fs from 'node:fs/promises';
+import path from 'node:path';
 import {logger} from '../services/logger.js';
 import type {AiRule} from '../types.js';
+import type {OutputPreview} from '../types/output-preview.js';
 
 /**
  * Base builder class for AI rule output generation
@@ -22,9 +24,16 @@ export abstract class AiRuleBuilder {
 		return '<!-- PSST-AI-INSTRUCTIONS-END -->';
 	}
 
+	/**
+	 * Content placed at the top of a newly created output file
+	 */
+	protected get fileHeader(): string {
+		return '';
+	}
+
 	/**
 	 * Constructor for AiRuleBuilder
-	 * @param outputPath Path to output directory
+	 * @param outputPath Path to the output file
 	 * @param recommendations List of recommendations collected from scanners
 	 */
 	constructor(
@@ -38,8 +47,9 @@ export abstract class AiRuleBuilder {
 
 	/**
 	 * Build the output file with all recommendations
+	 * @param noHeader If true, flatten the output without category headers
 	 */
-	public abstract build(): Promise<void>;
+	public abstract build(noHeader?: boolean): Promise<void>;
 
 	/**
 	 * Generate the output content for the output file
@@ -48,6 +58,120 @@ export abstract class AiRuleBuilder {
 	 */
 	public abstract generateOutputContent(noHeader?: boolean): string;
 
+	/**
+	 * Merge the generated content into existing file content
+	 * Only the region between the start and end tags is managed by psst-ai,
+	 * everything outside of it is preserved
+	 * @param existingContent Current file content, undefined if the file does not exist
+	 * @param noHeader If true, flatten the output without category headers
+	 * @returns The new file content
+	 */
+	public mergeContent(
+		existingContent: string | undefined,
+		noHeader?: boolean,
+	): string {
+		const contentToInsert = this.generateOutputContent(noHeader);
+
+		// New or empty file: header followed by the managed region
+		if (!existingContent?.trim()) {
+			return `${this.fileHeader}${this.startTag}\n${contentToInsert}\n${this.endTag}\n`;
+		}
+
+		const startIndex = existingContent.indexOf(this.startTag);
+		const endIndex = existingContent.indexOf(this.endTag);
+
+		// Existing file without tags: append the managed region to keep user edits
+		if (startIndex === -1 || endIndex === -1) {
+			return `${existingContent.trimEnd()}\n\n${this.startTag}\n${contentToInsert}\n${this.endTag}\n`;
+		}
+
+		return (
+			existingContent.slice(0, startIndex + this.startTag.length) +
+			'\n' +
+			contentToInsert +
+			'\n' +
+			existingContent.slice(endIndex)
+		);
+	}
+
+	/**
+	 * Compute the output file without writing it, e.g. for a dry run
+	 * @param noHeader If true, flatten the output without category headers
+	 * @returns The current and the new content of the output file
+	 */
+	public async preview(noHeader?: boolean): Promise<OutputPreview> {
+		const previousContent = await this.readOutputFile();
+		const content = this.mergeContent(previousContent, noHeader);
+
+		return {
+			filePath: this.outputPath,
+			previousContent,
+			content,
+			managedRegion: {
+				previous:
+					previousContent === undefined
+						? undefined
+						: this.getManagedRegion(previousContent),
+				next: this.getManagedRegion(content) ?? content,
+			},
+		};
+	}
+
+	/**
+	 * Get the content between the start and end tags
+	 * @returns The managed region, undefined if the content has no tags
+	 */
+	protected getManagedRegion(content: string): string | undefined {
+		const startIndex = content.indexOf(this.startTag);
+		const endIndex = content.indexOf(this.endTag);
+
+		if (startIndex === -1 || endIndex === -1) {
+			return undefined;
+		}
+
+		return content.slice(startIndex + this.startTag.length, endIndex).trim();
+	}
+
+	/**
+	 * Read the current content of the output file
+	 * @returns The content, undefined if the file does not exist
+	 */
+	protected async readOutputFile(): Promise<string | undefined> {
+		try {
+			return await fs.readFile(this.outputPath, 'utf8');
+		} catch {
+			this.logger.debug(`Creating new file ${this.outputPath}`);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Write the output file, merging with its existing content if present
+	 * @param noHeader If true, flatten the output without category headers
+	 */
+	protected async writeManagedFile(noHeader?: boolean): Promise<void> {
+		try {
+			const existingContent = await this.readOutputFile();
+
+			// Ensure the output directory exists
+			await fs.mkdir(path.dirname(this.outputPath), {recursive: true});
+
+			await fs.writeFile(
+				this.outputPath,
+				this.mergeContent(existingContent, noHeader),
+				'utf8',
+			);
+
+			this.logger.info(`AI instructions written to ${this.outputPath}`);
+		} catch (error: unknown) {
+			this.logger.error(
+				`Error writing AI instructions to ${this.outputPath}`,
+				error as Error,
+			);
+			throw error;
+		}
+	}
+
 	/**
 	 * Update file instructions by replacing content between start and end tags
 	 * @param filePath Path to the file to update
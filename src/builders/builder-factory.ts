@@ -0,0 +1,81 @@
+import path from 'node:path';
+import type {AiRule} from '../types.js';
+import {OutputFormat} from '../types/output-format.js';
+import type {AiRuleBuilder} from './ai-rule-builder.js';
+import {ClaudeBuilder} from './claude-builder.js';
+import {CopilotBuilder} from './copilot-builder.js';
+import {CursorBuilder} from './cursor-builder.js';
+import {JsonBuilder} from './json-builder.js';
+
+/**
+ * Options for creating an output builder
+ */
+export type RuleBuilderOptions = {
+	/**
+	 * Root of the scanned project, default output paths are relative to it
+	 */
+	projectPath: string;
+	/**
+	 * Explicit output file path, overrides the format's default location
+	 */
+	outputPath?: string;
+	/**
+	 * Write Cursor rules in the .cursor/rules/*.mdc format
+	 */
+	mdc?: boolean;
+};
+
+/**
+ * Create the builder that writes rules in the given output format
+ * @param format Output format
+ * @param rules Rules collected from scanners
+ * @param options Builder options
+ * @returns Builder for the output format
+ */
+export function createRuleBuilder(
+	format: OutputFormat,
+	rules: AiRule[],
+	options: RuleBuilderOptions,
+): AiRuleBuilder {
+	const resolveOutputPath = (defaultPath: string) =>
+		options.outputPath
+			? path.resolve(options.outputPath)
+			: path.join(options.projectPath, defaultPath);
+
+	switch (format) {
+		case OutputFormat.Cursor: {
+			return new CursorBuilder(
+				resolveOutputPath(
+					options.mdc ? CursorBuilder.mdcFilePath : CursorBuilder.rulesFilePath,
+				),
+				rules,
+			);
+		}
+
+		case OutputFormat.Copilot: {
+			return new CopilotBuilder(
+				resolveOutputPath(CopilotBuilder.instructionsFilePath),
+				rules,
+			);
+		}
+
+		case OutputFormat.Claude: {
+			return new ClaudeBuilder(
+				resolveOutputPath(ClaudeBuilder.claudeFilePath),
+				rules,
+			);
+		}
+
+		case OutputFormat.Json: {
+			// JSON is written to stdout unless an output file is given
+			return new JsonBuilder(
+				options.outputPath ? path.resolve(options.outputPath) : '',
+				rules,
+			);
+		}
+
+		default: {
+			throw new Error(`Output format "${format}" does not write a rules file`);
+		}
+	}
+}
@@ -0,0 +1,115 @@
+import {Category, type AiRule} from '../types.js';
+import {isBuiltInCategory} from '../utils/category-formatter.js';
+import {AiRuleBuilder} from './ai-rule-builder.js';
+import {MarkdownBuilder} from './markdown-builder.js';
+
+/**
+ * Sections of the generated CLAUDE.md content
+ */
+type ClaudeSection = {
+	title: string;
+	rules: AiRule[];
+	// Render the rules without category headers
+	flat?: boolean;
+};
+
+/**
+ * Builder class to write AI rules to CLAUDE.md for Claude Code
+ */
+export class ClaudeBuilder extends AiRuleBuilder {
+	/**
+	 * Default path of the CLAUDE.md file, relative to the project root
+	 */
+	public static readonly claudeFilePath = 'CLAUDE.md';
+
+	/**
+	 * Categories describing how code is written rather than what it is built with
+	 */
+	private readonly conventionCategories = new Set<Category>([
+		Category.General,
+		Category.PackageManager,
+		Category.Linting,
+		Category.Xo,
+		Category.Prettier,
+		Category.Biome,
+		Category.Testing,
+		Category.Ava,
+		Category.Jest,
+	]);
+
+	/**
+	 * Title for newly created CLAUDE.md files
+	 */
+	protected get fileHeader(): string {
+		return '# CLAUDE.md\n\n';
+	}
+
+	/**
+	 * Write the CLAUDE.md file, preserving content outside the psst-ai tags
+	 * @param noHeader If true, flatten the output without category headers
+	 */
+	public async build(noHeader?: boolean): Promise<void> {
+		await this.writeManagedFile(noHeader);
+	}
+
+	/**
+	 * Generate the rules organized in Tech Stack, Conventions and Commands sections
+	 * @param noHeader If true, flatten the output without category headers
+	 * @returns Formatted rules content
+	 */
+	public generateOutputContent(noHeader?: boolean): string {
+		const sharedContent = this.getSections()
+			.filter((section) => section.rules.length > 0)
+			.map((section) => {
+				const flatten = noHeader === true || section.flat === true;
+				const content = new MarkdownBuilder(section.rules).buildMarkdown(
+					flatten,
+					3,
+				);
+				return `## ${section.title}\n\n${content}`;
+			})
+			.join('\n\n');
+
+		// Rules of workspace packages follow in a section per package
+		const packageContent = new MarkdownBuilder(
+			this.recommendations.filter((recommendation) => recommendation.package),
+		).buildMarkdown(noHeader);
+
+		return [sharedContent, packageContent].filter(Boolean).join('\n\n');
+	}
+
+	/**
+	 * Split the shared recommendations into CLAUDE.md sections
+	 */
+	private getSections(): ClaudeSection[] {
+		const techStack: ClaudeSection = {title: 'Tech Stack', rules: []};
+		const conventions: ClaudeSection = {title: 'Conventions', rules: []};
+		const commands: ClaudeSection = {
+			title: 'Commands',
+			rules: [],
+			flat: true,
+		};
+
+		for (const recommendation of this.recommendations) {
+			// Package rules are not part of the shared sections
+			if (recommendation.package) {
+				continue;
+			}
+
+			const category = recommendation.category ?? Category.General;
+
+			if (category === Category.Commands) {
+				commands.rules.push(recommendation);
+			} else if (
+				isBuiltInCategory(category) &&
+				this.conventionCategories.has(category)
+			) {
+				conventions.rules.push(recommendation);
+			} else {
+				techStack.rules.push(recommendation);
+			}
+		}
+
+		return [techStack, conventions, commands];
+	}
+}
@@ -0,0 +1,62 @@
+import type {AiRule} from '../types.js';
+import {AiRuleBuilder} from './ai-rule-builder.js';
+import {MarkdownBuilder} from './markdown-builder.js';
+
+/**
+ * Builder class to write AI rules to GitHub Copilot's .github/copilot-instructions.md
+ */
+export class CopilotBuilder extends AiRuleBuilder {
+	/**
+	 * Default path of the Copilot instructions file, relative to the project root
+	 */
+	public static readonly instructionsFilePath =
+		'.github/copilot-instructions.md';
+
+	/**
+	 * Title for newly created Copilot instructions files
+	 */
+	protected get fileHeader(): string {
+		return '# Copilot Instructions\n\n';
+	}
+
+	/**
+	 * Write the Copilot instructions file, preserving content outside the psst-ai tags
+	 * @param noHeader If true, flatten the output without category headers
+	 */
+	public async build(noHeader?: boolean): Promise<void> {
+		await this.writeManagedFile(noHeader);
+	}
+
+	/**
+	 * Generate the rules grouped by category and phrased as imperative guidance
+	 * @param noHeader If true, flatten the output without category headers
+	 * @returns Formatted rules content
+	 */
+	public generateOutputContent(noHeader?: boolean): string {
+		const imperativeRules = this.recommendations.map((recommendation) => ({
+			...recommendation,
+			rule: this.toImperative(recommendation.rule),
+		}));
+
+		return new MarkdownBuilder(imperativeRules).buildMarkdown(noHeader);
+	}
+
+	/**
+	 * Rephrase descriptive rules ("Using X", "Detected X: Y") as instructions
+	 * @param rule Rule text
+	 * @returns Imperative rule text
+	 */
+	private toImperative(rule: AiRule['rule']): string {
+		const usingMatch = /^Using (.+)$/s.exec(rule);
+		if (usingMatch) {
+			return `Use ${usingMatch[1]}`;
+		}
+
+		const detectedMatch = /^(?:Detected|Found) ([^:]+): (.+?)\.?$/s.exec(rule);
+		if (detectedMatch) {
+			return `Account for the ${detectedMatch[1]} used in this project: ${detectedMatch[2]}.`;
+		}
+
+		return rule;
+	}
+}
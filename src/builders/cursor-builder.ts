@@ -0,0 +1,53 @@
+import {AiRuleBuilder} from './ai-rule-builder.js';
+import {MarkdownBuilder} from './markdown-builder.js';
+
+/**
+ * Builder class to write AI rules for Cursor (.cursorrules or .cursor/rules/*.mdc)
+ */
+export class CursorBuilder extends AiRuleBuilder {
+	/**
+	 * Default path of the legacy Cursor rules file, relative to the project root
+	 */
+	public static readonly rulesFilePath = '.cursorrules';
+
+	/**
+	 * Default path of the Cursor project rule file, relative to the project root
+	 */
+	public static readonly mdcFilePath = '.cursor/rules/psst-ai.mdc';
+
+	/**
+	 * Front matter for newly created .mdc files so Cursor always applies the rule
+	 */
+	protected get fileHeader(): string {
+		if (!this.outputPath.endsWith('.mdc')) {
+			return '';
+		}
+
+		return [
+			'---',
+			'description: Project conventions detected by psst-ai',
+			'globs:',
+			'alwaysApply: true',
+			'---',
+			'',
+			'',
+		].join('\n');
+	}
+
+	/**
+	 * Write the Cursor rules file, preserving content outside the psst-ai tags
+	 * @param noHeader If true, flatten the output without category headers
+	 */
+	public async build(noHeader?: boolean): Promise<void> {
+		await this.writeManagedFile(noHeader);
+	}
+
+	/**
+	 * Generate the rules grouped by category
+	 * @param noHeader If true, flatten the output without category headers
+	 * @returns Formatted rules content
+	 */
+	public generateOutputContent(noHeader?: boolean): string {
+		return new MarkdownBuilder(this.recommendations).buildMarkdown(noHeader);
+	}
+}
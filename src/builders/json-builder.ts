@@ -0,0 +1,114 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import process from 'node:process';
+import {packageInfo} from '../services/package-info.js';
+import {type AiRule, Category} from '../types.js';
+import {
+	type JsonOutput,
+	type JsonRule,
+	jsonSchemaVersion,
+} from '../types/json-output.js';
+import type {OutputPreview} from '../types/output-preview.js';
+import {getConfidence} from '../utils/confidence.js';
+import {getSeverity} from '../utils/severity.js';
+import {AiRuleBuilder} from './ai-rule-builder.js';
+
+/**
+ * Builder class to output AI rules as structured JSON for other tools
+ */
+export class JsonBuilder extends AiRuleBuilder {
+	/**
+	 * Write the JSON output to the output file, or to stdout when no file is set
+	 * The file is overwritten since JSON cannot hold content outside of psst-ai
+	 */
+	public async build(): Promise<void> {
+		const content = this.generateOutputContent();
+
+		if (!this.outputPath) {
+			process.stdout.write(content);
+			return;
+		}
+
+		try {
+			await fs.mkdir(path.dirname(this.outputPath), {recursive: true});
+			await fs.writeFile(this.outputPath, content, 'utf8');
+			this.logger.info(`JSON output written to ${this.outputPath}`);
+		} catch (error: unknown) {
+			this.logger.error(
+				`Error writing JSON output to ${this.outputPath}`,
+				error as Error,
+			);
+			throw error;
+		}
+	}
+
+	/**
+	 * Compute the JSON output file without writing it
+	 * The whole file is replaced, so there is no managed region
+	 */
+	public async preview(): Promise<OutputPreview> {
+		return {
+			filePath: this.outputPath,
+			previousContent: await this.readOutputFile(),
+			content: this.generateOutputContent(),
+		};
+	}
+
+	/**
+	 * Generate the JSON document with all recommendations
+	 * @returns JSON content terminated by a newline
+	 */
+	public generateOutputContent(): string {
+		const output: JsonOutput = {
+			schemaVersion: jsonSchemaVersion,
+			generator: {
+				name: 'psst-ai',
+				version: packageInfo.getVersion(),
+			},
+			rules: this.recommendations.map((recommendation) =>
+				this.toJsonRule(recommendation),
+			),
+		};
+
+		return `${JSON.stringify(output, undefined, 2)}\n`;
+	}
+
+	/**
+	 * Convert a recommendation to a JSON rule with a stable key order
+	 */
+	private toJsonRule(recommendation: AiRule): JsonRule {
+		const jsonRule: JsonRule = {
+			...(recommendation.id && {id: recommendation.id}),
+			rule: recommendation.rule,
+			category: recommendation.category ?? Category.General,
+			severity: getSeverity(recommendation),
+			confidence: getConfidence(recommendation),
+		};
+
+		if (recommendation.scanner) {
+			jsonRule.scanner = recommendation.scanner;
+		}
+
+		if (recommendation.sources && recommendation.sources.length > 1) {
+			jsonRule.sources = recommendation.sources;
+		}
+
+		if (recommendation.files && recommendation.files.length > 0) {
+			jsonRule.files = recommendation.files;
+		}
+
+		if (recommendation.commands && recommendation.commands.length > 0) {
+			jsonRule.commands = recommendation.commands;
+		}
+
+		if (recommendation.conflictGroup) {
+			jsonRule.conflictGroup = recommendation.conflictGroup;
+		}
+
+		if (recommendation.package) {
+			jsonRule.package = recommendation.package;
+		}
+
+		return jsonRule;
+	}
+}
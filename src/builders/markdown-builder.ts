@@ -2,7 +2,10 @@ import fs from 'node:fs/promises';
 import path from 'node:path';
 import {logger} from '../services/logger.js';
 import {Category, type AiRule} from '../types.js';
+import type {OutputPreview} from '../types/output-preview.js';
+import {Severity} from '../types/severity.js';
 import {formatCategoryTitle} from '../utils/category-formatter.js';
+import {getSeverity, sortBySeverity} from '../utils/severity.js';
 import type {RuleDetector} from './rule-detector.js';
 
 /**
@@ -41,19 +44,41 @@ export class MarkdownBuilder implements RuleDetector {
 
 	/**
 	 * Build markdown content from the recommendations
+	 * Rules of workspace packages are placed in a section per package
 	 * @param noHeader If true, all rules will be flattened without category headers
+	 * @param headingLevel Markdown heading level of the category headers
 	 * @returns Formatted markdown string
 	 */
-	public buildMarkdown(noHeader?: boolean): string {
-		if (noHeader) {
-			return this.formatFlatMarkdown();
+	public buildMarkdown(noHeader?: boolean, headingLevel = 2): string {
+		const rootRules = this.recommendations.filter((rule) => !rule.package);
+		const packagePaths = [
+			...new Set(
+				this.recommendations.flatMap((rule) =>
+					rule.package ? [rule.package] : [],
+				),
+			),
+		].sort();
+
+		// Shared rules come first, followed by a section for each package
+		const sections = [
+			this.buildRulesMarkdown(rootRules, noHeader, headingLevel),
+		];
+
+		for (const packagePath of packagePaths) {
+			const packageRules = this.recommendations.filter(
+				(rule) => rule.package === packagePath,
+			);
+			const content = this.buildRulesMarkdown(
+				packageRules,
+				noHeader,
+				headingLevel + 1,
+			);
+			sections.push(
+				`${'#'.repeat(headingLevel)} Package \`${packagePath}\`\n\n${content}`,
+			);
 		}
 
-		// Group recommendations by category
-		const categorizedRecommendations = this.categorizeRecommendations();
-
-		// Create markdown content
-		return this.formatMarkdown(categorizedRecommendations);
+		return sections.filter(Boolean).join('\n\n');
 	}
 
 	/**
@@ -158,6 +183,41 @@ export class MarkdownBuilder implements RuleDetector {
 		);
 	}
 
+	/**
+	 * Compute the update of a file's instructions without writing it
+	 * @param filePath Path to the file to update
+	 * @param noHeader If true, flatten the output without category headers
+	 * @returns The current and the new content of the file
+	 */
+	public async previewFileInstructions(
+		filePath: string,
+		noHeader?: boolean,
+	): Promise<OutputPreview> {
+		const previousContent = await fs.readFile(filePath, 'utf8');
+		const content = this.insertBetweenTags(previousContent, noHeader);
+		const getManagedRegion = (fileContent: string) => {
+			const startIndex = fileContent.indexOf(this.startTag);
+			const endIndex = fileContent.indexOf(this.endTag);
+
+			return startIndex === -1 || endIndex === -1
+				? undefined
+				: fileContent
+						.slice(startIndex + this.startTag.length, endIndex)
+						.trim();
+		};
+
+		// Files without the tags are left unchanged
+		return {
+			filePath,
+			previousContent,
+			content,
+			managedRegion: {
+				previous: getManagedRegion(previousContent),
+				next: getManagedRegion(content) ?? this.buildMarkdown(noHeader),
+			},
+		};
+	}
+
 	/**
 	 * Update file instructions by replacing content between start and end tags
 	 * @param filePath Path to the file to update
@@ -201,26 +261,56 @@ export class MarkdownBuilder implements RuleDetector {
 		}
 	}
 
+	/**
+	 * Build markdown content from a list of rules
+	 * @param rules Rules to format
+	 * @param noHeader If true, all rules will be flattened without category headers
+	 * @param headingLevel Markdown heading level of the category headers
+	 * @returns Formatted markdown string
+	 */
+	private buildRulesMarkdown(
+		rules: AiRule[],
+		noHeader: boolean | undefined,
+		headingLevel: number,
+	): string {
+		if (noHeader) {
+			return this.formatFlatMarkdown(rules);
+		}
+
+		// Group recommendations by category
+		const categorizedRecommendations = this.categorizeRecommendations(rules);
+
+		// Create markdown content
+		return this.formatMarkdown(categorizedRecommendations, headingLevel);
+	}
+
 	/**
 	 * Group recommendations by their categories
+	 * Categories keep the order in which they first occur in the rules, which
+	 * are sorted by `categoryOrder` when they are aggregated
+	 * @param rules Rules to group
 	 * @returns Map of categories to their recommendations
 	 */
-	private categorizeRecommendations(): Map<string, string[]> {
+	private categorizeRecommendations(rules: AiRule[]): Map<string, string[]> {
 		const categorizedRecommendations = new Map<string, string[]>();
 
-		for (const recommendation of this.recommendations) {
+		for (const recommendation of rules) {
+			categorizedRecommendations.set(
+				formatCategoryTitle(recommendation.category ?? Category.General),
+				[],
+			);
+		}
+
+		// Most important rules come first within each category
+		for (const recommendation of sortBySeverity(rules)) {
 			const categoryValue = recommendation.category ?? Category.General;
 			const displayCategory = formatCategoryTitle(categoryValue);
 
-			if (!categorizedRecommendations.has(displayCategory)) {
-				categorizedRecommendations.set(displayCategory, []);
-			}
-
 			// Add the individual rule to the recommendations
 			if (recommendation.rule) {
 				categorizedRecommendations
 					.get(displayCategory)
-					?.push(recommendation.rule);
+					?.push(this.formatRule(recommendation));
 			} else {
 				this.logger.warn(
 					`Skipping recommendation with missing rule: ${JSON.stringify(recommendation)}`,
@@ -231,27 +321,47 @@ export class MarkdownBuilder implements RuleDetector {
 		return categorizedRecommendations;
 	}
 
+	/**
+	 * Format a rule, labeling critical and high severity rules so they stand out
+	 * @param recommendation The rule to format
+	 * @returns Rule text for a bullet point
+	 */
+	private formatRule(recommendation: AiRule): string {
+		switch (getSeverity(recommendation)) {
+			case Severity.Critical: {
+				return `**Critical:** ${recommendation.rule}`;
+			}
+
+			case Severity.High: {
+				return `**Important:** ${recommendation.rule}`;
+			}
+
+			default: {
+				return recommendation.rule;
+			}
+		}
+	}
+
 	/**
 	 * Format the categorized recommendations into markdown
 	 * @param categorizedRecommendations Map of categories to their recommendations
+	 * @param headingLevel Markdown heading level of the category headers
 	 * @returns Formatted markdown string
 	 */
 	private formatMarkdown(
 		categorizedRecommendations: Map<string, string[]>,
+		headingLevel: number,
 	): string {
 		let content = '';
+		const headingPrefix = '#'.repeat(headingLevel);
 
-		// Sort categories alphabetically
-		const sortedCategories = [...categorizedRecommendations.keys()].sort();
-
-		for (const category of sortedCategories) {
-			const recommendations = categorizedRecommendations.get(category) ?? [];
+		for (const [category, recommendations] of categorizedRecommendations) {
 			// Add unique recommendations (avoid duplicates)
 			const uniqueRecommendations = [...new Set(recommendations)];
 
 			if (uniqueRecommendations.length > 0) {
 				// Add category header with a blank line after it
-				content += `## ${category}\n\n`;
+				content += `${headingPrefix} ${category}\n\n`;
 
 				// Add each recommendation as a bullet point under the category
 				for (const recommendation of uniqueRecommendations) {
@@ -269,16 +379,17 @@ export class MarkdownBuilder implements RuleDetector {
 
 	/**
 	 * Format recommendations into flat markdown without categories
+	 * @param rules Rules to format
 	 * @returns Formatted markdown string with all rules flattened
 	 */
-	private formatFlatMarkdown(): string {
+	private formatFlatMarkdown(rules: AiRule[]): string {
 		let content = '';
 		const allRecommendations = new Set<string>();
 
-		// Collect all unique recommendations
-		for (const recommendation of this.recommendations) {
+		// Collect all unique recommendations, most important first
+		for (const recommendation of sortBySeverity(rules)) {
 			if (recommendation.rule) {
-				allRecommendations.add(recommendation.rule);
+				allRecommendations.add(this.formatRule(recommendation));
 			} else {
 				this.logger.warn(
 					`Skipping recommendation with missing rule: ${JSON.stringify(recommendation)}`,
@@ -0,0 +1,126 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {afterEach, describe, expect, it} from 'vitest';
+import {CopilotBuilder} from '../copilot-builder.js';
+import {
+	createFixture,
+	removeFixture,
+} from '../../scanners/tests/fixture.js';
+import {Category, type AiRule} from '../../types.js';
+
+describe('CopilotBuilder', () => {
+	const testRules: AiRule[] = [
+		{
+			rule: 'Use pnpm as the package manager.',
+			category: Category.PackageManager,
+		},
+		{
+			rule: 'Using PostgreSQL database. Use UUID for primary keys.',
+			category: Category.Prisma,
+		},
+		{rule: 'Detected testing tool: playwright', category: Category.Testing},
+	];
+
+	describe('New file', () => {
+		it('should render a title and the rules between the psst-ai tags', () => {
+			const builder = new CopilotBuilder('copilot-instructions.md', testRules);
+			const content = builder.mergeContent(undefined);
+			const lines = content.split('\n');
+
+			expect(lines[0]).toBe('# Copilot Instructions');
+			expect(lines).toContain('<!-- PSST-AI-INSTRUCTIONS-START -->');
+			expect(lines).toContain('<!-- PSST-AI-INSTRUCTIONS-END -->');
+
+			// Categories are rendered as second level headers
+			const headers = lines.filter((line) => line.startsWith('## '));
+			expect(headers).toEqual([
+				'## Package Manager',
+				'## Prisma',
+				'## Testing',
+			]);
+		});
+
+		it('should phrase rules as imperative guidance', () => {
+			const builder = new CopilotBuilder('copilot-instructions.md', testRules);
+			const content = builder.generateOutputContent();
+
+			expect(content).toContain('- Use pnpm as the package manager.');
+			expect(content).toContain(
+				'- Use PostgreSQL database. Use UUID for primary keys.',
+			);
+			expect(content).toContain(
+				'- Account for the testing tool used in this project: playwright.',
+			);
+		});
+	});
+
+	describe('Existing file', () => {
+		it('should preserve content outside the psst-ai tags', () => {
+			const builder = new CopilotBuilder('copilot-instructions.md', testRules);
+			const existingContent = [
+				'# Team guidelines',
+				'',
+				'<!-- PSST-AI-INSTRUCTIONS-START -->',
+				'- Stale rule',
+				'<!-- PSST-AI-INSTRUCTIONS-END -->',
+				'',
+				'Handwritten notes',
+			].join('\n');
+
+			const content = builder.mergeContent(existingContent);
+
+			expect(content.startsWith('# Team guidelines\n')).toBe(true);
+			expect(content.endsWith('Handwritten notes')).toBe(true);
+			expect(content).not.toContain('- Stale rule');
+			expect(content).toContain('- Use pnpm as the package manager.');
+		});
+	});
+
+	describe('Preview', () => {
+		let rootPath: string;
+
+		afterEach(async () => {
+			await removeFixture(rootPath);
+		});
+
+		it('should have no previous content for a new file', async () => {
+			rootPath = await createFixture({});
+			const outputPath = path.join(rootPath, 'copilot-instructions.md');
+
+			const preview = await new CopilotBuilder(outputPath, testRules).preview();
+
+			expect(preview.previousContent).toBeUndefined();
+			expect(preview.managedRegion?.previous).toBeUndefined();
+		});
+
+		it('should have the same managed region for an up to date file', async () => {
+			rootPath = await createFixture({});
+			const outputPath = path.join(rootPath, 'copilot-instructions.md');
+			const builder = new CopilotBuilder(outputPath, testRules);
+			await builder.build();
+			await fs.appendFile(outputPath, '\nHandwritten notes\n');
+
+			const preview = await builder.preview();
+
+			expect(preview.managedRegion?.previous).toBe(
+				preview.managedRegion?.next,
+			);
+		});
+
+		it('should have a different managed region for an outdated file', async () => {
+			rootPath = await createFixture({});
+			const outputPath = path.join(rootPath, 'copilot-instructions.md');
+			await new CopilotBuilder(outputPath, testRules.slice(1)).build();
+
+			const preview = await new CopilotBuilder(outputPath, testRules).preview();
+
+			expect(preview.managedRegion?.previous).not.toContain(
+				'- Use pnpm as the package manager.',
+			);
+			expect(preview.managedRegion?.next).toContain(
+				'- Use pnpm as the package manager.',
+			);
+			expect(preview.previousContent).not.toBe(preview.content);
+		});
+	});
+});
@@ -0,0 +1,102 @@
+import {fileURLToPath} from 'node:url';
+import {describe, expect, it} from 'vitest';
+import {JsonBuilder} from '../json-builder.js';
+import {CodebaseScanner} from '../../scanners/codebase-scanner.js';
+import {Category, Severity, type AiRule} from '../../types.js';
+import {type JsonOutput, jsonSchemaVersion} from '../../types/json-output.js';
+
+describe('JsonBuilder', () => {
+	/**
+	 * Parse the JSON output of rules
+	 */
+	function generateOutput(rules: AiRule[]): JsonOutput {
+		return JSON.parse(
+			new JsonBuilder('', rules).generateOutputContent(),
+		) as JsonOutput;
+	}
+
+	describe('Document', () => {
+		it('should hold the schema version and the generator', () => {
+			const output = generateOutput([]);
+
+			expect(Object.keys(output)).toEqual([
+				'schemaVersion',
+				'generator',
+				'rules',
+			]);
+			expect(output.schemaVersion).toBe(jsonSchemaVersion);
+			expect(output.generator.name).toBe('psst-ai');
+		});
+	});
+
+	describe('Rules', () => {
+		it('should fill in the default severity, confidence and category', () => {
+			const output = generateOutput([{rule: 'Use TypeScript strict mode.'}]);
+
+			expect(output.rules).toEqual([
+				{
+					rule: 'Use TypeScript strict mode.',
+					category: Category.General,
+					severity: Severity.Normal,
+					confidence: 1,
+				},
+			]);
+		});
+
+		it('should write the fields in a stable order', () => {
+			const output = generateOutput([
+				{
+					package: 'packages/api',
+					conflictGroup: 'package-manager',
+					files: ['pnpm-lock.yaml'],
+					scanner: 'package-manager',
+					severity: Severity.Critical,
+					category: Category.PackageManager,
+					rule: 'Use pnpm as the package manager.',
+					id: 'package-manager-1234abcd',
+				},
+			]);
+
+			expect(Object.keys(output.rules[0])).toEqual([
+				'id',
+				'rule',
+				'category',
+				'severity',
+				'confidence',
+				'scanner',
+				'files',
+				'conflictGroup',
+				'package',
+			]);
+		});
+
+		it('should only list the sources of merged rules', () => {
+			const output = generateOutput([
+				{rule: 'Run `make test`.', sources: ['makefile']},
+				{rule: 'Run `npm test`.', sources: ['scripts', 'ci']},
+			]);
+
+			expect(output.rules.map((rule) => rule.sources)).toEqual([
+				undefined,
+				['scripts', 'ci'],
+			]);
+		});
+	});
+
+	describe('Scanner names', () => {
+		it('should name scanners as the only and disable options do', async () => {
+			const examplePath = fileURLToPath(
+				new URL('../../../examples/docker-1', import.meta.url),
+			);
+			const rules = await new CodebaseScanner(examplePath, {
+				only: ['docker', 'scripts'],
+				cache: false,
+			}).scan();
+
+			const output = generateOutput(rules);
+
+			const scannerNames = new Set(output.rules.map((rule) => rule.scanner));
+			expect([...scannerNames].sort()).toEqual(['docker', 'scripts']);
+		});
+	});
+});
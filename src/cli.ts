@@ -2,31 +2,65 @@
 
 import path from 'node:path';
 import process from 'node:process';
-import {Command} from 'commander';
+import {Command, InvalidArgumentError} from 'commander';
+import {createScanner} from './api.js';
+import {createRuleBuilder} from './builders/builder-factory.js';
 import {MarkdownBuilder} from './builders/markdown-builder.js';
-import {CodebaseScanner} from './scanners/codebase-scanner.js';
+import type {CodebaseScanner} from './scanners/codebase-scanner.js';
+import {configFileNames} from './services/config-loader.js';
+import {FileWatcher} from './services/file-watcher.js';
 import {logger} from './services/logger.js';
 import {packageInfo} from './services/package-info.js';
-import {type CliOptions, validateCliOptions} from './types.js';
+import {
+	cloneRepository,
+	redactCredentials,
+} from './services/repository-cloner.js';
+import {diffRules, rulesDiffer} from './services/rule-diff.js';
+import {cacheFileName, ScanCache} from './services/scan-cache.js';
+import {
+	type AiRule,
+	Category,
+	type CliOptions,
+	validateCliOptions,
+} from './types.js';
+import {OutputFormat} from './types/output-format.js';
+import type {OutputPreview} from './types/output-preview.js';
+import {Severity} from './types/severity.js';
+import {
+	formatCategoryTitle,
+	parseCategory,
+} from './utils/category-formatter.js';
+import {parseConfidence} from './utils/confidence.js';
+import {formatDiff} from './utils/diff.js';
+import {formatScanSummary} from './utils/summary-formatter.js';
 
-// Export types (for programmatic access when installed as dependency)
-export type {AiRule, Category, CliOptions} from './types.js';
-
-// Export builders
-export {MarkdownBuilder as GithubCopilotOutputBuilder} from './builders/markdown-builder.js';
-
-// Export scanners
-export {BaseScanner} from './scanners/base/base-scanner.js';
-export {CodebaseScanner as Scanner} from './scanners/codebase-scanner.js';
-export {LintingScanner} from './scanners/linters/linting-scanner.js';
+const cliLogger = logger.getLogger('CLI');
 
-export {PackageManagerScanner} from './scanners/node/package-manager-scanner.js';
+/**
+ * Parse a comma separated list, e.g. of scanner names or globs
+ */
+function parseNames(value: string): string[] {
+	return value
+		.split(',')
+		.map((name) => name.trim())
+		.filter(Boolean);
+}
 
-// Export services
-export {logger} from './services/logger.js';
-export {packageInfo} from './services/package-info.js';
+/**
+ * Parse a comma separated list of categories
+ */
+function parseCategories(value: string): Category[] {
+	return parseNames(value).map((name) => {
+		const category = parseCategory(name);
+		if (!category) {
+			throw new InvalidArgumentError(
+				`Unknown category: ${name}. Run --list-categories to see the categories.`,
+			);
+		}
 
-const cliLogger = logger.getLogger('CLI');
+		return category;
+	});
+}
 
 /**
  * Class to handle CLI operations
@@ -68,6 +102,102 @@ export class CliHandler {
 			.option('-v, --verbose', 'Show verbose output')
 			.option('--no-header', 'Flatten output without category headers')
 			.option('-f, --file <path>', 'File path to update with AI instructions')
+			.option(
+				'--format <format>',
+				`Output format (${Object.values(OutputFormat).join(', ')})`,
+			)
+			.option(
+				'--mdc',
+				'Write Cursor rules to .cursor/rules/psst-ai.mdc instead of .cursorrules',
+			)
+			.option(
+				'--concurrency <number>',
+				'Number of scanners to run in parallel (defaults to the CPU count)',
+			)
+			.option('--no-gitignore', 'Scan files ignored by .gitignore')
+			.option(
+				'--min-severity <severity>',
+				`Only include rules of at least this severity (${Object.values(Severity).join(', ')})`,
+			)
+			.option(
+				'--min-confidence <confidence>',
+				'Only include rules at least this certain, from 0 to 1 or a level (low, medium, high, certain)',
+				parseConfidence,
+			)
+			.option(
+				'--min-evidence <number>',
+				'Evidence a scanner needs before emitting rules, overriding the defaults of the scanners',
+			)
+			.option(
+				'--max-rules <number>',
+				'Maximum number of rules, leaving out the lowest-priority rules first',
+			)
+			.option(
+				'--token-budget <number>',
+				'Maximum number of tokens of the rules, leaving out the lowest-priority rules first',
+			)
+			.option(
+				'--category <categories>',
+				'Only include rules of these categories, comma separated (e.g. testing,linting)',
+				parseCategories,
+			)
+			.option(
+				'--exclude-category <categories>',
+				'Leave out rules of these categories, comma separated',
+				parseCategories,
+			)
+			.option('--list-categories', 'List the rule categories and exit')
+			.option(
+				'--per-package',
+				'Scan each package of a monorepo workspace separately',
+			)
+			.option('-w, --watch', 'Regenerate the output when config files change')
+			.option(
+				'--only <names>',
+				'Only run these scanners, comma separated (e.g. go,linter)',
+				parseNames,
+			)
+			.option(
+				'--disable <names>',
+				'Skip these scanners, comma separated (e.g. docker,kubernetes)',
+				parseNames,
+			)
+			.option(
+				'--include <globs>',
+				'Only scan files matching these globs, comma separated and relative to the directory (e.g. packages/frontend)',
+				parseNames,
+			)
+			.option(
+				'--exclude <globs>',
+				'Leave out files matching these globs, comma separated and relative to the directory (e.g. legacy,**/*.generated.ts)',
+				parseNames,
+			)
+			.option(
+				'-c, --config <path>',
+				'Config file with custom scanners and rules (defaults to psst.config.js or psst.config.ts in the scanned directory)',
+			)
+			.option(
+				'--no-cache',
+				`Scan all files instead of reusing the results of unchanged files (${cacheFileName})`,
+			)
+			.option('--clear-cache', 'Delete the scan cache and exit')
+			.option(
+				'--dry-run',
+				'Print the rules and the changes to the output file without writing anything',
+			)
+			.option(
+				'--check',
+				'Exit with code 1 and print a diff if the output file is not up to date, without writing anything',
+			)
+			.option(
+				'--repo <url>',
+				'Clone a git repository to a temporary directory and scan it, writing the output to the current directory',
+			)
+			.option('--ref <ref>', 'Branch, tag or commit to check out with --repo')
+			.option(
+				'--summary',
+				'Print which scanners matched, on which files, and which found nothing',
+			)
 			.action(async (directory?: string, options?: CliOptions) => {
 				await this.runScan(directory, options);
 			});
@@ -91,60 +221,535 @@ export class CliHandler {
 			const pathToScan = directory ?? process.cwd();
 			const absolutePath = path.resolve(pathToScan);
 
-			if (validatedOptions?.verbose) {
-				cliLogger.info(`Starting scan of directory: ${absolutePath}`);
+			if (validatedOptions?.listCategories) {
+				this.listCategories();
+				return;
 			}
 
-			const scanner = new CodebaseScanner(absolutePath);
+			if (validatedOptions?.clearCache) {
+				await this.clearCache(absolutePath, validatedOptions);
+				return;
+			}
 
-			// If file option is specified, update that file with AI instructions
-			if (validatedOptions?.file) {
-				const rules = await scanner.scan();
-				const filePath = path.resolve(validatedOptions.file);
+			if (validatedOptions?.repo) {
+				if (directory) {
+					throw new Error('Pass either a directory or --repo, not both');
+				}
 
-				// Create a markdown builder for the rules
-				const markdownBuilder = new MarkdownBuilder(rules);
+				await this.scanRepository(validatedOptions.repo, validatedOptions);
+				return;
+			}
 
-				// Update the specified file with AI instructions
-				await markdownBuilder.updateFileInstructions(
-					filePath,
-					!validatedOptions?.header,
-				);
+			if (validatedOptions?.ref) {
+				throw new Error('--ref can only be used with --repo');
+			}
 
-				if (validatedOptions?.verbose) {
-					cliLogger.info(`Updated AI instructions in file: ${filePath}`);
-				}
-			} else {
-				// Get the formatted output
-				const output = await scanner.getOutput(!validatedOptions?.header);
-
-				// Print the output to the console if not in quiet mode
-				if (!validatedOptions?.quiet) {
-					console.log('\n--- 🤫 psst-ai Generated Instructions ---\n');
-					console.log(output);
-					console.log('\n--- End of Generated Instructions ---\n');
-				}
+			if (validatedOptions?.verbose) {
+				cliLogger.info(`Starting scan of directory: ${absolutePath}`);
+			}
 
-				// Save output to file if specified
-				if (validatedOptions?.output) {
-					const fs = await import('node:fs/promises');
-					const outputPath = path.resolve(validatedOptions.output);
-					await fs.writeFile(outputPath, output, 'utf8');
+			const scanner = await this.createScanner(absolutePath, validatedOptions);
+			const rules = await scanner.scan();
 
-					if (validatedOptions?.verbose) {
-						cliLogger.info(`Output saved to: ${outputPath}`);
-					}
-				}
+			if (validatedOptions?.check) {
+				await this.checkOutput(rules, absolutePath, validatedOptions);
+				this.printSummary(scanner, validatedOptions);
+				return;
 			}
 
+			await this.writeOutput(rules, absolutePath, validatedOptions);
+			this.printSummary(scanner, validatedOptions);
+
 			if (validatedOptions?.verbose) {
 				cliLogger.info('Scan completed');
 			}
+
+			if (validatedOptions?.watch) {
+				this.watch(scanner, rules, absolutePath, validatedOptions);
+			}
 		} catch (error) {
 			cliLogger.error('Error running psst-ai', error);
 			process.exit(1);
 		}
 	}
+
+	/**
+	 * Scan a remote repository, shallow cloned to a temporary directory
+	 * The output is written relative to the current directory, as the clone
+	 * is deleted after the scan. The config file of the repository is not
+	 * loaded, so scanning a repository never runs its code.
+	 * @param url URL of the repository
+	 * @param validatedOptions Command options
+	 */
+	private async scanRepository(
+		url: string,
+		validatedOptions: CliOptions,
+	): Promise<void> {
+		if (validatedOptions.watch) {
+			throw new Error('--watch can not be used with --repo');
+		}
+
+		const repository = await cloneRepository(url, validatedOptions.ref);
+
+		try {
+			if (validatedOptions.verbose) {
+				cliLogger.info(
+					`Starting scan of ${redactCredentials(url)} in ${repository.directory}`,
+				);
+			}
+
+			const scanner = await this.createScanner(
+				repository.directory,
+				validatedOptions,
+			);
+			const rules = await scanner.scan();
+
+			await (validatedOptions.check
+				? this.checkOutput(rules, process.cwd(), validatedOptions)
+				: this.writeOutput(rules, process.cwd(), validatedOptions));
+			this.printSummary(scanner, validatedOptions);
+		} finally {
+			await repository.cleanup();
+		}
+	}
+
+	/**
+	 * Create the scanner of a directory from the command options
+	 * @param absolutePath Absolute path of the directory to scan
+	 * @param validatedOptions Command options
+	 */
+	private async createScanner(
+		absolutePath: string,
+		validatedOptions?: CliOptions,
+	): Promise<CodebaseScanner> {
+		return createScanner({
+			directory: absolutePath,
+			// The config file of a cloned repository could run any code
+			config:
+				validatedOptions?.repo && !validatedOptions.config
+					? {}
+					: validatedOptions?.config,
+			concurrency: validatedOptions?.concurrency,
+			gitignore: validatedOptions?.gitignore,
+			minSeverity: validatedOptions?.minSeverity,
+			minConfidence: validatedOptions?.minConfidence,
+			minEvidence: validatedOptions?.minEvidence,
+			maxRules: validatedOptions?.maxRules,
+			tokenBudget: validatedOptions?.tokenBudget,
+			categories: validatedOptions?.category,
+			excludeCategories: validatedOptions?.excludeCategory,
+			perPackage: validatedOptions?.perPackage,
+			only: validatedOptions?.only,
+			disable: validatedOptions?.disable,
+			include: validatedOptions?.include,
+			exclude: validatedOptions?.exclude,
+			// Dry runs and checks do not write the cache file either, and a
+			// cloned repository is deleted after the scan
+			cache:
+				validatedOptions?.dryRun ||
+				validatedOptions?.check ||
+				validatedOptions?.repo
+					? false
+					: validatedOptions?.cache,
+		});
+	}
+
+	/**
+	 * Print the summary of the last scan if requested
+	 * The summary goes to stderr, so it can be combined with rules printed to
+	 * stdout, e.g. as JSON
+	 * @param scanner Scanner of the last scan
+	 * @param validatedOptions Command options
+	 */
+	private printSummary(
+		scanner: CodebaseScanner,
+		validatedOptions?: CliOptions,
+	): void {
+		if (validatedOptions?.summary) {
+			console.error(`\n${formatScanSummary(scanner.getReport())}\n`);
+		}
+	}
+
+	/**
+	 * Print the rule categories accepted by --category
+	 */
+	private listCategories(): void {
+		const categories = Object.values(Category);
+		const width = Math.max(...categories.map((category) => category.length));
+
+		for (const category of categories) {
+			console.log(
+				`${category.padEnd(width)}  ${formatCategoryTitle(category)}`,
+			);
+		}
+	}
+
+	/**
+	 * Delete the scan cache of a directory
+	 * @param absolutePath Absolute path of the scanned directory
+	 * @param validatedOptions Command options
+	 */
+	private async clearCache(
+		absolutePath: string,
+		validatedOptions: CliOptions,
+	): Promise<void> {
+		const cleared = await new ScanCache(absolutePath).clear();
+
+		if (!validatedOptions.quiet) {
+			console.log(
+				cleared
+					? `Cleared the scan cache of ${absolutePath}`
+					: `No scan cache found in ${absolutePath}`,
+			);
+		}
+	}
+
+	/**
+	 * Write the rules in the requested format
+	 * @param rules Rules found by the scan
+	 * @param projectPath Directory the output files are written to
+	 * @param validatedOptions Command options
+	 */
+	private async writeOutput(
+		rules: AiRule[],
+		projectPath: string,
+		validatedOptions?: CliOptions,
+	): Promise<void> {
+		const format = validatedOptions?.format ?? OutputFormat.Markdown;
+
+		// JSON without an output file only goes to stdout, even in a dry run
+		if (
+			validatedOptions?.dryRun &&
+			(format !== OutputFormat.Json || validatedOptions.output)
+		) {
+			await this.previewOutput(rules, projectPath, validatedOptions);
+			return;
+		}
+
+		// If a rules file format is specified, write the rules in that format
+		if (format !== OutputFormat.Markdown) {
+			const builder = createRuleBuilder(format, rules, {
+				projectPath,
+				outputPath: validatedOptions?.output,
+				mdc: validatedOptions?.mdc,
+			});
+
+			await builder.build(!validatedOptions?.header);
+
+			if (validatedOptions?.verbose) {
+				cliLogger.info(`Wrote ${format} rules`);
+			}
+		} else if (validatedOptions?.file) {
+			// If file option is specified, update that file with AI instructions
+			const filePath = path.resolve(validatedOptions.file);
+
+			// Create a markdown builder for the rules
+			const markdownBuilder = new MarkdownBuilder(rules);
+
+			// Update the specified file with AI instructions
+			await markdownBuilder.updateFileInstructions(
+				filePath,
+				!validatedOptions?.header,
+			);
+
+			if (validatedOptions?.verbose) {
+				cliLogger.info(`Updated AI instructions in file: ${filePath}`);
+			}
+		} else {
+			// Get the formatted output
+			const output = new MarkdownBuilder(rules).buildMarkdown(
+				!validatedOptions?.header,
+			);
+
+			// Print the output to the console if not in quiet mode
+			if (!validatedOptions?.quiet) {
+				console.log('\n--- 🤫 psst-ai Generated Instructions ---\n');
+				console.log(output);
+				console.log('\n--- End of Generated Instructions ---\n');
+			}
+
+			// Save output to file if specified
+			if (validatedOptions?.output) {
+				const fs = await import('node:fs/promises');
+				const outputPath = path.resolve(validatedOptions.output);
+				await fs.writeFile(outputPath, output, 'utf8');
+
+				if (validatedOptions?.verbose) {
+					cliLogger.info(`Output saved to: ${outputPath}`);
+				}
+			}
+		}
+	}
+
+	/**
+	 * Print the rules and the changes to the output file instead of writing it
+	 * Files with a psst-ai region only show the changes of that region
+	 * @param rules Rules found by the scan
+	 * @param projectPath Directory the output files are written to
+	 * @param validatedOptions Command options
+	 */
+	private async previewOutput(
+		rules: AiRule[],
+		projectPath: string,
+		validatedOptions: CliOptions,
+	): Promise<void> {
+		if (!validatedOptions.quiet) {
+			const noHeader = !validatedOptions.header;
+			console.log('\n--- 🤫 psst-ai Generated Instructions ---\n');
+			console.log(new MarkdownBuilder(rules).buildMarkdown(noHeader));
+			console.log('\n--- End of Generated Instructions ---\n');
+		}
+
+		const preview = await this.getOutputPreview(
+			rules,
+			projectPath,
+			validatedOptions,
+		);
+		console.log(
+			preview
+				? this.formatPreview(preview)
+				: 'Dry run: no file would be written',
+		);
+	}
+
+	/**
+	 * Render the output file in memory without writing it
+	 * @param rules Rules found by the scan
+	 * @param projectPath Directory the output files are written to
+	 * @param validatedOptions Command options
+	 * @returns The file and its content, undefined if the output only goes to
+	 * the console
+	 */
+	private async getOutputPreview(
+		rules: AiRule[],
+		projectPath: string,
+		validatedOptions: CliOptions,
+	): Promise<OutputPreview | undefined> {
+		const format = validatedOptions.format ?? OutputFormat.Markdown;
+		const noHeader = !validatedOptions.header;
+
+		if (format !== OutputFormat.Markdown) {
+			// JSON without an output file only goes to stdout
+			if (format === OutputFormat.Json && !validatedOptions.output) {
+				return undefined;
+			}
+
+			return createRuleBuilder(format, rules, {
+				projectPath,
+				outputPath: validatedOptions.output,
+				mdc: validatedOptions.mdc,
+			}).preview(noHeader);
+		}
+
+		if (validatedOptions.file) {
+			return new MarkdownBuilder(rules).previewFileInstructions(
+				path.resolve(validatedOptions.file),
+				noHeader,
+			);
+		}
+
+		if (validatedOptions.output) {
+			const fs = await import('node:fs/promises');
+			const outputPath = path.resolve(validatedOptions.output);
+			return {
+				filePath: outputPath,
+				previousContent: await fs
+					.readFile(outputPath, 'utf8')
+					.catch(() => undefined),
+				content: new MarkdownBuilder(rules).buildMarkdown(noHeader),
+			};
+		}
+
+		return undefined;
+	}
+
+	/**
+	 * Check that the output file is up to date without writing it
+	 * Files with a psst-ai region only compare that region. The exit code is
+	 * 1 when the file is missing or out of date, e.g. to fail a CI job.
+	 * @param rules Rules found by the scan
+	 * @param projectPath Directory the output files are written to
+	 * @param validatedOptions Command options
+	 */
+	private async checkOutput(
+		rules: AiRule[],
+		projectPath: string,
+		validatedOptions: CliOptions,
+	): Promise<void> {
+		const preview = await this.getOutputPreview(
+			rules,
+			projectPath,
+			validatedOptions,
+		);
+
+		if (!preview) {
+			throw new Error(
+				'--check needs an output file, use --file, --output or a --format other than json',
+			);
+		}
+
+		const {message, isUpToDate} = this.formatCheck(preview);
+		if (!isUpToDate) {
+			process.exitCode = 1;
+		}
+
+		if (!validatedOptions.quiet) {
+			console.log(message);
+		}
+	}
+
+	/**
+	 * Describe whether the file of a preview is up to date
+	 */
+	private formatCheck(preview: OutputPreview): {
+		message: string;
+		isUpToDate: boolean;
+	} {
+		const {filePath, previousContent, content, managedRegion} = preview;
+		const hint = 'Run psst-ai without --check to update it.';
+
+		if (previousContent === undefined) {
+			return {
+				message: `Check failed: ${filePath} does not exist. ${hint}`,
+				isUpToDate: false,
+			};
+		}
+
+		if (managedRegion?.previous !== undefined) {
+			return managedRegion.previous === managedRegion.next
+				? {message: `${filePath} is up to date`, isUpToDate: true}
+				: {
+						message: `Check failed: the psst-ai section of ${filePath} is out of date. ${hint}\n\n${formatDiff(managedRegion.previous, managedRegion.next)}`,
+						isUpToDate: false,
+					};
+		}
+
+		if (managedRegion) {
+			// Files updated with --file only get a section between their tags
+			const sectionHint =
+				previousContent === content
+					? 'Add the psst-ai start and end tags to it.'
+					: hint;
+			return {
+				message: `Check failed: ${filePath} has no psst-ai section. ${sectionHint}`,
+				isUpToDate: false,
+			};
+		}
+
+		return previousContent === content
+			? {message: `${filePath} is up to date`, isUpToDate: true}
+			: {
+					message: `Check failed: ${filePath} is out of date. ${hint}\n\n${formatDiff(previousContent, content)}`,
+					isUpToDate: false,
+				};
+	}
+
+	/**
+	 * Describe the change a preview would make to its file
+	 */
+	private formatPreview(preview: OutputPreview): string {
+		const {filePath, previousContent, content, managedRegion} = preview;
+
+		if (previousContent === undefined) {
+			return `Dry run: would create ${filePath}:\n\n${content}`;
+		}
+
+		if (previousContent === content) {
+			// Files updated with --file are only changed between their tags
+			return managedRegion && managedRegion.previous === undefined
+				? `Dry run: ${filePath} has no psst-ai section, nothing would be written`
+				: `Dry run: ${filePath} is up to date`;
+		}
+
+		if (managedRegion?.previous !== undefined) {
+			return `Dry run: would update the psst-ai section of ${filePath}:\n\n${formatDiff(managedRegion.previous, managedRegion.next)}`;
+		}
+
+		if (managedRegion) {
+			return `Dry run: would add a psst-ai section to ${filePath}:\n\n${formatDiff(previousContent, content)}`;
+		}
+
+		return `Dry run: would overwrite ${filePath}:\n\n${formatDiff(previousContent, content)}`;
+	}
+
+	/**
+	 * Scan again whenever a file read by the scanners changes
+	 * The output is only written when the rules differ from the previous scan.
+	 * The watched files are collected again after every scan, as a changed
+	 * config file can add scanners and rules that read other files.
+	 * @param scanner Scanner of the watched directory
+	 * @param initialRules Rules of the first scan
+	 * @param absolutePath Absolute path of the scanned directory
+	 * @param validatedOptions Command options
+	 */
+	private watch(
+		scanner: CodebaseScanner,
+		initialRules: AiRule[],
+		absolutePath: string,
+		validatedOptions: CliOptions,
+	): void {
+		let previousRules = initialRules;
+		let currentScanner = scanner;
+
+		// Files written by psst-ai itself must not trigger a new scan
+		const ignoredFiles = [
+			validatedOptions.output,
+			validatedOptions.file,
+			path.join(absolutePath, cacheFileName),
+		]
+			.filter((file) => file !== undefined)
+			.map((file) => path.resolve(file));
+
+		const watcher = new FileWatcher(
+			absolutePath,
+			{patterns: scanner.getWatchedFiles(), ignoredFiles},
+			async (changedFiles) => {
+				cliLogger.info(`Changed: ${changedFiles.join(', ')}`);
+
+				// A changed config file can add or remove custom scanners
+				if (
+					changedFiles.some((file) =>
+						configFileNames.includes(path.basename(file)),
+					)
+				) {
+					currentScanner = await this.createScanner(
+						absolutePath,
+						validatedOptions,
+					);
+				}
+
+				const rules = await currentScanner.scan();
+				watcher.setPatterns(currentScanner.getWatchedFiles());
+				if (!rulesDiffer(previousRules, rules)) {
+					cliLogger.info('Rules are unchanged');
+					return;
+				}
+
+				const changes = diffRules(previousRules, rules);
+				previousRules = rules;
+				await this.writeOutput(rules, absolutePath, validatedOptions);
+
+				cliLogger.info(
+					`Rules updated: ${changes.added.length} added, ${changes.removed.length} removed, ${changes.updated.length} changed`,
+				);
+				for (const rule of changes.added) {
+					cliLogger.info(`+ ${rule.rule}`);
+				}
+
+				for (const rule of changes.removed) {
+					cliLogger.info(`- ${rule.rule}`);
+				}
+			},
+		);
+
+		watcher.start();
+		cliLogger.info(`Watching ${absolutePath} for changes, press Ctrl+C to stop`);
+
+		process.once('SIGINT', () => {
+			watcher.close();
+			process.exit(0);
+		});
+	}
 }
 
 // Create and run the CLI
@@ -0,0 +1,42 @@
+// Programmatic API
+export {createScanner, scan} from './api.js';
+
+// Export types
+export type {
+	AiRule,
+	CliOptions,
+	DeclarativeRule,
+	ProjectCommand,
+	PsstConfig,
+	Rule,
+	RuleCategory,
+	ScanOptions,
+	ScanResult,
+	Scanner,
+	ScannerConstructor,
+} from './types.js';
+export {Category, CommandPurpose, Confidence, Severity} from './types.js';
+export {OutputFormat} from './types/output-format.js';
+export type {JsonOutput, JsonRule} from './types/json-output.js';
+
+// Export builders
+export {MarkdownBuilder} from './builders/markdown-builder.js';
+export {MarkdownBuilder as GithubCopilotOutputBuilder} from './builders/markdown-builder.js';
+export {ClaudeBuilder} from './builders/claude-builder.js';
+export {CopilotBuilder} from './builders/copilot-builder.js';
+export {CursorBuilder} from './builders/cursor-builder.js';
+export {JsonBuilder} from './builders/json-builder.js';
+
+// Export scanners
+export {BaseScanner} from './scanners/base/base-scanner.js';
+export {
+	CodebaseScanner,
+	type CodebaseScannerOptions,
+} from './scanners/codebase-scanner.js';
+export {LintingScanner} from './scanners/linters/linting-scanner.js';
+export {PackageManagerScanner} from './scanners/node/package-manager-scanner.js';
+
+// Export services
+export type {FileIndex} from './services/file-index.js';
+export {logger} from './services/logger.js';
+export {packageInfo} from './services/package-info.js';
@@ -0,0 +1,140 @@
+import path from 'node:path';
+import {Category, Confidence, Severity, type AiRule} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+import {readDependencies} from '../base/scanner-helpers.js';
+
+/**
+ * An accessibility tool and how it catches regressions
+ */
+type AccessibilityTool = {
+	name: string;
+	// Matches the packages of the tool in package.json
+	packagePattern: RegExp;
+	// Matches config files of the tool, which are enough to detect it
+	configFilePattern?: RegExp;
+	// How the tool catches regressions, completing "Regressions are caught by"
+	check: string;
+	// Name the detected package in the rule, for tools with several
+	// integrations
+	namePackage?: boolean;
+};
+
+/**
+ * Accessibility tools, linters first as they catch regressions earliest
+ */
+const accessibilityTools: AccessibilityTool[] = [
+	{
+		name: 'eslint-plugin-jsx-a11y',
+		packagePattern: /^eslint-plugin-jsx-a11y$/,
+		check: 'the `jsx-a11y` ESLint rules when linting',
+	},
+	{
+		name: 'eslint-plugin-vuejs-accessibility',
+		packagePattern: /^eslint-plugin-vuejs-accessibility$/,
+		check: 'the `vuejs-accessibility` ESLint rules when linting',
+	},
+	{
+		name: 'axe-core',
+		packagePattern:
+			/^(?:axe-core|@axe-core\/[\w-]+|jest-axe|vitest-axe|cypress-axe|axe-playwright)$/,
+		check: 'axe-core checks',
+		namePackage: true,
+	},
+	{
+		name: 'Storybook a11y addon',
+		packagePattern: /^@storybook\/addon-a11y$/,
+		check: 'the a11y addon of Storybook on every story',
+	},
+	{
+		name: 'Pa11y',
+		packagePattern: /^pa11y(?:-ci)?$/,
+		configFilePattern: /^\.pa11y(?:ci|rc)(?:\.json|\.js|\.cjs)?$/,
+		check: 'Pa11y audits of the pages',
+	},
+];
+
+/**
+ * Scanner to detect accessibility tooling (eslint-plugin-jsx-a11y, axe-core,
+ * the Storybook a11y addon, Pa11y), so new markup stays accessible
+ */
+export class AccessibilityScanner extends BaseScanner {
+	public readonly name = 'accessibility';
+	public readonly watchedFiles = ['package.json', '.pa11yci*', '.pa11yrc*'];
+
+	/**
+	 * Scan the project to determine which accessibility tools check the markup
+	 * All the tools are named in one rule, as they enforce the same markup
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for accessibility tools');
+
+		try {
+			const dependencies = await readDependencies(this.rootPath);
+			const files = (await this.fileIndex.getFiles()).map((file) =>
+				this.toRelative(file),
+			);
+
+			const tools = accessibilityTools
+				.map((tool) => ({
+					tool,
+					packageNames: dependencies.filter((name) =>
+						tool.packagePattern.test(name),
+					),
+					configFiles: files.filter(
+						(file) =>
+							tool.configFilePattern?.test(path.posix.basename(file)) ??
+							false,
+					),
+				}))
+				.filter(
+					({packageNames, configFiles}) =>
+						packageNames.length > 0 || configFiles.length > 0,
+				);
+
+			if (tools.length === 0) {
+				return [];
+			}
+
+			const checks = tools.map(({tool, packageNames}) =>
+				tool.namePackage && packageNames.length > 0
+					? `${tool.check} (\`${packageNames[0]}\`)`
+					: tool.check,
+			);
+			const checkList =
+				checks.length > 1
+					? `${checks.slice(0, -1).join(', ')} and ${checks.at(-1)}`
+					: checks[0];
+			const hasPackages = tools.some(
+				({packageNames}) => packageNames.length > 0,
+			);
+			const toolFiles = [
+				...(hasPackages ? ['package.json'] : []),
+				...tools.flatMap(({configFiles}) => configFiles),
+			];
+			if (!this.hasEnoughEvidence(toolFiles.map((file) => ({file})))) {
+				return [];
+			}
+
+			return [
+				{
+					category: Category.Accessibility,
+					rule: `Accessible markup is required. Use semantic elements such as \`<button>\`, \`<nav>\` and \`<label>\` instead of clickable \`<div>\`s, give images a meaningful \`alt\` text and add \`aria-*\` attributes where native elements do not describe the content. Regressions are caught by ${checkList}. Fix the reported violations instead of disabling the checks.`,
+					severity: Severity.High,
+					// Config files alone may belong to a tool that is no longer installed
+					...(hasPackages ? {} : {confidence: Confidence.Medium}),
+					files: toolFiles,
+				},
+			];
+		} catch (error) {
+			this.logger.error('Error scanning for accessibility tools', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get a path relative to the root with forward slashes
+	 */
+	private toRelative(file: string): string {
+		return path.relative(this.rootPath, file).split(path.sep).join('/');
+	}
+}
@@ -0,0 +1 @@
+export {AccessibilityScanner} from './accessibility-scanner.js';
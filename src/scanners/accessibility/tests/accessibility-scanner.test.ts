@@ -0,0 +1,53 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {AccessibilityScanner} from '../accessibility-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+import {Confidence} from '../../../types.js';
+
+describe('AccessibilityScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Tools', () => {
+		it('should name every tool in one rule', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					devDependencies: {
+						'eslint-plugin-jsx-a11y': '^6.0.0',
+						'jest-axe': '^9.0.0',
+					},
+				}),
+			});
+
+			const rules = await new AccessibilityScanner(rootPath).scan();
+
+			expect(rules).toHaveLength(1);
+			expect(rules[0].rule).toMatch(
+				'Regressions are caught by the `jsx-a11y` ESLint rules when linting and axe-core checks (`jest-axe`).',
+			);
+			expect(rules[0].confidence).toBeUndefined();
+		});
+
+		it('should be less certain with only a config file', async () => {
+			rootPath = await createFixture({'.pa11yci.json': '{"urls": []}\n'});
+
+			const rules = await new AccessibilityScanner(rootPath).scan();
+
+			expect(rules[0].rule).toMatch(
+				'Regressions are caught by Pa11y audits of the pages.',
+			);
+			expect(rules[0].confidence).toBe(Confidence.Medium);
+			expect(rules[0].files).toEqual(['.pa11yci.json']);
+		});
+
+		it('should not emit rules without an accessibility tool', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({devDependencies: {eslint: '^9.0.0'}}),
+			});
+
+			expect(await new AccessibilityScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
@@ -0,0 +1,317 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, Severity, type AiRule, type Evidence} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * Parsed information from a GraphQL Code Generator config file
+ */
+type CodegenConfig = {
+	schema?: string;
+	generatedPaths: string[];
+};
+
+/**
+ * Maximum number of schema files listed in a rule
+ */
+const maxListedFiles = 5;
+
+/**
+ * Matches type system definitions, which only appear in schema files
+ */
+const schemaDefinitionPattern =
+	/^\s*(?:extend\s+)?(?:schema|type|input|interface|union|enum|scalar)\b/m;
+
+/**
+ * Scanner to detect GraphQL schemas, clients, servers and code generation
+ */
+export class GraphQLScanner extends BaseScanner {
+	public readonly name = 'graphql';
+	public readonly watchedFiles = [
+		'package.json',
+		'*.graphql',
+		'*.gql',
+		'codegen.*',
+	];
+
+	/**
+	 * GraphQL client packages and their display names
+	 */
+	private readonly clientLibraries: Record<string, string> = {
+		'@apollo/client': 'Apollo Client',
+		urql: 'urql',
+		'@urql/core': 'urql',
+		'react-relay': 'Relay',
+		'relay-runtime': 'Relay',
+		'graphql-request': 'graphql-request',
+	};
+
+	/**
+	 * GraphQL server packages and their display names
+	 */
+	private readonly serverLibraries: Record<string, string> = {
+		'@apollo/server': 'Apollo Server',
+		'apollo-server': 'Apollo Server',
+		'apollo-server-express': 'Apollo Server',
+		'graphql-yoga': 'GraphQL Yoga',
+		'@nestjs/graphql': 'NestJS GraphQL',
+		'type-graphql': 'TypeGraphQL',
+		mercurius: 'Mercurius',
+	};
+
+	/**
+	 * GraphQL Code Generator config file names
+	 */
+	private readonly codegenFileNames = [
+		'codegen.yml',
+		'codegen.yaml',
+		'codegen.ts',
+		'codegen.js',
+		'codegen.json',
+	];
+
+	/**
+	 * Scan the project to determine if and how GraphQL is used
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for GraphQL');
+
+		try {
+			const files = await this.fileIndex.getFiles();
+			const graphqlFiles = files.filter((file) =>
+				['.graphql', '.gql'].includes(path.extname(file)),
+			);
+			const codegenFile = this.codegenFileNames.find((fileName) =>
+				existsSync(path.join(this.rootPath, fileName)),
+			);
+			const packageJson = await this.readPackageJson();
+			const clients = this.findLibraries(packageJson, this.clientLibraries);
+			const servers = this.findLibraries(packageJson, this.serverLibraries);
+			const hasCodegenDependency = this.hasDependency(
+				packageJson,
+				'@graphql-codegen/cli',
+			);
+
+			// If GraphQL is not used, don't return any recommendations
+			if (
+				graphqlFiles.length === 0 &&
+				!codegenFile &&
+				clients.length === 0 &&
+				servers.length === 0
+			) {
+				return [];
+			}
+
+			const evidence: Evidence[] = [
+				...graphqlFiles.map((file) => ({file: this.toRelative(file)})),
+				...(codegenFile ? [{file: codegenFile}] : []),
+				...(clients.length > 0 || servers.length > 0
+					? [{file: 'package.json'}]
+					: []),
+			];
+			if (!this.hasEnoughEvidence(evidence)) {
+				return [];
+			}
+
+			const recommendations: AiRule[] = [];
+
+			if (clients.length > 0) {
+				recommendations.push({
+					category: Category.API,
+					rule: `Use ${clients.join(' and ')} as the GraphQL client. Fetch data with GraphQL queries and mutations instead of adding REST calls.`,
+					severity: Severity.High,
+					files: ['package.json'],
+				});
+			}
+
+			if (servers.length > 0) {
+				recommendations.push({
+					category: Category.API,
+					rule: `The API is a GraphQL server built with ${servers.join(' and ')}. Expose new data by extending the GraphQL schema and resolvers instead of adding REST endpoints.`,
+					severity: Severity.High,
+					files: ['package.json'],
+				});
+			}
+
+			const codegenConfig = codegenFile
+				? await this.readCodegenConfig(codegenFile)
+				: undefined;
+			const schemaFiles = await this.findSchemaFiles(graphqlFiles);
+
+			if (schemaFiles.length > 0) {
+				const listedFiles = schemaFiles.slice(0, maxListedFiles);
+				const moreFiles =
+					schemaFiles.length > maxListedFiles
+						? ` and ${schemaFiles.length - maxListedFiles} more files`
+						: '';
+
+				recommendations.push({
+					category: Category.API,
+					rule: `The GraphQL schema is defined in ${listedFiles.join(', ')}${moreFiles}. Update the schema first when adding types, queries or mutations.`,
+					files: listedFiles,
+				});
+			} else if (codegenFile && codegenConfig?.schema) {
+				recommendations.push({
+					category: Category.API,
+					rule: `The GraphQL schema is loaded from ${codegenConfig.schema}. Check it for the available types, queries and mutations.`,
+					files: [codegenFile],
+				});
+			}
+
+			if (codegenFile || hasCodegenDependency) {
+				recommendations.push(
+					this.getCodegenRule(packageJson, codegenFile, codegenConfig),
+				);
+			}
+
+			return recommendations;
+		} catch (error) {
+			this.logger.error('Error scanning for GraphQL', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the rule describing GraphQL Code Generator
+	 */
+	private getCodegenRule(
+		packageJson: Record<string, unknown> | undefined,
+		codegenFile: string | undefined,
+		codegenConfig: CodegenConfig | undefined,
+	): AiRule {
+		const scripts = (packageJson?.scripts ?? {}) as Record<string, string>;
+		const codegenScript = Object.keys(scripts).find((name) =>
+			scripts[name].includes('graphql-codegen'),
+		);
+		const command = codegenScript
+			? `the \`${codegenScript}\` script`
+			: '`graphql-codegen`';
+		const generatedPaths =
+			codegenConfig && codegenConfig.generatedPaths.length > 0
+				? ` Do not edit the generated files in ${codegenConfig.generatedPaths.join(', ')} by hand.`
+				: '';
+
+		return {
+			category: Category.API,
+			rule: `GraphQL types are generated with GraphQL Code Generator. Run ${command} after changing the schema or operations instead of writing the types by hand.${generatedPaths}`,
+			severity: Severity.High,
+			files: [codegenFile ?? 'package.json'],
+		};
+	}
+
+	/**
+	 * Find the GraphQL files that define schema types rather than operations
+	 * @returns Paths relative to the scanned root
+	 */
+	private async findSchemaFiles(graphqlFiles: string[]): Promise<string[]> {
+		const schemaFiles: string[] = [];
+
+		for (const file of graphqlFiles) {
+			try {
+				// eslint-disable-next-line no-await-in-loop
+				const content = await fs.readFile(file, 'utf8');
+
+				if (schemaDefinitionPattern.test(content)) {
+					schemaFiles.push(this.toRelative(file));
+				}
+			} catch (error) {
+				this.logger.error(`Error reading ${file}`, error);
+			}
+		}
+
+		return schemaFiles;
+	}
+
+	/**
+	 * Read the schema and generated output paths of a codegen config file
+	 */
+	private async readCodegenConfig(
+		codegenFile: string,
+	): Promise<CodegenConfig | undefined> {
+		try {
+			const content = await fs.readFile(
+				path.join(this.rootPath, codegenFile),
+				'utf8',
+			);
+			const schema = /\bschema["']?\s*:\s*["']?([^"'\s,]+)/.exec(
+				content,
+			)?.[1];
+
+			// Keys under "generates" that look like paths are the generated outputs
+			const generates = content.split(/\bgenerates["']?\s*:/)[1] ?? '';
+			const generatedPaths = [
+				...generates.matchAll(
+					/^\s*["']?([^"'\s:]*[./][^"'\s:]*)["']?\s*:/gm,
+				),
+			]
+				.map((match) => match[1].replace(/^\.\//, ''))
+				.filter((generatedPath) => !generatedPath.startsWith('http'));
+
+			return {schema, generatedPaths: [...new Set(generatedPaths)]};
+		} catch (error) {
+			this.logger.error(`Error reading ${codegenFile}`, error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Find the display names of the libraries the project depends on
+	 */
+	private findLibraries(
+		packageJson: Record<string, unknown> | undefined,
+		libraries: Record<string, string>,
+	): string[] {
+		const names = Object.keys(libraries)
+			.filter((dependency) => this.hasDependency(packageJson, dependency))
+			.map((dependency) => libraries[dependency]);
+
+		return [...new Set(names)];
+	}
+
+	/**
+	 * Get a path relative to the scanned root for display
+	 */
+	private toRelative(filePath: string): string {
+		return path.relative(this.rootPath, filePath).split(path.sep).join('/');
+	}
+
+	/**
+	 * Check if package.json declares a dependency
+	 */
+	private hasDependency(
+		packageJson: Record<string, unknown> | undefined,
+		dependency: string,
+	): boolean {
+		const dependencyFields = ['dependencies', 'devDependencies'] as const;
+
+		return dependencyFields.some((field) => {
+			const dependencies = packageJson?.[field];
+			return (
+				typeof dependencies === 'object' &&
+				dependencies !== null &&
+				dependency in dependencies
+			);
+		});
+	}
+
+	/**
+	 * Read and parse package.json
+	 */
+	private async readPackageJson(): Promise<
+		Record<string, unknown> | undefined
+	> {
+		const packageJsonPath = path.join(this.rootPath, 'package.json');
+		if (!existsSync(packageJsonPath)) {
+			return undefined;
+		}
+
+		try {
+			const content = await fs.readFile(packageJsonPath, 'utf8');
+			return JSON.parse(content) as Record<string, unknown>;
+		} catch (error) {
+			this.logger.error('Error reading package.json', error);
+			return undefined;
+		}
+	}
+}
@@ -0,0 +1,2 @@
+export {GraphQLScanner} from './graphql-scanner.js';
+export {ProtobufScanner} from './protobuf-scanner.js';
@@ -0,0 +1,344 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, Severity, type AiRule, type Evidence} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * A gRPC service declared in a .proto file
+ */
+type ProtoService = {
+	name: string;
+	rpcs: string[];
+	file: string;
+};
+
+/**
+ * Maximum number of services or directories listed in a rule
+ */
+const maxListedItems = 5;
+
+/**
+ * Matches files generated by protoc plugins, e.g. user.pb.go or user_pb2.py
+ */
+const generatedFilePattern =
+	/(?:\.pb\.(?:go|cc|h|swift)|_pb2(?:_grpc)?\.pyi?|_(?:grpc_)?pb\.(?:js|ts|d\.ts))$/;
+
+/**
+ * Scanner to detect Protocol Buffers and gRPC service definitions
+ */
+export class ProtobufScanner extends BaseScanner {
+	public readonly name = 'protobuf';
+	public readonly watchedFiles = [
+		'*.proto',
+		'buf.yaml',
+		'buf.gen.yaml',
+		'buf.work.yaml',
+		'package.json',
+		'Makefile',
+	];
+
+	/**
+	 * Scan the project to determine if and how Protocol Buffers are used
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for Protocol Buffers');
+
+		try {
+			const files = await this.fileIndex.getFiles();
+			const protoFiles = files.filter((file) => file.endsWith('.proto'));
+			const hasBufConfig = existsSync(path.join(this.rootPath, 'buf.yaml'));
+			const hasBufGenerate = existsSync(
+				path.join(this.rootPath, 'buf.gen.yaml'),
+			);
+
+			// If no Protocol Buffers are found, don't return any recommendations
+			if (protoFiles.length === 0 && !hasBufConfig && !hasBufGenerate) {
+				return [];
+			}
+
+			const evidence: Evidence[] = [
+				...protoFiles.map((file) => ({file: this.toRelative(file)})),
+				...(hasBufConfig ? [{file: 'buf.yaml'}] : []),
+				...(hasBufGenerate ? [{file: 'buf.gen.yaml'}] : []),
+			];
+			if (!this.hasEnoughEvidence(evidence)) {
+				return [];
+			}
+
+			const recommendations: AiRule[] = [];
+			const services = await this.parseServices(protoFiles);
+			const relativeProtoFiles = protoFiles.map((file) =>
+				this.toRelative(file),
+			);
+
+			if (services.length > 0) {
+				recommendations.push({
+					category: Category.API,
+					rule: `The project defines gRPC services in Protocol Buffers: ${this.describeServices(services)}. Change the .proto definitions first when adding or changing RPCs, and keep them backwards compatible.`,
+					severity: Severity.High,
+					files: [...new Set(services.map((service) => service.file))],
+				});
+			} else if (protoFiles.length > 0) {
+				recommendations.push({
+					category: Category.API,
+					rule: `Protocol Buffers messages are defined in ${this.listDirectories(relativeProtoFiles)}. Change the .proto definitions first when changing shared messages.`,
+					files: relativeProtoFiles.slice(0, maxListedItems),
+				});
+			}
+
+			const generatedDirectories = this.removeNestedDirectories([
+				...(hasBufGenerate ? await this.readBufOutputs() : []),
+				...files
+					.filter((file) => generatedFilePattern.test(path.basename(file)))
+					.map((file) => path.posix.dirname(this.toRelative(file))),
+			]);
+			const generateCommand = hasBufGenerate
+				? '`buf generate`'
+				: await this.findProtocCommand();
+			const generatedLocation =
+				generatedDirectories.length > 0
+					? ` (${this.listDirectories(generatedDirectories, true)})`
+					: '';
+			const regenerate = generateCommand
+				? ` Regenerate it with ${generateCommand} after changing a .proto file.`
+				: ' Regenerate it after changing a .proto file.';
+
+			recommendations.push({
+				category: Category.API,
+				rule: `Code generated from .proto files${generatedLocation} must not be edited by hand.${regenerate}`,
+				severity: Severity.High,
+				...(hasBufGenerate ? {files: ['buf.gen.yaml']} : {}),
+			});
+
+			if (hasBufConfig) {
+				recommendations.push(await this.getBufRule(hasBufGenerate));
+			}
+
+			return recommendations;
+		} catch (error) {
+			this.logger.error('Error scanning for Protocol Buffers', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Parse the services and their RPCs declared in .proto files
+	 */
+	private async parseServices(protoFiles: string[]): Promise<ProtoService[]> {
+		const services: ProtoService[] = [];
+
+		for (const protoFile of protoFiles) {
+			try {
+				// eslint-disable-next-line no-await-in-loop
+				const content = await fs.readFile(protoFile, 'utf8');
+				services.push(
+					...this.parseProto(content, this.toRelative(protoFile)),
+				);
+			} catch (error) {
+				this.logger.error(`Error reading ${protoFile}`, error);
+			}
+		}
+
+		return services;
+	}
+
+	/**
+	 * Parse the service and rpc declarations of a .proto file
+	 * RPCs may have an options block, so the service body is found by
+	 * matching braces rather than up to the first closing brace
+	 */
+	private parseProto(content: string, file: string): ProtoService[] {
+		const source = content
+			.replaceAll(/\/\*[\s\S]*?\*\//g, '')
+			.replaceAll(/\/\/.*$/gm, '');
+		const services: ProtoService[] = [];
+
+		for (const match of source.matchAll(/\bservice\s+(\w+)\s*\{/g)) {
+			const bodyStart = match.index + match[0].length;
+			let depth = 1;
+			let bodyEnd = bodyStart;
+
+			while (bodyEnd < source.length && depth > 0) {
+				if (source[bodyEnd] === '{') {
+					depth++;
+				} else if (source[bodyEnd] === '}') {
+					depth--;
+				}
+
+				bodyEnd++;
+			}
+
+			const body = source.slice(bodyStart, bodyEnd - 1);
+			const rpcs = [...body.matchAll(/\brpc\s+(\w+)\s*\(/g)].map(
+				(rpcMatch) => rpcMatch[1],
+			);
+
+			services.push({name: match[1], rpcs, file});
+		}
+
+		return services;
+	}
+
+	/**
+	 * Describe services with their RPCs, e.g. "UserService (GetUser, ListUsers)"
+	 */
+	private describeServices(services: ProtoService[]): string {
+		const described = services
+			.slice(0, maxListedItems)
+			.map((service) =>
+				service.rpcs.length > 0
+					? `${service.name} (${service.rpcs.join(', ')})`
+					: service.name,
+			)
+			.join(', ');
+		const more =
+			services.length > maxListedItems
+				? ` and ${services.length - maxListedItems} more services`
+				: '';
+
+		return `${described}${more}`;
+	}
+
+	/**
+	 * List the unique directories of files relative to the root
+	 * @param paths Relative file paths, or directories when isDirectory is set
+	 * @param isDirectory If the paths are already directories
+	 */
+	private listDirectories(paths: string[], isDirectory = false): string {
+		const directories = [
+			...new Set(
+				paths.map((relativePath) =>
+					isDirectory ? relativePath : path.posix.dirname(relativePath),
+				),
+			),
+		].map((directory) =>
+			directory === '.' ? 'the project root' : `${directory}/`,
+		);
+		const more =
+			directories.length > maxListedItems
+				? ` and ${directories.length - maxListedItems} more directories`
+				: '';
+
+		return `${directories.slice(0, maxListedItems).join(', ')}${more}`;
+	}
+
+	/**
+	 * Remove directories located inside another directory of the list
+	 */
+	private removeNestedDirectories(directories: string[]): string[] {
+		const uniqueDirectories = [...new Set(directories)];
+
+		return uniqueDirectories.filter(
+			(directory) =>
+				!uniqueDirectories.some(
+					(other) =>
+						other !== directory &&
+						(other === '.' || directory.startsWith(`${other}/`)),
+				),
+		);
+	}
+
+	/**
+	 * Read the output directories of the buf.gen.yaml plugins
+	 */
+	private async readBufOutputs(): Promise<string[]> {
+		try {
+			const content = await fs.readFile(
+				path.join(this.rootPath, 'buf.gen.yaml'),
+				'utf8',
+			);
+
+			return [...content.matchAll(/^\s*out:\s*["']?([^"'\s#]+)/gm)].map(
+				(match) => match[1].replace(/^\.\//, '').replace(/\/+$/, ''),
+			);
+		} catch (error) {
+			this.logger.error('Error reading buf.gen.yaml', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Find the command that runs protoc, a package.json script or a Makefile
+	 * target
+	 */
+	private async findProtocCommand(): Promise<string | undefined> {
+		const packageJson = await this.readFile('package.json');
+		if (packageJson) {
+			try {
+				const scripts = ((JSON.parse(packageJson) as Record<string, unknown>)
+					.scripts ?? {}) as Record<string, string>;
+				const script = Object.keys(scripts).find((name) =>
+					/\b(?:protoc|buf generate)\b/.test(scripts[name]),
+				);
+
+				if (script) {
+					return `the \`${script}\` script`;
+				}
+			} catch (error) {
+				this.logger.error('Error parsing package.json', error);
+			}
+		}
+
+		const makefile = await this.readFile('Makefile');
+		if (makefile) {
+			let target: string | undefined;
+
+			for (const line of makefile.split('\n')) {
+				const targetMatch = /^([\w.-]+)\s*:(?!=)/.exec(line);
+				if (targetMatch) {
+					target = targetMatch[1];
+				} else if (
+					target &&
+					line.startsWith('\t') &&
+					line.includes('protoc')
+				) {
+					return `\`make ${target}\``;
+				}
+			}
+		}
+
+		return undefined;
+	}
+
+	/**
+	 * Get the rule describing Buf linting and breaking change detection
+	 */
+	private async getBufRule(hasBufGenerate: boolean): Promise<AiRule> {
+		const content = (await this.readFile('buf.yaml')) ?? '';
+		const tasks = hasBufGenerate ? 'code generation and linting' : 'linting';
+		const breaking = /^breaking:/m.test(content)
+			? ' and `buf breaking` to check for breaking changes'
+			: '';
+
+		return {
+			category: Category.API,
+			rule: `Buf is used for Protocol Buffers ${tasks}. Run \`buf lint\`${breaking} after changing .proto files.`,
+			files: hasBufGenerate ? ['buf.yaml', 'buf.gen.yaml'] : ['buf.yaml'],
+		};
+	}
+
+	/**
+	 * Get a path relative to the scanned root for display
+	 */
+	private toRelative(filePath: string): string {
+		return path.relative(this.rootPath, filePath).split(path.sep).join('/');
+	}
+
+	/**
+	 * Read a file of the project, undefined if it does not exist
+	 */
+	private async readFile(fileName: string): Promise<string | undefined> {
+		const filePath = path.join(this.rootPath, fileName);
+		if (!existsSync(filePath)) {
+			return undefined;
+		}
+
+		try {
+			return await fs.readFile(filePath, 'utf8');
+		} catch (error) {
+			this.logger.error(`Error reading ${fileName}`, error);
+			return undefined;
+		}
+	}
+}
@@ -0,0 +1,84 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {GraphQLScanner} from '../graphql-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+const codegenConfig = `const config = {
+  schema: 'schema.graphql',
+  generates: {
+    './src/gql/': {preset: 'client'},
+  },
+};
+export default config;
+`;
+
+describe('GraphQLScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Libraries', () => {
+		it('should name the GraphQL client and server', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					dependencies: {
+						'@apollo/client': '^3.10.0',
+						'@apollo/server': '^4.0.0',
+					},
+				}),
+			});
+
+			const rules = await new GraphQLScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Use Apollo Client as the GraphQL client. Fetch data with GraphQL queries and mutations instead of adding REST calls.',
+				'The API is a GraphQL server built with Apollo Server. Expose new data by extending the GraphQL schema and resolvers instead of adding REST endpoints.',
+			]);
+		});
+
+		it('should not emit rules for projects without GraphQL', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {express: '^4.0.0'}}),
+			});
+
+			expect(await new GraphQLScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+
+	describe('Schema', () => {
+		it('should point to schema files but not to operations', async () => {
+			rootPath = await createFixture({
+				'schema.graphql': 'type Query {\n  user(id: ID!): User\n}\n',
+				'src/queries/user.graphql':
+					'query User($id: ID!) {\n  user(id: $id) {\n    id\n  }\n}\n',
+			});
+
+			const rules = await new GraphQLScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'The GraphQL schema is defined in schema.graphql. Update the schema first when adding types, queries or mutations.',
+			]);
+		});
+	});
+
+	describe('Code generation', () => {
+		it('should name the codegen script and the generated files', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					devDependencies: {'@graphql-codegen/cli': '^5.0.0'},
+					scripts: {codegen: 'graphql-codegen'},
+				}),
+				'codegen.ts': codegenConfig,
+				'schema.graphql': 'type Query {\n  ok: Boolean\n}\n',
+			});
+
+			const rules = await new GraphQLScanner(rootPath).scan();
+
+			expect(rules.at(-1)?.rule).toBe(
+				'GraphQL types are generated with GraphQL Code Generator. Run the `codegen` script after changing the schema or operations instead of writing the types by hand. Do not edit the generated files in src/gql/ by hand.',
+			);
+			expect(rules.at(-1)?.files).toEqual(['codegen.ts']);
+		});
+	});
+});
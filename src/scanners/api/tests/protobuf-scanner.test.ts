@@ -0,0 +1,63 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {ProtobufScanner} from '../protobuf-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+const userProto = `syntax = "proto3";
+
+package user.v1;
+
+service UserService {
+  rpc GetUser(GetUserRequest) returns (User);
+  rpc ListUsers(ListUsersRequest) returns (ListUsersResponse);
+}
+`;
+
+describe('ProtobufScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Services', () => {
+		it('should list the gRPC services and generate with Buf', async () => {
+			rootPath = await createFixture({
+				'buf.yaml': 'version: v2\nbreaking:\n  use:\n    - FILE\n',
+				'buf.gen.yaml':
+					'version: v2\nplugins:\n  - remote: buf.build/protocolbuffers/go\n    out: gen/go\n',
+				'proto/user/v1/user.proto': userProto,
+			});
+
+			const rules = await new ProtobufScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'The project defines gRPC services in Protocol Buffers: UserService (GetUser, ListUsers). Change the .proto definitions first when adding or changing RPCs, and keep them backwards compatible.',
+				'Code generated from .proto files (gen/go/) must not be edited by hand. Regenerate it with `buf generate` after changing a .proto file.',
+				'Buf is used for Protocol Buffers code generation and linting. Run `buf lint` and `buf breaking` to check for breaking changes after changing .proto files.',
+			]);
+		});
+	});
+
+	describe('Messages', () => {
+		it('should find the generated code next to the messages', async () => {
+			rootPath = await createFixture({
+				'proto/events.proto':
+					'syntax = "proto3";\n\nmessage Event {\n  string id = 1;\n}\n',
+				'gen/events.pb.go': 'package gen\n',
+			});
+
+			const rules = await new ProtobufScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Protocol Buffers messages are defined in proto/. Change the .proto definitions first when changing shared messages.',
+				'Code generated from .proto files (gen/) must not be edited by hand. Regenerate it after changing a .proto file.',
+			]);
+		});
+
+		it('should not emit rules without .proto files', async () => {
+			rootPath = await createFixture({'gen/events.pb.go': 'package gen\n'});
+
+			expect(await new ProtobufScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
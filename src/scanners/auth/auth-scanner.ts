@@ -0,0 +1,274 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, Confidence, Severity, type AiRule} from '../../types.js';
+import {getMajorVersion} from '../../utils/version.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * A hosted authentication provider and the packages of its SDKs
+ */
+type AuthProvider = {
+	name: string;
+	// Packages of the provider, by precedence, and how to read the user with
+	// each of them
+	packages: Record<string, string>;
+	// Packages that are also used without the auth features of the provider
+	sharedPackages?: string[];
+};
+
+/**
+ * Authentication providers detected from their SDK packages
+ * NextAuth/Auth.js is detected separately, as its API depends on the version
+ */
+const authProviders: AuthProvider[] = [
+	{
+		name: 'Clerk',
+		packages: {
+			'@clerk/nextjs':
+				'Read the user with the `useUser()` and `useAuth()` hooks in client components and with `auth()` or `currentUser()` from `@clerk/nextjs/server` on the server, and protect routes with `clerkMiddleware()`.',
+			'@clerk/remix':
+				'Read the user with the `useUser()` and `useAuth()` hooks in components and with `getAuth()` in loaders and actions.',
+			'@clerk/clerk-react':
+				'Read the user with the `useUser()` and `useAuth()` hooks.',
+			'@clerk/clerk-expo':
+				'Read the user with the `useUser()` and `useAuth()` hooks.',
+			'@clerk/express':
+				'Read the user with `getAuth(req)` and protect routes with `requireAuth()`.',
+		},
+	},
+	{
+		name: 'Auth0',
+		packages: {
+			'@auth0/nextjs-auth0':
+				'Read the user with the `useUser()` hook in client components and with `auth0.getSession()` on the server.',
+			'@auth0/auth0-react':
+				'Read the user with the `useAuth0()` hook inside `Auth0Provider`.',
+			'@auth0/auth0-vue': 'Read the user with the `useAuth0()` composable.',
+			'express-openid-connect':
+				'Read the user from `req.oidc.user` and protect routes with `requiresAuth()`.',
+			'@auth0/auth0-spa-js':
+				'Read the user with `getUser()` of the `Auth0Client`.',
+		},
+	},
+	{
+		name: 'Supabase Auth',
+		packages: {
+			'@supabase/ssr':
+				'Create clients with `createServerClient()` on the server and `createBrowserClient()` in the browser, and read the user with `supabase.auth.getUser()`.',
+			'@supabase/auth-helpers-nextjs':
+				'Read the user with `supabase.auth.getUser()` of the clients created by the auth helpers.',
+			'@supabase/supabase-js':
+				'Read the user with `supabase.auth.getUser()` and listen to sign-ins with `supabase.auth.onAuthStateChange()`.',
+		},
+		sharedPackages: ['@supabase/supabase-js'],
+	},
+	{
+		name: 'Firebase Auth',
+		packages: {
+			'@react-native-firebase/auth':
+				'Read the user with `auth().currentUser` and listen to sign-ins with `auth().onAuthStateChanged()`.',
+			firebase:
+				'Read the user with `getAuth().currentUser` and listen to sign-ins with `onAuthStateChanged()` from `firebase/auth`.',
+			'firebase-admin':
+				'Verify ID tokens on the server with `getAuth().verifyIdToken()` from `firebase-admin/auth`.',
+		},
+		sharedPackages: ['firebase', 'firebase-admin'],
+	},
+];
+
+/**
+ * Matches the Auth.js config file, e.g. auth.ts or src/auth.js
+ */
+const authConfigPattern = /^(?:src\/)?auth\.(?:ts|js|mjs)$/;
+
+/**
+ * Matches the NextAuth API route, e.g. app/api/auth/[...nextauth]/route.ts
+ */
+const nextAuthRoutePattern = /(?:^|\/)api\/auth\/\[\.\.\.nextauth]/;
+
+/**
+ * Scanner to detect authentication providers (NextAuth/Auth.js, Clerk, Auth0,
+ * Supabase Auth, Firebase Auth) and the APIs to read sessions and users with
+ */
+export class AuthScanner extends BaseScanner {
+	public readonly name = 'auth';
+	public readonly watchedFiles = [
+		'package.json',
+		'package-lock.json',
+		'pnpm-lock.yaml',
+		'yarn.lock',
+		'bun.lock',
+	];
+	public readonly versionedDependencies = ['next-auth'];
+
+	/**
+	 * Scan the project to determine which authentication providers are used
+	 * Several providers get one rule each, as the primary one can't be told
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for authentication providers');
+
+		try {
+			const packageJson = await this.readPackageJson();
+			const recommendations: AiRule[] = [];
+
+			const nextAuthRule = await this.getNextAuthRule(packageJson);
+			if (nextAuthRule) {
+				recommendations.push(nextAuthRule);
+			}
+
+			for (const provider of authProviders) {
+				const rule = this.getProviderRule(provider, packageJson);
+				if (rule) {
+					recommendations.push(rule);
+				}
+			}
+
+			return this.hasEnoughEvidence(this.getRuleEvidence(recommendations))
+				? recommendations
+				: [];
+		} catch (error) {
+			this.logger.error('Error scanning for authentication providers', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the rule for NextAuth/Auth.js, which changed its API in v5
+	 */
+	private async getNextAuthRule(
+		packageJson: Record<string, unknown> | undefined,
+	): Promise<AiRule | undefined> {
+		const files = (await this.fileIndex.getFiles()).map((file) =>
+			this.toRelative(file),
+		);
+		const configFile = files.find((file) => authConfigPattern.test(file));
+		const routeFile = files.find((file) => nextAuthRoutePattern.test(file));
+		const hasNextAuth = this.hasDependency(packageJson, 'next-auth');
+		const authJsPackage = hasNextAuth
+			? undefined
+			: this.getDependencies(packageJson).find((dependency) =>
+					dependency.startsWith('@auth/'),
+				);
+
+		// If neither package nor API route is found, NextAuth is not used
+		if (!hasNextAuth && !authJsPackage && !routeFile) {
+			return undefined;
+		}
+
+		const sourceFiles = [configFile, routeFile].filter(
+			(file) => file !== undefined,
+		);
+		const location = configFile ? `, configured in \`${configFile}\`` : '';
+		const baseRule = {
+			category: Category.Authentication,
+			severity: Severity.High,
+			files: [...(packageJson ? ['package.json'] : []), ...sourceFiles],
+		};
+
+		if (authJsPackage) {
+			return {
+				...baseRule,
+				rule: `Authentication uses Auth.js (\`${authJsPackage}\`)${location}. Read the session with the helpers of \`${authJsPackage}\` instead of parsing session cookies by hand.`,
+			};
+		}
+
+		const version = await this.resolveDependencyVersion('next-auth');
+		const major = version ? getMajorVersion(version) : undefined;
+
+		// Auth.js v5 is configured in auth.ts, v4 with authOptions in the route
+		if (major === undefined ? configFile !== undefined : major >= 5) {
+			return {
+				...baseRule,
+				rule: `Authentication uses Auth.js (NextAuth v5)${location}. Read the session with \`auth()\` on the server and the \`useSession()\` hook from \`next-auth/react\` in client components, and sign in and out with \`signIn()\` and \`signOut()\`.`,
+			};
+		}
+
+		const versionLabel = major === undefined ? '' : ` v${major}`;
+
+		return {
+			...baseRule,
+			rule: `Authentication uses NextAuth.js${versionLabel}. Read the session with \`getServerSession(authOptions)\` on the server and the \`useSession()\` hook from \`next-auth/react\` in client components.`,
+		};
+	}
+
+	/**
+	 * Get the rule for a provider detected from its SDK packages
+	 * Packages also used without auth only give a medium confidence
+	 */
+	private getProviderRule(
+		provider: AuthProvider,
+		packageJson: Record<string, unknown> | undefined,
+	): AiRule | undefined {
+		const packageName = Object.keys(provider.packages).find((name) =>
+			this.hasDependency(packageJson, name),
+		);
+		if (!packageName) {
+			return undefined;
+		}
+
+		const isShared = provider.sharedPackages?.includes(packageName) ?? false;
+
+		return {
+			category: Category.Authentication,
+			rule: `Authentication uses ${provider.name} (\`${packageName}\`). ${provider.packages[packageName]} Do not implement sessions or password handling by hand.`,
+			severity: Severity.High,
+			...(isShared ? {confidence: Confidence.Medium} : {}),
+			files: ['package.json'],
+		};
+	}
+
+	/**
+	 * Get the names of the dependencies and dev dependencies of package.json
+	 */
+	private getDependencies(
+		packageJson: Record<string, unknown> | undefined,
+	): string[] {
+		const dependencyFields = ['dependencies', 'devDependencies'] as const;
+
+		return dependencyFields.flatMap((field) => {
+			const dependencies = packageJson?.[field];
+			return typeof dependencies === 'object' && dependencies !== null
+				? Object.keys(dependencies)
+				: [];
+		});
+	}
+
+	/**
+	 * Check if package.json declares a dependency or dev dependency
+	 */
+	private hasDependency(
+		packageJson: Record<string, unknown> | undefined,
+		dependency: string,
+	): boolean {
+		return this.getDependencies(packageJson).includes(dependency);
+	}
+
+	/**
+	 * Get a path relative to the root with forward slashes
+	 */
+	private toRelative(file: string): string {
+		return path.relative(this.rootPath, file).split(path.sep).join('/');
+	}
+
+	/**
+	 * Read and parse package.json
+	 */
+	private async readPackageJson(): Promise<
+		Record<string, unknown> | undefined
+	> {
+		const packageJsonPath = path.join(this.rootPath, 'package.json');
+		if (!existsSync(packageJsonPath)) {
+			return undefined;
+		}
+
+		try {
+			const content = await fs.readFile(packageJsonPath, 'utf8');
+			return JSON.parse(content) as Record<string, unknown>;
+		} catch (error) {
+			this.logger.error('Error reading package.json', error);
+			return undefined;
+		}
+	}
+}
@@ -0,0 +1 @@
+export {AuthScanner} from './auth-scanner.js';
@@ -0,0 +1,71 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {AuthScanner} from '../auth-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+import {Confidence} from '../../../types.js';
+
+describe('AuthScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('NextAuth', () => {
+		it('should use the v4 API for NextAuth 4', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					dependencies: {'next-auth': '^4.24.0'},
+				}),
+				'app/api/auth/[...nextauth]/route.ts': 'export {handler as GET};\n',
+			});
+
+			const rules = await new AuthScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Authentication uses NextAuth.js v4. Read the session with `getServerSession(authOptions)` on the server and the `useSession()` hook from `next-auth/react` in client components.',
+			]);
+			expect(rules[0].files).toEqual([
+				'package.json',
+				'app/api/auth/[...nextauth]/route.ts',
+			]);
+		});
+
+		it('should use the Auth.js API for NextAuth 5', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					dependencies: {'next-auth': '5.0.0-beta.20'},
+				}),
+				'auth.ts': 'export const {auth} = NextAuth({});\n',
+			});
+
+			const rules = await new AuthScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Authentication uses Auth.js (NextAuth v5), configured in `auth.ts`. Read the session with `auth()` on the server and the `useSession()` hook from `next-auth/react` in client components, and sign in and out with `signIn()` and `signOut()`.',
+			]);
+		});
+	});
+
+	describe('Providers', () => {
+		it('should be less certain of packages also used without auth', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					dependencies: {'@supabase/supabase-js': '^2.0.0'},
+				}),
+			});
+
+			const rules = await new AuthScanner(rootPath).scan();
+
+			expect(rules).toHaveLength(1);
+			expect(rules[0].confidence).toBe(Confidence.Medium);
+		});
+
+		it('should not emit rules without an auth package', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {react: '^19.0.0'}}),
+			});
+
+			expect(await new AuthScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
@@ -1,17 +1,70 @@
+import {DependencyResolver} from '../../services/dependency-resolver.js';
+import {FileIndex} from '../../services/file-index.js';
 import {logger} from '../../services/logger.js';
 import type {AiRule} from '../../types.js';
+import {Confidence} from '../../types/confidence.js';
+import type {Evidence} from '../../types/evidence.js';
+import type {Scanner} from './scanner.js';
 
 /**
  * Base scanner class that all scanners should extend
  */
-export abstract class BaseScanner {
+export abstract class BaseScanner implements Scanner {
+	/**
+	 * Stable identifier used to enable or disable the scanner, e.g. "docker"
+	 */
+	public abstract readonly name: string;
+
+	/**
+	 * Names of the files the scanner reads, "*" matches any characters
+	 * Watch mode scans again when one of these files changes
+	 */
+	public readonly watchedFiles: string[] = [];
+
+	/**
+	 * Whether the rules can be reused while the watched files in the file index
+	 * are unchanged, false for scanners reading files the index skips
+	 */
+	public readonly cacheable: boolean = true;
+
+	/**
+	 * Names of the npm packages whose versions the scanner resolves, so the
+	 * cached rules are not reused after installing another version
+	 */
+	public readonly versionedDependencies: string[] = [];
+
+	/**
+	 * Confidence of the rules that do not set their own
+	 * Scanners relying on heuristics use a lower confidence, and rules can set
+	 * their own confidence to override it
+	 */
+	public readonly confidence: number = Confidence.Certain;
+
+	/**
+	 * Evidence required by --min-evidence, overriding the default of the scanner
+	 */
+	public minEvidence: number | undefined;
+
+	/**
+	 * Evidence the scanner requires before emitting rules, see
+	 * hasEnoughEvidence. Scanners detecting a stack from files that can be
+	 * stray copies, e.g. an example Dockerfile, require more than one file.
+	 */
+	protected readonly defaultMinEvidence: number = 1;
+
 	protected readonly logger = logger.getLogger(this.constructor.name);
+	private dependencyResolver: DependencyResolver | undefined;
 
 	/**
 	 * Constructor for BaseScanner
 	 * @param rootPath Path to scan (defaults to current directory)
+	 * @param fileIndex Shared index of the project files, so the directory tree
+	 * is only traversed once when running several scanners
 	 */
-	constructor(protected readonly rootPath: string) {
+	constructor(
+		protected readonly rootPath: string,
+		protected readonly fileIndex: FileIndex = new FileIndex(rootPath),
+	) {
 		this.logger.debug(
 			`${this.constructor.name} initialized with path: ${this.rootPath}`,
 		);
@@ -21,4 +74,54 @@ export abstract class BaseScanner {
 	 * Run the scanner and return recommendations
 	 */
 	public abstract scan(): Promise<AiRule[]>;
+
+	/**
+	 * Check if the evidence a scanner collected is enough to emit its rules
+	 * Scanners collect all their evidence first and then decide, so a single
+	 * stray file does not trigger the rules of a whole stack
+	 * @param evidence Files pointing to the detected technology
+	 * @returns True if the weights of the evidence reach the threshold
+	 */
+	protected hasEnoughEvidence(evidence: Evidence[]): boolean {
+		const threshold = this.minEvidence ?? this.defaultMinEvidence;
+		const total = evidence.reduce((sum, item) => sum + (item.weight ?? 1), 0);
+
+		if (total < threshold) {
+			if (evidence.length === 0) {
+				return false;
+			}
+
+			this.logger.debug(
+				`Skipping rules, evidence ${total} is below ${threshold}: ${evidence.map((item) => item.file).join(', ')}`,
+			);
+			return false;
+		}
+
+		return true;
+	}
+
+	/**
+	 * Get the files the rules of a scanner were derived from as evidence, for
+	 * scanners whose rules list the files they found
+	 * @param rules Rules of the scanner
+	 * @returns Each file once, with the default weight
+	 */
+	protected getRuleEvidence(rules: AiRule[]): Evidence[] {
+		const files = new Set(rules.flatMap((rule) => rule.files ?? []));
+		return [...files].map((file) => ({file}));
+	}
+
+	/**
+	 * Resolve the version of an npm dependency of the project
+	 * The version installed in node_modules or locked in the lock file is
+	 * preferred over the range declared in package.json
+	 * @param dependencyName Name of the npm package
+	 * @returns The version or declared range, undefined if it is not a dependency
+	 */
+	protected async resolveDependencyVersion(
+		dependencyName: string,
+	): Promise<string | undefined> {
+		this.dependencyResolver ??= new DependencyResolver(this.rootPath);
+		return this.dependencyResolver.resolveVersion(dependencyName);
+	}
 }
@@ -0,0 +1,59 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {logger} from '../../services/logger.js';
+
+const helperLogger = logger.getLogger('ScannerHelpers');
+
+/**
+ * Matches source files that can import packages
+ */
+export const sourceFilePattern = /\.[cm]?[jt]sx?$/;
+
+/**
+ * Watched files of the scanners that read source files, e.g. to find the
+ * files importing a package
+ */
+export const sourceFileGlobs = [
+	'*.js',
+	'*.jsx',
+	'*.ts',
+	'*.tsx',
+	'*.mjs',
+	'*.cjs',
+];
+
+/**
+ * Escape the special characters of a regular expression
+ */
+export function escapeRegex(value: string): string {
+	return value.replaceAll(/[.*+?^${}()|[\]\\/]/g, '\\$&');
+}
+
+/**
+ * Read the names of the dependencies and dev dependencies of a project
+ * @param rootPath Directory of the package.json file
+ * @returns The package names, empty without a readable package.json
+ */
+export async function readDependencies(rootPath: string): Promise<string[]> {
+	const packageJsonPath = path.join(rootPath, 'package.json');
+	if (!existsSync(packageJsonPath)) {
+		return [];
+	}
+
+	try {
+		const content = await fs.readFile(packageJsonPath, 'utf8');
+		const packageJson = JSON.parse(content) as {
+			dependencies?: Record<string, string>;
+			devDependencies?: Record<string, string>;
+		};
+
+		return [
+			...Object.keys(packageJson.dependencies ?? {}),
+			...Object.keys(packageJson.devDependencies ?? {}),
+		];
+	} catch (error) {
+		helperLogger.error('Error reading package.json', error);
+		return [];
+	}
+}
@@ -0,0 +1,49 @@
+import type {FileIndex} from '../../services/file-index.js';
+import type {AiRule} from '../../types.js';
+
+/**
+ * Minimal interface of a scanner, implemented by BaseScanner
+ * Custom scanners from a config file only need to implement this interface
+ */
+export type Scanner = {
+	/**
+	 * Identifier used to enable or disable the scanner, defaults to the class
+	 * name
+	 */
+	readonly name?: string;
+
+	/**
+	 * Names of the files the scanner reads, "*" matches any characters
+	 */
+	readonly watchedFiles?: string[];
+
+	/**
+	 * Whether the rules can be reused while the watched files in the file index
+	 * are unchanged, false for scanners reading files the index skips
+	 */
+	readonly cacheable?: boolean;
+
+	/**
+	 * Names of the npm packages whose resolved versions the rules depend on,
+	 * their installed package.json files are part of the cache fingerprint
+	 */
+	readonly versionedDependencies?: string[];
+
+	/**
+	 * Confidence of the rules that do not set their own, from 0 to 1
+	 */
+	readonly confidence?: number;
+
+	/**
+	 * Run the scanner and return recommendations
+	 */
+	scan(): Promise<AiRule[]>;
+};
+
+/**
+ * A scanner class, constructed for each scanned directory
+ */
+export type ScannerConstructor = new (
+	rootPath: string,
+	fileIndex: FileIndex,
+) => Scanner;
@@ -0,0 +1 @@
+export {MakefileScanner} from './makefile-scanner.js';
@@ -0,0 +1,162 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, CommandPurpose, type AiRule} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * File names make looks for, in the order make tries them
+ */
+const makefileNames = ['GNUmakefile', 'makefile', 'Makefile'];
+
+/**
+ * Matches the targets of a rule, e.g. "build test:" but not "VERSION := 1"
+ */
+const targetLinePattern =
+	/^([A-Za-z\d][\w-]*(?:[ \t]+[A-Za-z\d][\w-]*)*)[ \t]*:{1,2}(?![:=])/gm;
+
+/**
+ * Target names by the purpose of the command they run
+ */
+const targetPurposes: Array<[CommandPurpose, RegExp]> = [
+	[CommandPurpose.Dev, /^(?:dev|watch)$/],
+	[CommandPurpose.Start, /^(?:run|start|serve)$/],
+	[CommandPurpose.Build, /^(?:build|compile|dist|bundle)$/],
+	[CommandPurpose.Test, /^(?:test|tests|check)$/],
+	[CommandPurpose.Lint, /^(?:lint|vet)$/],
+	[CommandPurpose.Fix, /^(?:fix|lint-fix)$/],
+	[CommandPurpose.Format, /^(?:fmt|format)$/],
+	[CommandPurpose.Typecheck, /^(?:typecheck|type-check|types)$/],
+];
+
+/**
+ * Maximum number of other targets listed in a rule
+ */
+const maxListedTargets = 10;
+
+/**
+ * Scanner to detect the targets of the Makefile, so agents run the build,
+ * test and lint steps through make
+ */
+export class MakefileScanner extends BaseScanner {
+	public readonly name = 'makefile';
+	public readonly watchedFiles = makefileNames;
+
+	/**
+	 * Scan the Makefile to determine which targets it defines
+	 * Targets with a well-known purpose are collected into the Commands rules,
+	 * the other targets are listed in one rule
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for Makefile targets');
+
+		try {
+			const makefile = await this.findMakefile();
+			if (!makefile || !this.hasEnoughEvidence([{file: makefile.name}])) {
+				return [];
+			}
+
+			const recommendations: AiRule[] = [];
+			const otherTargets: string[] = [];
+
+			for (const target of this.getTargets(makefile.content)) {
+				const purpose = targetPurposes.find(([, pattern]) =>
+					pattern.test(target),
+				)?.[0];
+
+				if (purpose) {
+					const command = `make ${target}`;
+					recommendations.push({
+						category: Category.Commands,
+						rule: `Run \`${command}\`.`,
+						files: [makefile.name],
+						commands: [{command, purpose}],
+					});
+				} else {
+					otherTargets.push(target);
+				}
+			}
+
+			if (otherTargets.length > 0) {
+				recommendations.push(
+					this.getTargetsRule(
+						makefile.name,
+						otherTargets,
+						recommendations.length > 0,
+					),
+				);
+			}
+
+			return recommendations;
+		} catch (error) {
+			this.logger.error('Error scanning for Makefile targets', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the rule listing the targets without a well-known purpose
+	 * @param file Name of the Makefile
+	 * @param targets Targets to list
+	 * @param hasCommands Whether other targets are listed as Commands rules
+	 */
+	private getTargetsRule(
+		file: string,
+		targets: string[],
+		hasCommands: boolean,
+	): AiRule {
+		const names = targets
+			.slice(0, maxListedTargets)
+			.map((target) => `\`${target}\``);
+		const more =
+			targets.length > maxListedTargets
+				? ` and ${targets.length - maxListedTargets} more`
+				: '';
+		const list =
+			names.length > 1 && !more
+				? `${names.slice(0, -1).join(', ')} and ${names.at(-1)}`
+				: `${names.join(', ')}${more}`;
+		const noun = targets.length > 1 ? 'targets' : 'target';
+
+		return {
+			category: Category.Commands,
+			rule: `The ${file} ${hasCommands ? 'also ' : ''}defines the ${noun} ${list}. Run tasks with \`make <target>\` instead of the commands a target wraps.`,
+			files: [file],
+		};
+	}
+
+	/**
+	 * Get the names of the targets of a Makefile, in the order they are defined
+	 * Special targets such as .PHONY, pattern rules and file targets with an
+	 * extension are left out
+	 */
+	private getTargets(content: string): string[] {
+		const targets = [...content.matchAll(targetLinePattern)].flatMap((match) =>
+			match[1].split(/[ \t]+/),
+		);
+
+		return [...new Set(targets)];
+	}
+
+	/**
+	 * Find the Makefile of the project
+	 * @returns The name and content of the Makefile, undefined if none
+	 */
+	private async findMakefile(): Promise<
+		{name: string; content: string} | undefined
+	> {
+		for (const name of makefileNames) {
+			try {
+				// eslint-disable-next-line no-await-in-loop
+				const content = await fs.readFile(
+					path.join(this.rootPath, name),
+					'utf8',
+				);
+				return {name, content};
+			} catch {
+				// Try the next name
+			}
+		}
+
+		return undefined;
+	}
+}
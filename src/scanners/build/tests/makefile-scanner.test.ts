@@ -0,0 +1,74 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {MakefileScanner} from '../makefile-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+const makefile = `VERSION := 1.0.0
+
+.PHONY: build test lint migrate seed
+
+build:
+	go build ./...
+
+test lint:
+	go test ./...
+
+migrate: build
+	./bin/migrate up
+
+seed:
+	./bin/seed
+
+%.o: %.c
+	cc -c $<
+`;
+
+describe('MakefileScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Targets', () => {
+		it('should turn well-known targets into commands', async () => {
+			rootPath = await createFixture({Makefile: makefile});
+
+			const rules = await new MakefileScanner(rootPath).scan();
+
+			expect(rules.flatMap((rule) => rule.commands ?? [])).toEqual([
+				{command: 'make build', purpose: 'build'},
+				{command: 'make test', purpose: 'test'},
+				{command: 'make lint', purpose: 'lint'},
+			]);
+		});
+
+		it('should list the other targets in one rule', async () => {
+			rootPath = await createFixture({Makefile: makefile});
+
+			const rules = await new MakefileScanner(rootPath).scan();
+
+			expect(rules.at(-1)?.rule).toBe(
+				'The Makefile also defines the targets `migrate` and `seed`. Run tasks with `make <target>` instead of the commands a target wraps.',
+			);
+		});
+
+		it('should prefer GNUmakefile as make does', async () => {
+			rootPath = await createFixture({
+				GNUmakefile: 'test:\n\tgo test ./...\n',
+				Makefile: 'build:\n\tgo build ./...\n',
+			});
+
+			const rules = await new MakefileScanner(rootPath).scan();
+
+			expect(rules.flatMap((rule) => rule.files ?? [])).toEqual([
+				'GNUmakefile',
+			]);
+		});
+
+		it('should not emit rules without a Makefile', async () => {
+			rootPath = await createFixture({'build.sh': 'go build ./...\n'});
+
+			expect(await new MakefileScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
@@ -1,21 +1,156 @@
 import path from 'node:path';
 import {MarkdownBuilder} from '../builders/markdown-builder.js';
+import {configFileNames} from '../services/config-loader.js';
+import {getInstalledPackageJsonPath} from '../services/dependency-resolver.js';
+import {FileIndex} from '../services/file-index.js';
 import {logger} from '../services/logger.js';
-import type {AiRule} from '../types.js';
-import {PrismaScanner} from './database/index.js';
-import {NextjsScanner, VueScanner} from './frameworks/index.js';
-import {GoVersionScanner} from './go/index.js';
-import {LintingScanner, XoScanner} from './linters/index.js';
+import {
+	aggregateRules,
+	excludeSharedRules,
+} from '../services/rule-aggregator.js';
+import {limitRules} from '../services/rule-budget.js';
+import {applyRuleTemplates} from '../services/rule-templates.js';
+import {ScanCache} from '../services/scan-cache.js';
+import {
+	type WorkspacePackage,
+	WorkspaceDetector,
+} from '../services/workspace-detector.js';
+import type {AiRule, Category} from '../types.js';
+import {Confidence} from '../types/confidence.js';
+import type {PsstConfig} from '../types/config.js';
+import type {ScannerReport, ScannerStatus} from '../types/scan.js';
+import type {Severity} from '../types/severity.js';
+import {
+	getDefaultConcurrency,
+	mapWithConcurrency,
+} from '../utils/concurrency.js';
+import {AccessibilityScanner} from './accessibility/index.js';
+import {GraphQLScanner, ProtobufScanner} from './api/index.js';
+import {AuthScanner} from './auth/index.js';
+import {BaseScanner} from './base/base-scanner.js';
+import type {Scanner} from './base/scanner.js';
+import {MakefileScanner} from './build/index.js';
+import {EnvScanner} from './config/index.js';
+import {DeclarativeScanner} from './custom/index.js';
+import {DatabaseScanner, PrismaScanner} from './database/index.js';
+import {
+	CIScanner,
+	CloudInfraScanner,
+	DockerScanner,
+	KubernetesScanner,
+	TerraformScanner,
+} from './devops/index.js';
+import {NextjsScanner, ReactScanner, VueScanner} from './frameworks/index.js';
+import {GoModuleScanner, GoVersionScanner} from './go/index.js';
+import {I18nScanner} from './i18n/index.js';
+import {BiomeScanner, LintingScanner, XoScanner} from './linters/index.js';
 import {PrettierScanner} from './linters/prettier-scanner.js';
 import {NodeVersionScanner} from './node/node-version-scanner.js';
 import {PackageManagerScanner} from './node/package-manager-scanner.js';
-import {ZustandScanner} from './state/index.js';
+import {ScriptsScanner} from './node/scripts-scanner.js';
+import {MessageQueueScanner} from './messaging/index.js';
+import {MobileScanner} from './mobile/index.js';
+import {ObservabilityScanner} from './observability/index.js';
+import {PythonScanner} from './python/index.js';
+import {RustScanner} from './rust/index.js';
+import {StateManagementScanner, ZustandScanner} from './state/index.js';
 import {
 	TestingFrameworkScanner,
 	AvaScanner,
 	JestScanner,
 } from './test/index.js';
-import {TailwindScanner} from './ui/index.js';
+import {StylingScanner, TailwindScanner} from './ui/index.js';
+
+/**
+ * Options for running the codebase scanner
+ */
+export type CodebaseScannerOptions = {
+	/**
+	 * Maximum number of scanners running at the same time
+	 * Defaults to the CPU count
+	 */
+	concurrency?: number;
+	/**
+	 * Skip files ignored by .gitignore files (defaults to true)
+	 */
+	gitignore?: boolean;
+	/**
+	 * Drop rules that are less important than this severity
+	 */
+	minSeverity?: Severity;
+	/**
+	 * Drop rules that are less certain than this confidence, from 0 to 1
+	 */
+	minConfidence?: number;
+	/**
+	 * Evidence every scanner needs before emitting rules, overriding the
+	 * defaults of the scanners, e.g. 1 to emit Docker rules for any Dockerfile
+	 */
+	minEvidence?: number;
+	/**
+	 * Maximum number of rules, the lowest-priority rules are left out first
+	 */
+	maxRules?: number;
+	/**
+	 * Maximum number of tokens of the rules, estimated from their length, the
+	 * lowest-priority rules are left out first
+	 */
+	tokenBudget?: number;
+	/**
+	 * Only output rules of these categories
+	 */
+	categories?: Category[];
+	/**
+	 * Drop rules of these categories from the output
+	 */
+	excludeCategories?: Category[];
+	/**
+	 * Scan each package of a monorepo workspace separately
+	 */
+	perPackage?: boolean;
+	/**
+	 * Config file contents with custom scanners and declarative rules
+	 */
+	config?: PsstConfig;
+	/**
+	 * Names of the only scanners to run, overrides the config file
+	 * A name also selects the scanners it prefixes, e.g. "go" selects "go-module"
+	 */
+	only?: string[];
+	/**
+	 * Names of scanners to skip, overrides the config file
+	 */
+	disable?: string[];
+	/**
+	 * Globs of the files to scan, relative to the scanned directory, overrides
+	 * the config file. A glob matching a directory includes all its files
+	 */
+	include?: string[];
+	/**
+	 * Globs of the files and directories to leave out of the scan, overrides
+	 * the config file
+	 */
+	exclude?: string[];
+	/**
+	 * Reuse the results of scanners whose files are unchanged since the last
+	 * run, stored in .psst-cache.json in the scanned directory
+	 */
+	cache?: boolean;
+};
+
+/**
+ * Get the name used to enable or disable a scanner
+ */
+function getScannerName(scanner: Scanner): string {
+	return scanner.name ?? scanner.constructor.name;
+}
+
+/**
+ * Check if a scanner name is selected by a name given in the options
+ */
+function matchesScannerName(scannerName: string, name: string): boolean {
+	return scannerName === name || scannerName.startsWith(`${name}-`);
+}
 
 /**
  * Scanner class to handle scanning a directory
@@ -23,14 +158,26 @@ import {TailwindScanner} from './ui/index.js';
 export class CodebaseScanner {
 	private readonly logger = logger.getLogger('Scanner');
 	private readonly outputPath: string;
+	private readonly cache: ScanCache | undefined;
+	// Scanners of the config file, which are never cached
+	private readonly customScanners = new WeakSet<Scanner>();
+	// Scanners of the last scan that threw or whose rules came from the cache
+	private readonly failedScanners = new WeakSet<Scanner>();
+	private readonly cachedScanners = new WeakSet<Scanner>();
+	private report: ScannerReport[] = [];
 
 	/**
 	 * Constructor for Scanner
 	 * @param pathToScan Path to scan (defaults to current directory)
+	 * @param options Scanner options
 	 */
-	constructor(private readonly pathToScan: string) {
+	constructor(
+		private readonly pathToScan: string,
+		private readonly options: CodebaseScannerOptions = {},
+	) {
 		this.logger.debug(`Scanner initialized with path: ${this.pathToScan}`);
 		this.outputPath = path.resolve(this.pathToScan, 'output');
+		this.cache = options.cache ? new ScanCache(this.pathToScan) : undefined;
 	}
 
 	/**
@@ -67,53 +214,493 @@ export class CodebaseScanner {
 
 	/**
 	 * Scan the directory using all available sub-scanners
+	 * In per-package mode the rules of each workspace package are tagged with
+	 * the package path, and rules shared with the root are only kept once
 	 */
 	public async scan(): Promise<AiRule[]> {
 		this.logger.debug('Running all sub-scanners');
 
-		const scanners = [
-			new PackageManagerScanner(this.pathToScan),
-			new NodeVersionScanner(this.pathToScan),
-			new GoVersionScanner(this.pathToScan),
-			new LintingScanner(this.pathToScan),
-			new XoScanner(this.pathToScan),
-			new TestingFrameworkScanner(this.pathToScan),
-			new AvaScanner(this.pathToScan),
-			new JestScanner(this.pathToScan),
-			new PrettierScanner(this.pathToScan),
-			new NextjsScanner(this.pathToScan),
-			new VueScanner(this.pathToScan),
-			new PrismaScanner(this.pathToScan),
-			new TailwindScanner(this.pathToScan),
-			new ZustandScanner(this.pathToScan),
+		// All scanners share one traversal of the directory tree
+		const fileIndex = new FileIndex(this.pathToScan, {
+			gitignore: this.options.gitignore,
+			include: this.options.include ?? this.options.config?.include,
+			exclude: this.options.exclude ?? this.options.config?.exclude,
+		});
+
+		this.warnUnknownScannerNames(fileIndex);
+		await this.cache?.load();
+		this.report = [];
+
+		const packages = this.options.perPackage
+			? await new WorkspaceDetector(this.pathToScan, fileIndex).detectPackages()
+			: [];
+
+		if (this.options.perPackage && packages.length === 0) {
+			this.logger.warn(
+				'No workspace packages found, scanning the directory as a whole',
+			);
+		}
+
+		const packagePaths = packages.map((workspacePackage) =>
+			path.join(this.pathToScan, workspacePackage.path),
+		);
+
+		// Package directories are left out of the root scan
+		const rootRules = await this.scanDirectory(
+			this.pathToScan,
+			fileIndex.createSubIndex(this.pathToScan, packagePaths),
+		);
+
+		const allRules = [...rootRules];
+		// Packages are scanned one after another, each running its scanners
+		// concurrently
+		for (const workspacePackage of packages) {
+			// eslint-disable-next-line no-await-in-loop
+			const packageRules = await this.scanPackage(
+				workspacePackage,
+				fileIndex,
+			);
+			allRules.push(...excludeSharedRules(packageRules, rootRules));
+		}
+
+		await this.cache?.save();
+		this.logger.info(`Found a total of ${allRules.length} rules`);
+
+		// The budget covers the whole output, including every package
+		const {maxRules, tokenBudget} = this.options;
+		if (maxRules === undefined && tokenBudget === undefined) {
+			return allRules;
+		}
+
+		const limitedRules = limitRules(allRules, {maxRules, tokenBudget});
+		if (limitedRules !== allRules) {
+			// The note on the omitted rules is not one of the scanned rules
+			const scannedRules = new Set(allRules);
+			const keptCount = limitedRules.filter((rule) =>
+				scannedRules.has(rule),
+			).length;
+			this.logger.info(
+				`Kept ${keptCount} of ${allRules.length} rules to fit the budget`,
+			);
+		}
+
+		return limitedRules;
+	}
+
+	/**
+	 * Get what each scanner found in the last scan, in the order of the
+	 * scanners, including the scanners that were disabled
+	 * Scanners of workspace packages are listed after the root scanners
+	 */
+	public getReport(): ScannerReport[] {
+		return this.report;
+	}
+
+	/**
+	 * Get the names of all files the scanners read, "*" matches any characters
+	 * Used by watch mode to decide which changes require a new scan
+	 */
+	public getWatchedFiles(): string[] {
+		const scanners = this.selectScanners(
+			this.createScanners(
+				this.pathToScan,
+				new FileIndex(this.pathToScan),
+				true,
+			),
+		);
+
+		return [
+			...new Set([
+				...scanners.flatMap((scanner) => scanner.watchedFiles ?? []),
+				// Files that change which files and packages are scanned
+				'.gitignore',
+				'pnpm-workspace.yaml',
+				'lerna.json',
+				'nx.json',
+				'turbo.json',
+				'project.json',
+				...configFileNames,
+			]),
+		].sort();
+	}
+
+	/**
+	 * Scan a single package of the workspace
+	 * Scanners describing the whole workspace only run at the root
+	 */
+	private async scanPackage(
+		workspacePackage: WorkspacePackage,
+		fileIndex: FileIndex,
+	): Promise<AiRule[]> {
+		this.logger.debug(`Scanning workspace package: ${workspacePackage.path}`);
+
+		const packagePath = path.join(this.pathToScan, workspacePackage.path);
+		const rules = await this.scanDirectory(
+			packagePath,
+			fileIndex.createSubIndex(packagePath),
+			workspacePackage.path,
+		);
+
+		this.logger.debug(
+			`Found ${rules.length} rules in ${workspacePackage.path}`,
+		);
+		return rules;
+	}
+
+	/**
+	 * Run the sub-scanners on a directory and aggregate their rules
+	 * @param directoryPath Directory to scan
+	 * @param fileIndex Index of the files in the directory
+	 * @param packagePath Workspace package path to tag the rules with
+	 */
+	private async scanDirectory(
+		directoryPath: string,
+		fileIndex: FileIndex,
+		packagePath?: string,
+	): Promise<AiRule[]> {
+		const allScanners = this.createScanners(
+			directoryPath,
+			fileIndex,
+			!packagePath,
+		);
+		const scanners = this.selectScanners(allScanners);
+
+		const concurrency = this.options.concurrency ?? getDefaultConcurrency();
+		this.logger.debug(`Running scanners with concurrency ${concurrency}`);
+
+		// Run scanners concurrently, results keep the order of the scanners list
+		// so the output is the same regardless of which scanner finishes first
+		const scannerResults = await mapWithConcurrency(
+			scanners,
+			concurrency,
+			async (scanner) =>
+				this.runCachedScanner(scanner, fileIndex, packagePath),
+		);
+
+		this.addToReport(allScanners, scanners, scannerResults, packagePath);
+
+		const rules = scannerResults
+			.flat()
+			.map((rule) => (packagePath ? {...rule, package: packagePath} : rule));
+
+		// Merge duplicate rules emitted by different scanners
+		const aggregatedRules = aggregateRules(rules, {
+			minSeverity: this.options.minSeverity,
+			minConfidence: this.options.minConfidence,
+			categories: this.options.categories,
+			excludeCategories: this.options.excludeCategories,
+		});
+
+		const templates = this.options.config?.templates;
+		if (!templates) {
+			return aggregatedRules;
+		}
+
+		// Templates can also be keyed by the class names of the scanners
+		const classNames = new Map(
+			scanners.map((scanner) => [
+				getScannerName(scanner),
+				scanner.constructor.name,
+			]),
+		);
+		return applyRuleTemplates(aggregatedRules, templates, classNames);
+	}
+
+	/**
+	 * Add the outcome of the scanners of a directory to the report
+	 * Disabled scanners are only reported for the root, as they are disabled
+	 * for every package too
+	 * @param allScanners Scanners created for the directory
+	 * @param scanners Scanners that ran
+	 * @param scannerResults Rules of the scanners that ran, in the same order
+	 * @param packagePath Workspace package path of the directory
+	 */
+	private addToReport(
+		allScanners: Scanner[],
+		scanners: Scanner[],
+		scannerResults: AiRule[][],
+		packagePath?: string,
+	): void {
+		for (const scanner of allScanners) {
+			const index = scanners.indexOf(scanner);
+			if (index === -1 && packagePath) {
+				continue;
+			}
+
+			const rules = index === -1 ? [] : scannerResults[index];
+			const files = new Set(rules.flatMap((rule) => rule.files ?? []));
+
+			this.report.push({
+				name: getScannerName(scanner),
+				...(packagePath ? {package: packagePath} : {}),
+				status: this.getScannerStatus(scanner, index !== -1, rules),
+				cached: this.cachedScanners.has(scanner),
+				rules: rules.length,
+				files: [...files].sort(),
+			});
+		}
+	}
+
+	/**
+	 * Get the outcome of a scanner of the last scan
+	 * @param scanner Scanner of the scanned directory
+	 * @param enabled Whether the scanner was run
+	 * @param rules Rules emitted by the scanner
+	 */
+	private getScannerStatus(
+		scanner: Scanner,
+		enabled: boolean,
+		rules: AiRule[],
+	): ScannerStatus {
+		if (!enabled) {
+			return 'disabled';
+		}
+
+		if (this.failedScanners.has(scanner)) {
+			return 'failed';
+		}
+
+		return rules.length > 0 ? 'matched' : 'no-match';
+	}
+
+	/**
+	 * Create the sub-scanners for a directory, built-in scanners first
+	 * @param directoryPath Directory to scan
+	 * @param fileIndex Index of the files in the directory
+	 * @param includeWorkspaceScanners Include scanners describing the whole
+	 * workspace, which are skipped for workspace packages
+	 */
+	private createScanners(
+		directoryPath: string,
+		fileIndex: FileIndex,
+		includeWorkspaceScanners: boolean,
+	): Scanner[] {
+		const scanners: Scanner[] = [];
+
+		// The package manager and Node.js version are set for the whole workspace
+		if (includeWorkspaceScanners) {
+			scanners.push(
+				new PackageManagerScanner(directoryPath, fileIndex),
+				new NodeVersionScanner(directoryPath, fileIndex),
+			);
+		}
+
+		scanners.push(
+			new ScriptsScanner(directoryPath, fileIndex),
+			new MakefileScanner(directoryPath, fileIndex),
+			new GoVersionScanner(directoryPath, fileIndex),
+			new GoModuleScanner(directoryPath, fileIndex),
+			new RustScanner(directoryPath, fileIndex),
+			new LintingScanner(directoryPath, fileIndex),
+			new XoScanner(directoryPath, fileIndex),
+			new TestingFrameworkScanner(directoryPath, fileIndex),
+			new AvaScanner(directoryPath, fileIndex),
+			new JestScanner(directoryPath, fileIndex),
+			new PrettierScanner(directoryPath, fileIndex),
+			new BiomeScanner(directoryPath, fileIndex),
+			new NextjsScanner(directoryPath, fileIndex),
+			new ReactScanner(directoryPath, fileIndex),
+			new VueScanner(directoryPath, fileIndex),
+			new MobileScanner(directoryPath, fileIndex),
+			new AuthScanner(directoryPath, fileIndex),
+			new DatabaseScanner(directoryPath, fileIndex),
+			new PrismaScanner(directoryPath, fileIndex),
+			new TailwindScanner(directoryPath, fileIndex),
+			new StylingScanner(directoryPath, fileIndex),
+			new StateManagementScanner(directoryPath, fileIndex),
+			new ZustandScanner(directoryPath, fileIndex),
+			new I18nScanner(directoryPath, fileIndex),
+			new GraphQLScanner(directoryPath, fileIndex),
+			new ProtobufScanner(directoryPath, fileIndex),
+			new MessageQueueScanner(directoryPath, fileIndex),
+			new ObservabilityScanner(directoryPath, fileIndex),
+			new AccessibilityScanner(directoryPath, fileIndex),
+			new CIScanner(directoryPath, fileIndex),
+			new EnvScanner(directoryPath, fileIndex),
+			new DockerScanner(directoryPath, fileIndex),
+			new KubernetesScanner(directoryPath, fileIndex),
+			new PythonScanner(directoryPath, fileIndex),
+			new TerraformScanner(directoryPath, fileIndex),
+			new CloudInfraScanner(directoryPath, fileIndex),
 			// Add more scanners here as they are implemented
+		);
+
+		const {config} = this.options;
+		for (const entry of config?.scanners ?? []) {
+			if (typeof entry === 'function') {
+				const CustomScanner = entry;
+				scanners.push(
+					this.addCustomScanner(new CustomScanner(directoryPath, fileIndex)),
+				);
+			} else if (includeWorkspaceScanners) {
+				// Scanner objects are bound to the directory they were created for
+				scanners.push(this.addCustomScanner(entry));
+			}
+		}
+
+		if (config?.rules && config.rules.length > 0) {
+			scanners.push(
+				this.addCustomScanner(
+					new DeclarativeScanner(directoryPath, fileIndex, config.rules),
+				),
+			);
+		}
+
+		const {minEvidence} = this.options;
+		if (minEvidence !== undefined) {
+			for (const scanner of scanners) {
+				if (scanner instanceof BaseScanner) {
+					scanner.minEvidence = minEvidence;
+				}
+			}
+		}
+
+		return scanners;
+	}
+
+	/**
+	 * Remember a scanner of the config file, as its results can depend on
+	 * config changes that the cache does not detect
+	 */
+	private addCustomScanner(scanner: Scanner): Scanner {
+		this.customScanners.add(scanner);
+		return scanner;
+	}
+
+	/**
+	 * Keep the scanners enabled by the options and the config file
+	 * Disabled scanners are never run
+	 */
+	private selectScanners(scanners: Scanner[]): Scanner[] {
+		const only = this.options.only ?? this.options.config?.only;
+		const disable = this.options.disable ?? this.options.config?.disable;
+
+		return scanners.filter((scanner) => {
+			const scannerName = getScannerName(scanner);
+			const enabled =
+				(!only ||
+					only.some((name) => matchesScannerName(scannerName, name))) &&
+				!disable?.some((name) => matchesScannerName(scannerName, name));
+
+			if (!enabled) {
+				this.logger.debug(`Skipping disabled scanner: ${scannerName}`);
+			}
+
+			return enabled;
+		});
+	}
+
+	/**
+	 * Warn about enabled or disabled scanner names that match no scanner
+	 */
+	private warnUnknownScannerNames(fileIndex: FileIndex): void {
+		const names = [
+			...(this.options.only ?? this.options.config?.only ?? []),
+			...(this.options.disable ?? this.options.config?.disable ?? []),
 		];
+		if (names.length === 0) {
+			return;
+		}
+
+		const scannerNames = this.createScanners(
+			this.pathToScan,
+			fileIndex,
+			true,
+		).map((scanner) => getScannerName(scanner));
 
-		const allRules: AiRule[] = [];
-
-		// Run all scanners and collect their rules
-		for (const scanner of scanners) {
-			try {
-				const scannerName = scanner.constructor.name;
-				this.logger.debug(`Running scanner: ${scannerName}`);
-
-				// eslint-disable-next-line no-await-in-loop
-				const rules = await scanner.scan();
-				// Type assertion to ensure rules is properly typed as AiRule[]
-				const typedRules = rules;
-				allRules.push(...typedRules);
-
-				this.logger.debug(`Scanner ${scannerName} found ${rules.length} rules`);
-			} catch (error) {
-				this.logger.error(
-					`Error running scanner ${scanner.constructor.name}`,
-					error,
+		for (const name of names) {
+			if (
+				!scannerNames.some((scannerName) =>
+					matchesScannerName(scannerName, name),
+				)
+			) {
+				this.logger.warn(
+					`Unknown scanner name: ${name} (available: ${scannerNames.join(', ')})`,
 				);
-				// Continue with other scanners even if one fails
 			}
 		}
+	}
 
-		this.logger.info(`Found a total of ${allRules.length} rules`);
-		return allRules;
+	/**
+	 * Run a single scanner, reusing its cached rules if its files are unchanged
+	 * Custom scanners, scanners without watched files and scanners that are not
+	 * cacheable always run
+	 * @param scanner Scanner to run
+	 * @param fileIndex Index of the files in the scanned directory
+	 * @param packagePath Workspace package path of the scanned directory
+	 */
+	private async runCachedScanner(
+		scanner: Scanner,
+		fileIndex: FileIndex,
+		packagePath?: string,
+	): Promise<AiRule[]> {
+		const {cache} = this;
+		if (
+			!cache ||
+			!scanner.watchedFiles?.length ||
+			scanner.cacheable === false ||
+			this.customScanners.has(scanner)
+		) {
+			return this.runScanner(scanner);
+		}
+
+		// Rules found with another evidence threshold are cached separately
+		const evidenceLabel =
+			this.options.minEvidence === undefined
+				? ''
+				: `:evidence-${this.options.minEvidence}`;
+		const key = `${packagePath ?? '.'}:${getScannerName(scanner)}${evidenceLabel}`;
+		const fingerprint = await cache.getFingerprint(
+			await fileIndex.getFiles(),
+			scanner.watchedFiles,
+			(scanner.versionedDependencies ?? []).map((dependencyName) =>
+				path.join(
+					fileIndex.getRootPath(),
+					getInstalledPackageJsonPath(dependencyName),
+				),
+			),
+		);
+
+		const cachedRules = cache.get(key, fingerprint);
+		if (cachedRules) {
+			this.logger.debug(`Reusing cached rules of ${key}`);
+			this.cachedScanners.add(scanner);
+			return cachedRules;
+		}
+
+		const rules = await this.runScanner(scanner);
+		// A failed scanner runs again next time instead of reusing no rules
+		if (!this.failedScanners.has(scanner)) {
+			cache.set(key, fingerprint, rules);
+		}
+
+		return rules;
+	}
+
+	/**
+	 * Run a single scanner, tagging its rules with the scanner name, the same
+	 * name as in the summary and the only and disable options
+	 * A failing scanner does not stop the other scanners
+	 */
+	private async runScanner(scanner: Scanner): Promise<AiRule[]> {
+		const scannerName = getScannerName(scanner);
+
+		try {
+			this.logger.debug(`Running scanner: ${scannerName}`);
+
+			const rules = await scanner.scan();
+			this.logger.debug(`Scanner ${scannerName} found ${rules.length} rules`);
+
+			// Record which scanner emitted each rule and how certain it is
+			return rules.map((rule) => ({
+				scanner: scannerName,
+				confidence: scanner.confidence ?? Confidence.Certain,
+				...rule,
+			}));
+		} catch (error) {
+			this.logger.error(`Error running scanner ${scannerName}`, error);
+			this.failedScanners.add(scanner);
+			return [];
+		}
 	}
 }
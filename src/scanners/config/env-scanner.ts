@@ -0,0 +1,225 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, Severity, type AiRule} from '../../types.js';
+import {Gitignore} from '../../utils/gitignore.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * Maximum number of variable names listed in a rule
+ */
+const maxListedNames = 20;
+
+/**
+ * Matches the name of a variable assignment, e.g. "export API_URL=..."
+ * Only the name is captured, the value is never read
+ */
+const variableNamePattern = /^\s*(?:export\s+)?([A-Za-z_][\w.]*)\s*=/;
+
+/**
+ * Matches the names of env files, e.g. .env or .env.local
+ */
+const envFilePattern = /^\.env(?:\..+)?$/;
+
+/**
+ * Suffixes of env files that document variables instead of holding values
+ */
+const templateSuffixes = new Set(['example', 'sample', 'template', 'dist']);
+
+/**
+ * How each language reads an environment variable, keyed by a file that
+ * marks a project of that language
+ */
+const environmentAccessors: Record<string, string> = {
+	'package.json': '`process.env.NAME`',
+	'pyproject.toml': '`os.environ["NAME"]`',
+	'requirements.txt': '`os.environ["NAME"]`',
+	'go.mod': '`os.Getenv("NAME")`',
+	'Cargo.toml': '`std::env::var("NAME")`',
+};
+
+/**
+ * Scanner to detect .env files and the environment variables a project uses
+ * Values are never read or emitted, only variable names
+ */
+export class EnvScanner extends BaseScanner {
+	public readonly name = 'env';
+	public readonly watchedFiles = ['.env*', '.gitignore'];
+	// .env files are usually ignored by git and missing from the file index,
+	// so the cache would not notice when they change
+	public readonly cacheable = false;
+
+	/**
+	 * Scan the project to determine which environment variables it expects
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for environment files');
+
+		try {
+			// .env files are usually ignored by git, so the file index does not
+			// list them
+			const entries = await fs.readdir(this.rootPath, {withFileTypes: true});
+			const envFiles = entries
+				.filter((entry) => entry.isFile() && envFilePattern.test(entry.name))
+				.map((entry) => entry.name)
+				.sort();
+
+			// If no env files are found, don't return any recommendations
+			if (envFiles.length === 0) {
+				return [];
+			}
+
+			const templateFiles = envFiles.filter((file) => this.isTemplate(file));
+			const valueFiles = envFiles.filter((file) => !this.isTemplate(file));
+
+			// Variable names come from the templates, and from the real env files
+			// when there is no template
+			const namesFiles = templateFiles.length > 0 ? templateFiles : valueFiles;
+			const names = new Set<string>();
+			for (const file of namesFiles) {
+				// eslint-disable-next-line no-await-in-loop
+				for (const name of await this.readVariableNames(file)) {
+					names.add(name);
+				}
+			}
+
+			const recommendations: AiRule[] = [];
+
+			// Committed secrets are reported whatever the evidence
+			if (this.hasEnoughEvidence(envFiles.map((file) => ({file})))) {
+				if (names.size > 0) {
+					recommendations.push(
+						this.getVariablesRule([...names], namesFiles, templateFiles),
+					);
+				}
+
+				recommendations.push(this.getEnvironmentRule(envFiles));
+			}
+
+			const committedFiles = await this.findUnignoredFiles(valueFiles);
+			if (committedFiles.length > 0) {
+				const fileList = committedFiles.map((file) => `\`${file}\``).join(', ');
+				const verb = committedFiles.length === 1 ? 'is' : 'are';
+
+				recommendations.push({
+					category: Category.Configuration,
+					rule: `${fileList} may hold secrets but ${verb} not ignored by git. Never commit real values, add the file to .gitignore.`,
+					severity: Severity.Critical,
+					files: committedFiles,
+				});
+			}
+
+			return recommendations;
+		} catch (error) {
+			this.logger.error('Error scanning for environment files', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the rule listing the environment variables of the project
+	 */
+	private getVariablesRule(
+		names: string[],
+		namesFiles: string[],
+		templateFiles: string[],
+	): AiRule {
+		const quotedNames = names
+			.slice(0, maxListedNames)
+			.map((name) => `\`${name}\``);
+		if (names.length > maxListedNames) {
+			quotedNames.push(`${names.length - maxListedNames} more`);
+		}
+
+		const nameList =
+			quotedNames.length > 1
+				? `${quotedNames.slice(0, -1).join(', ')} and ${quotedNames.at(-1)}`
+				: quotedNames[0];
+		const template = templateFiles[0];
+		const documentation = template
+			? ` When adding a variable, also add it to \`${template}\` with a placeholder value.`
+			: '';
+
+		return {
+			category: Category.Configuration,
+			rule: `The project is configured with the environment variables ${nameList}.${documentation}`,
+			files: namesFiles,
+		};
+	}
+
+	/**
+	 * Get the rule to read configuration from the environment
+	 */
+	private getEnvironmentRule(envFiles: string[]): AiRule {
+		const accessors = [
+			...new Set(
+				Object.keys(environmentAccessors)
+					.filter((file) => existsSync(path.join(this.rootPath, file)))
+					.map((file) => environmentAccessors[file]),
+			),
+		];
+		const usage = accessors.length > 0 ? ` (${accessors.join(', ')})` : '';
+
+		return {
+			category: Category.Configuration,
+			rule: `Read configuration and secrets from environment variables${usage} instead of hardcoding URLs, credentials or API keys. Never copy values from .env files into code, logs or output.`,
+			severity: Severity.High,
+			files: envFiles,
+		};
+	}
+
+	/**
+	 * Check if an env file documents variables instead of holding values,
+	 * e.g. .env.example
+	 */
+	private isTemplate(file: string): boolean {
+		return templateSuffixes.has(file.split('.').at(-1) ?? '');
+	}
+
+	/**
+	 * Read the names of the variables assigned in an env file
+	 * Values are dropped line by line and never kept
+	 */
+	private async readVariableNames(file: string): Promise<string[]> {
+		try {
+			const content = await fs.readFile(path.join(this.rootPath, file), 'utf8');
+			const names: string[] = [];
+
+			for (const line of content.split('\n')) {
+				const name = variableNamePattern.exec(line)?.[1];
+				if (name) {
+					names.push(name);
+				}
+			}
+
+			return names;
+		} catch (error) {
+			this.logger.error(`Error reading ${file}`, error);
+			return [];
+		}
+	}
+
+	/**
+	 * Find env files with values that the .gitignore of the project does not
+	 * ignore
+	 * Only checked in git repositories or directories with a .gitignore, as
+	 * packages of a workspace are covered by the .gitignore of its root
+	 */
+	private async findUnignoredFiles(valueFiles: string[]): Promise<string[]> {
+		const gitignorePath = path.join(this.rootPath, '.gitignore');
+
+		if (!existsSync(gitignorePath)) {
+			return existsSync(path.join(this.rootPath, '.git')) ? valueFiles : [];
+		}
+
+		try {
+			const gitignore = new Gitignore(await fs.readFile(gitignorePath, 'utf8'));
+			return valueFiles.filter(
+				(file) => gitignore.isIgnored(file, false) !== true,
+			);
+		} catch (error) {
+			this.logger.error('Error reading .gitignore', error);
+			return [];
+		}
+	}
+}
@@ -0,0 +1 @@
+export {EnvScanner} from './env-scanner.js';
@@ -0,0 +1,59 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {EnvScanner} from '../env-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+import {Severity} from '../../../types.js';
+
+describe('EnvScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Variables', () => {
+		it('should list the variables of the template file', async () => {
+			rootPath = await createFixture({
+				'.env': 'DATABASE_URL=postgres://secret\n',
+				'.env.example': 'DATABASE_URL=\nexport API_KEY=changeme\n',
+				'.gitignore': '.env\n',
+				'package.json': '{}',
+			});
+
+			const rules = await new EnvScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'The project is configured with the environment variables `DATABASE_URL` and `API_KEY`. When adding a variable, also add it to `.env.example` with a placeholder value.',
+				'Read configuration and secrets from environment variables (`process.env.NAME`) instead of hardcoding URLs, credentials or API keys. Never copy values from .env files into code, logs or output.',
+			]);
+		});
+
+		it('should never emit the values of the variables', async () => {
+			rootPath = await createFixture({'.env': 'API_KEY=sk-live-1234\n'});
+
+			const rules = await new EnvScanner(rootPath).scan();
+
+			expect(JSON.stringify(rules)).not.toContain('sk-live-1234');
+		});
+	});
+
+	describe('Git', () => {
+		it('should warn about env files that git does not ignore', async () => {
+			rootPath = await createFixture({
+				'.env': 'API_KEY=sk-live-1234\n',
+				'.env.local': 'API_KEY=sk-test-1234\n',
+				'.gitignore': '.env.local\n',
+			});
+
+			const rules = await new EnvScanner(rootPath).scan();
+
+			expect(rules.at(-1)?.severity).toBe(Severity.Critical);
+			expect(rules.at(-1)?.files).toEqual(['.env']);
+		});
+
+		it('should not emit rules without env files', async () => {
+			rootPath = await createFixture({'package.json': '{}'});
+
+			expect(await new EnvScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
@@ -0,0 +1,122 @@
+import path from 'node:path';
+import type {FileIndex} from '../../services/file-index.js';
+import {Category, type AiRule, type RuleCategory} from '../../types.js';
+import type {DeclarativeRule} from '../../types/config.js';
+import {parseCategory} from '../../utils/category-formatter.js';
+import {globToRegex} from '../../utils/glob.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * Maximum number of matching files listed for a rule
+ */
+const maxListedFiles = 10;
+
+/**
+ * Scanner that emits the declarative rules of the config file
+ * A rule is emitted when enough files match its globs, at least one
+ */
+export class DeclarativeScanner extends BaseScanner {
+	public readonly name = 'declarative';
+	public readonly watchedFiles: string[];
+
+	/**
+	 * Constructor for DeclarativeScanner
+	 * @param rootPath Path to scan
+	 * @param fileIndex Shared index of the project files
+	 * @param rules Declarative rules of the config file
+	 */
+	constructor(
+		rootPath: string,
+		fileIndex: FileIndex,
+		private readonly rules: DeclarativeRule[],
+	) {
+		super(rootPath, fileIndex);
+
+		// The watcher matches file names, so only the last part of a glob counts
+		this.watchedFiles = [
+			...new Set(
+				rules.flatMap((rule) =>
+					this.getGlobs(rule).map((glob) => glob.split('/').at(-1) ?? glob),
+				),
+			),
+		];
+	}
+
+	/**
+	 * Scan the project for files matching the globs of each rule
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for declarative rules');
+
+		try {
+			const files = (await this.fileIndex.getFiles()).map((file) =>
+				path.relative(this.rootPath, file).split(path.sep).join('/'),
+			);
+			const recommendations: AiRule[] = [];
+
+			for (const rule of this.rules) {
+				const matchingFiles = this.findMatchingFiles(rule, files);
+				if (
+					matchingFiles.length === 0 ||
+					!this.hasEnoughEvidence(matchingFiles.map((file) => ({file})))
+				) {
+					continue;
+				}
+
+				recommendations.push({
+					category: this.getCategory(rule),
+					rule: rule.rule,
+					...(rule.severity ? {severity: rule.severity} : {}),
+					...(rule.confidence === undefined
+						? {}
+						: {confidence: rule.confidence}),
+					files: matchingFiles.slice(0, maxListedFiles),
+				});
+			}
+
+			return recommendations;
+		} catch (error) {
+			this.logger.error('Error scanning for declarative rules', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Find the files matching any glob of a rule
+	 * @param rule Declarative rule
+	 * @param files Files of the project, relative to the root
+	 */
+	private findMatchingFiles(rule: DeclarativeRule, files: string[]): string[] {
+		const globs = this.getGlobs(rule).map((glob) => ({
+			regex: globToRegex(glob.replace(/^\.\//, '')),
+			matchesName: !glob.includes('/'),
+		}));
+
+		return files.filter((file) =>
+			globs.some(({regex, matchesName}) =>
+				regex.test(matchesName ? path.posix.basename(file) : file),
+			),
+		);
+	}
+
+	/**
+	 * Get the category of a rule
+	 * Built-in categories can be given by value or title, other values are
+	 * custom titles and are shown as written
+	 */
+	private getCategory(rule: DeclarativeRule): RuleCategory {
+		const category = rule.category?.trim();
+		if (!category) {
+			return Category.General;
+		}
+
+		return parseCategory(category) ?? category;
+	}
+
+	/**
+	 * Get the globs of a rule as a list
+	 */
+	private getGlobs(rule: DeclarativeRule): string[] {
+		return typeof rule.files === 'string' ? [rule.files] : rule.files;
+	}
+}
@@ -0,0 +1 @@
+export {DeclarativeScanner} from './declarative-scanner.js';
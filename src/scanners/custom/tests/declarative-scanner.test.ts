@@ -0,0 +1,106 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {DeclarativeScanner} from '../declarative-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+import {FileIndex} from '../../../services/file-index.js';
+import {Category, Severity, type AiRule} from '../../../types.js';
+import type {DeclarativeRule} from '../../../types/config.js';
+
+const rules: DeclarativeRule[] = [
+	{
+		files: '*.stories.tsx',
+		rule: 'Write a story for every new component.',
+		category: 'Storybook',
+	},
+	{
+		files: ['src/api/**/*.ts', './openapi.yaml'],
+		rule: 'Keep the API client in sync with openapi.yaml.',
+		severity: Severity.High,
+	},
+	{files: '*.graphql', rule: 'Never emitted.'},
+];
+
+describe('DeclarativeScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	/**
+	 * Scan a project with the test rules
+	 */
+	async function scan(): Promise<AiRule[]> {
+		return new DeclarativeScanner(
+			rootPath,
+			new FileIndex(rootPath),
+			rules,
+		).scan();
+	}
+
+	describe('Globs', () => {
+		it('should emit the rules whose globs match a file', async () => {
+			rootPath = await createFixture({
+				'src/components/Button.stories.tsx': '',
+				'src/api/client.ts': '',
+				'openapi.yaml': '',
+			});
+
+			expect(await scan()).toEqual([
+				{
+					category: 'Storybook',
+					rule: 'Write a story for every new component.',
+					files: ['src/components/Button.stories.tsx'],
+				},
+				{
+					category: Category.General,
+					rule: 'Keep the API client in sync with openapi.yaml.',
+					severity: Severity.High,
+					files: ['openapi.yaml', 'src/api/client.ts'],
+				},
+			]);
+		});
+
+		it('should anchor globs with a slash at the root', async () => {
+			rootPath = await createFixture({'packages/web/src/api/client.ts': ''});
+
+			expect(await scan()).toEqual([]);
+		});
+	});
+
+	describe('Categories', () => {
+		it('should resolve built-in categories given by title', async () => {
+			rootPath = await createFixture({'schema.prisma': ''});
+
+			const recommendations = await new DeclarativeScanner(
+				rootPath,
+				new FileIndex(rootPath),
+				[
+					{
+						files: '*.prisma',
+						rule: 'Run the migrations.',
+						category: 'Database',
+					},
+					{files: '*.prisma', rule: 'Seed the data.', category: ' Seeding '},
+				],
+			).scan();
+
+			expect(recommendations.map((rule) => rule.category)).toEqual([
+				Category.Database,
+				'Seeding',
+			]);
+		});
+	});
+
+	describe('Watched files', () => {
+		it('should watch the file names of the globs', () => {
+			const scanner = new DeclarativeScanner('.', new FileIndex('.'), rules);
+
+			expect(scanner.watchedFiles).toEqual([
+				'*.stories.tsx',
+				'*.ts',
+				'openapi.yaml',
+				'*.graphql',
+			]);
+		});
+	});
+});
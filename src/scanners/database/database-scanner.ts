@@ -0,0 +1,370 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, Severity, type AiRule} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * An ORM or query builder detected in the project
+ */
+type DatabaseTool = {
+	name: string;
+	// Database the tool connects to, e.g. PostgreSQL
+	database?: string;
+	// Migrations directory relative to the root
+	migrationsDirectory?: string;
+	// Command that creates a new migration
+	migrationCommand: string;
+	files: string[];
+};
+
+/**
+ * Display names of Prisma providers and Drizzle dialects
+ */
+const databaseNames: Record<string, string> = {
+	postgresql: 'PostgreSQL',
+	postgres: 'PostgreSQL',
+	mysql: 'MySQL',
+	sqlite: 'SQLite',
+	turso: 'SQLite (Turso)',
+	sqlserver: 'SQL Server',
+	mongodb: 'MongoDB',
+	cockroachdb: 'CockroachDB',
+	singlestore: 'SingleStore',
+};
+
+/**
+ * Matches the migrations path of .sequelizerc, either a string or the
+ * arguments of path.resolve()
+ */
+const sequelizeMigrationsPattern =
+	/["']migrations-path["']\s*:\s*(?:path\.(?:resolve|join)\(([^)]*)\)|["']([^"']+)["'])/;
+
+/**
+ * Scanner to detect ORMs, query builders and database migrations
+ * Supports Prisma, Drizzle, TypeORM, Sequelize and Knex
+ */
+export class DatabaseScanner extends BaseScanner {
+	public readonly name = 'database';
+	public readonly watchedFiles = [
+		'package.json',
+		'schema.prisma',
+		'drizzle.config.*',
+		'ormconfig.*',
+		'data-source.*',
+		'.sequelizerc',
+		'knexfile.*',
+	];
+
+	/**
+	 * Scan the project to determine which ORM is used and where its
+	 * migrations live
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for database tools');
+
+		try {
+			const packageJson = await this.readPackageJson();
+			const migrationsDirectories = await this.findMigrationsDirectories();
+			const detectedTools = await Promise.all([
+				this.detectPrisma(packageJson),
+				this.detectDrizzle(packageJson),
+				this.detectTypeOrm(packageJson),
+				this.detectSequelize(packageJson),
+				this.detectKnex(packageJson),
+			]);
+			const tools = detectedTools
+				.filter((tool) => tool !== undefined)
+				.filter((tool) =>
+					this.hasEnoughEvidence(tool.files.map((file) => ({file}))),
+				);
+
+			// If no database tool is used, don't return any recommendations
+			if (tools.length === 0) {
+				return [];
+			}
+
+			const recommendations: AiRule[] = [];
+			const toolNames = tools.map((tool) =>
+				tool.database ? `${tool.name} (${tool.database})` : tool.name,
+			);
+
+			recommendations.push({
+				category: Category.Database,
+				rule: `Use ${toolNames.join(' and ')} for database access. Do not write raw SQL queries that bypass it.`,
+				severity: Severity.High,
+				files: [...new Set(tools.flatMap((tool) => tool.files))],
+			});
+
+			for (const tool of tools) {
+				// Fall back to a migrations directory found in the project
+				const migrationsDirectory =
+					tool.migrationsDirectory ??
+					(tools.length === 1 ? migrationsDirectories[0] : undefined);
+				const location = migrationsDirectory
+					? `${tool.name} migrations live in \`${migrationsDirectory}/\`. `
+					: '';
+
+				recommendations.push({
+					category: Category.Database,
+					rule: `${location}Create a new migration with \`${tool.migrationCommand}\` instead of changing the database schema directly, and do not edit migrations that were already applied.`,
+					severity: Severity.High,
+					files: tool.files,
+				});
+			}
+
+			return recommendations;
+		} catch (error) {
+			this.logger.error('Error scanning for database tools', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Detect Prisma and the datasource provider of its schema
+	 */
+	private async detectPrisma(
+		packageJson: Record<string, unknown> | undefined,
+	): Promise<DatabaseTool | undefined> {
+		const schemaFile = ['prisma/schema.prisma', 'schema.prisma'].find(
+			(fileName) => existsSync(path.join(this.rootPath, fileName)),
+		);
+		if (!schemaFile && !this.hasDependency(packageJson, 'prisma')) {
+			return undefined;
+		}
+
+		const schema = schemaFile ? await this.readFile(schemaFile) : undefined;
+		const datasource = /datasource\s+\w+\s*\{([^}]*)\}/.exec(
+			schema ?? '',
+		)?.[1];
+		const provider = /provider\s*=\s*"(\w+)"/.exec(datasource ?? '')?.[1];
+
+		return {
+			name: 'Prisma',
+			database: provider ? (databaseNames[provider] ?? provider) : undefined,
+			migrationsDirectory: schemaFile
+				? path.posix.join(path.posix.dirname(schemaFile), 'migrations')
+				: 'prisma/migrations',
+			migrationCommand: 'prisma migrate dev --name <name>',
+			files: [schemaFile ?? 'package.json'],
+		};
+	}
+
+	/**
+	 * Detect Drizzle ORM and the dialect and output directory of its config
+	 */
+	private async detectDrizzle(
+		packageJson: Record<string, unknown> | undefined,
+	): Promise<DatabaseTool | undefined> {
+		const configFile = this.findFile('drizzle.config');
+		if (!configFile && !this.hasDependency(packageJson, 'drizzle-orm')) {
+			return undefined;
+		}
+
+		const config = configFile ? await this.readFile(configFile) : undefined;
+		const dialect = /dialect\s*:\s*["'](\w+)["']/.exec(config ?? '')?.[1];
+		const out = /\bout\s*:\s*["']([^"']+)["']/.exec(config ?? '')?.[1];
+
+		return {
+			name: 'Drizzle ORM',
+			database: dialect ? (databaseNames[dialect] ?? dialect) : undefined,
+			migrationsDirectory: configFile
+				? this.normalizeDirectory(out ?? 'drizzle')
+				: undefined,
+			migrationCommand: 'drizzle-kit generate',
+			files: [configFile ?? 'package.json'],
+		};
+	}
+
+	/**
+	 * Detect TypeORM and the migrations glob of its data source
+	 */
+	private async detectTypeOrm(
+		packageJson: Record<string, unknown> | undefined,
+	): Promise<DatabaseTool | undefined> {
+		if (!this.hasDependency(packageJson, 'typeorm')) {
+			return undefined;
+		}
+
+		const configFile =
+			this.findFile('ormconfig') ??
+			this.findFile('data-source') ??
+			this.findFile('src/data-source');
+		const config = configFile ? await this.readFile(configFile) : undefined;
+		const migrationsGlob = /migrations["']?\s*:\s*\[\s*["']([^"']+)["']/.exec(
+			config ?? '',
+		)?.[1];
+
+		return {
+			name: 'TypeORM',
+			migrationsDirectory: migrationsGlob
+				? this.getGlobDirectory(migrationsGlob)
+				: undefined,
+			migrationCommand: 'typeorm migration:generate <path>',
+			files: configFile ? ['package.json', configFile] : ['package.json'],
+		};
+	}
+
+	/**
+	 * Detect Sequelize and the migrations path of .sequelizerc
+	 */
+	private async detectSequelize(
+		packageJson: Record<string, unknown> | undefined,
+	): Promise<DatabaseTool | undefined> {
+		if (!this.hasDependency(packageJson, 'sequelize')) {
+			return undefined;
+		}
+
+		const sequelizerc = await this.readFile('.sequelizerc');
+		const migrationsPath = sequelizeMigrationsPattern.exec(
+			sequelizerc ?? '',
+		);
+
+		// Only the string arguments of path.resolve() are part of the path
+		const migrationsDirectory = migrationsPath?.[1]
+			? [...migrationsPath[1].matchAll(/["']([^"']+)["']/g)]
+					.map((match) => match[1])
+					.join('/')
+			: migrationsPath?.[2];
+
+		return {
+			name: 'Sequelize',
+			migrationsDirectory: migrationsDirectory
+				? this.normalizeDirectory(migrationsDirectory)
+				: undefined,
+			migrationCommand: 'sequelize-cli migration:generate --name <name>',
+			files: sequelizerc ? ['package.json', '.sequelizerc'] : ['package.json'],
+		};
+	}
+
+	/**
+	 * Detect Knex and the migrations directory of its knexfile
+	 */
+	private async detectKnex(
+		packageJson: Record<string, unknown> | undefined,
+	): Promise<DatabaseTool | undefined> {
+		if (!this.hasDependency(packageJson, 'knex')) {
+			return undefined;
+		}
+
+		const knexfile = this.findFile('knexfile');
+		const config = knexfile ? await this.readFile(knexfile) : undefined;
+		const migrations = /migrations\s*:\s*\{([^}]*)\}/.exec(config ?? '')?.[1];
+		const directory = /directory\s*:\s*["']([^"']+)["']/.exec(
+			migrations ?? '',
+		)?.[1];
+
+		return {
+			name: 'Knex',
+			migrationsDirectory: this.normalizeDirectory(directory ?? 'migrations'),
+			migrationCommand: 'knex migrate:make <name>',
+			files: knexfile ? ['package.json', knexfile] : ['package.json'],
+		};
+	}
+
+	/**
+	 * Find directories named migrations or migration that contain files
+	 * @returns Directories relative to the root, shortest first
+	 */
+	private async findMigrationsDirectories(): Promise<string[]> {
+		const directories = new Set<string>();
+
+		for (const file of await this.fileIndex.getFiles()) {
+			const parts = path
+				.relative(this.rootPath, file)
+				.split(path.sep)
+				.slice(0, -1);
+			const index = parts.findIndex((part) =>
+				['migrations', 'migration'].includes(part),
+			);
+
+			if (index !== -1) {
+				directories.add(parts.slice(0, index + 1).join('/'));
+			}
+		}
+
+		return [...directories].sort((a, b) => a.length - b.length);
+	}
+
+	/**
+	 * Get the directory part of a glob, e.g. "src/migrations" for
+	 * "src/migrations/*.ts"
+	 */
+	private getGlobDirectory(glob: string): string {
+		const parts = this.normalizeDirectory(glob).split('/');
+		const globIndex = parts.findIndex((part) => /[*?{[]/.test(part));
+		return parts.slice(0, globIndex === -1 ? -1 : globIndex).join('/');
+	}
+
+	/**
+	 * Normalize a configured directory, e.g. "./db/migrations/" to
+	 * "db/migrations"
+	 */
+	private normalizeDirectory(directory: string): string {
+		return directory.replace(/^\.\//, '').replace(/\/+$/, '');
+	}
+
+	/**
+	 * Find a JavaScript or TypeScript file by its name without extension
+	 */
+	private findFile(baseName: string): string | undefined {
+		return ['.ts', '.js', '.mjs', '.cjs', '.json']
+			.map((extension) => `${baseName}${extension}`)
+			.find((fileName) => existsSync(path.join(this.rootPath, fileName)));
+	}
+
+	/**
+	 * Check if package.json declares a dependency
+	 */
+	private hasDependency(
+		packageJson: Record<string, unknown> | undefined,
+		dependency: string,
+	): boolean {
+		const dependencyFields = ['dependencies', 'devDependencies'] as const;
+
+		return dependencyFields.some((field) => {
+			const dependencies = packageJson?.[field];
+			return (
+				typeof dependencies === 'object' &&
+				dependencies !== null &&
+				dependency in dependencies
+			);
+		});
+	}
+
+	/**
+	 * Read and parse package.json
+	 */
+	private async readPackageJson(): Promise<
+		Record<string, unknown> | undefined
+	> {
+		const content = await this.readFile('package.json');
+		if (!content) {
+			return undefined;
+		}
+
+		try {
+			return JSON.parse(content) as Record<string, unknown>;
+		} catch (error) {
+			this.logger.error('Error parsing package.json', error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Read a file of the project, undefined if it does not exist
+	 */
+	private async readFile(fileName: string): Promise<string | undefined> {
+		const filePath = path.join(this.rootPath, fileName);
+		if (!existsSync(filePath)) {
+			return undefined;
+		}
+
+		try {
+			return await fs.readFile(filePath, 'utf8');
+		} catch (error) {
+			this.logger.error(`Error reading ${fileName}`, error);
+			return undefined;
+		}
+	}
+}
@@ -1 +1,2 @@
+export {DatabaseScanner} from './database-scanner.js';
 export {PrismaScanner} from './prisma-scanner.js';
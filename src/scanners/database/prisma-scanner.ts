@@ -1,13 +1,16 @@
 import {existsSync} from 'node:fs';
 import fs from 'node:fs/promises';
 import path from 'node:path';
-import {Category, type AiRule} from '../../types.js';
+import {Category, type AiRule, type Evidence} from '../../types.js';
 import {BaseScanner} from '../base/base-scanner.js';
 
 /**
  * Scanner to detect Prisma configuration and schema patterns in a project
  */
 export class PrismaScanner extends BaseScanner {
+	public readonly name = 'prisma';
+	public readonly watchedFiles = ['package.json', 'schema.prisma', 'seed.*'];
+
 	/**
 	 * Common Prisma configuration file names
 	 */
@@ -31,6 +34,14 @@ export class PrismaScanner extends BaseScanner {
 				return [];
 			}
 
+			const evidence: Evidence[] = [
+				...(schemaFile ? [{file: schemaFile}] : []),
+				...(hasDependency ? [{file: 'package.json'}] : []),
+			];
+			if (!this.hasEnoughEvidence(evidence)) {
+				return [];
+			}
+
 			// Initialize recommendations array
 			const recommendations: AiRule[] = [];
 
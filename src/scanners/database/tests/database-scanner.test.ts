@@ -0,0 +1,60 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {DatabaseScanner} from '../database-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+describe('DatabaseScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Prisma', () => {
+		it('should name the database and the migrations directory', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({devDependencies: {prisma: '^5.0.0'}}),
+				'prisma/schema.prisma':
+					'datasource db {\n  provider = "postgresql"\n  url      = env("DATABASE_URL")\n}\n',
+			});
+
+			const rules = await new DatabaseScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Use Prisma (PostgreSQL) for database access. Do not write raw SQL queries that bypass it.',
+				'Prisma migrations live in `prisma/migrations/`. Create a new migration with `prisma migrate dev --name <name>` instead of changing the database schema directly, and do not edit migrations that were already applied.',
+			]);
+		});
+	});
+
+	describe('Several tools', () => {
+		it('should read the migrations directory of each tool', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					dependencies: {'drizzle-orm': '^0.30.0', sequelize: '^6.0.0'},
+				}),
+				'drizzle.config.ts':
+					"export default {\n  dialect: 'sqlite',\n  out: './db/migrations',\n};\n",
+				'.sequelizerc':
+					"module.exports = {\n  'migrations-path': path.resolve('database', 'migrations'),\n};\n",
+			});
+
+			const rules = await new DatabaseScanner(rootPath).scan();
+
+			expect(rules[0].rule).toBe(
+				'Use Drizzle ORM (SQLite) and Sequelize for database access. Do not write raw SQL queries that bypass it.',
+			);
+			expect(rules.slice(1).map((rule) => rule.rule.split('. ')[0])).toEqual([
+				'Drizzle ORM migrations live in `db/migrations/`',
+				'Sequelize migrations live in `database/migrations/`',
+			]);
+		});
+
+		it('should not emit rules without a database tool', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {pg: '^8.0.0'}}),
+			});
+
+			expect(await new DatabaseScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
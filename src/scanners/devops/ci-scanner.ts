@@ -0,0 +1,439 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {
+	Category,
+	CommandPurpose,
+	type AiRule,
+	type ProjectCommand,
+} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * A GitHub Actions workflow and its jobs
+ */
+type Workflow = {
+	name: string;
+	file: string;
+	jobs: string[];
+	commands: string[];
+};
+
+/**
+ * A command run by CI and the files it was found in
+ */
+type CiCommand = {
+	command: string;
+	file: string;
+};
+
+/**
+ * Maximum number of workflows or jobs listed in a rule
+ */
+const maxListedItems = 5;
+
+/**
+ * Lint, test and build commands, surfaced as the canonical commands of the
+ * project in this order
+ */
+const commandPatterns: Array<[CommandPurpose, RegExp]> = [
+	[
+		CommandPurpose.Lint,
+		/\b(?:lint|eslint|xo|ruff|clippy|golangci-lint|flake8|biome)\b/,
+	],
+	[CommandPurpose.Test, /\b(?:test|tests|pytest|jest|vitest|mocha)\b/],
+	[CommandPurpose.Build, /\b(?:build|compile|tsc)\b/],
+];
+
+/**
+ * Top-level keys of .gitlab-ci.yml that are not jobs
+ */
+const gitlabKeywords = new Set([
+	'default',
+	'include',
+	'stages',
+	'variables',
+	'workflow',
+	'image',
+	'services',
+	'cache',
+	'before_script',
+	'after_script',
+]);
+
+/**
+ * Scanner to detect CI pipelines (GitHub Actions, GitLab CI, CircleCI,
+ * Jenkins), their jobs and the commands they run
+ */
+export class CIScanner extends BaseScanner {
+	public readonly name = 'ci';
+	public readonly watchedFiles = ['*.yml', '*.yaml', 'Jenkinsfile'];
+
+	/**
+	 * Scan the project to determine which CI system is used and what it runs
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for CI configuration');
+
+		try {
+			const recommendations: AiRule[] = [];
+			const commands: CiCommand[] = [];
+
+			const workflows = await this.readWorkflows();
+			if (workflows.length > 0) {
+				recommendations.push(this.getWorkflowsRule(workflows));
+				commands.push(
+					...workflows.flatMap((workflow) =>
+						workflow.commands.map((command) => ({
+							command,
+							file: workflow.file,
+						})),
+					),
+				);
+			}
+
+			const gitlab = await this.readFile('.gitlab-ci.yml');
+			if (gitlab !== undefined) {
+				const jobs = this.getTopLevelKeys(gitlab).filter(
+					(key) => !gitlabKeywords.has(key) && !key.startsWith('.'),
+				);
+				recommendations.push(
+					this.getPipelineRule('GitLab CI', '.gitlab-ci.yml', 'jobs', jobs),
+				);
+				commands.push(
+					...this.getListCommands(gitlab, 'script').map((command) => ({
+						command,
+						file: '.gitlab-ci.yml',
+					})),
+				);
+			}
+
+			const circleci = await this.readFile('.circleci/config.yml');
+			if (circleci !== undefined) {
+				recommendations.push(
+					this.getPipelineRule(
+						'CircleCI',
+						'.circleci/config.yml',
+						'jobs',
+						this.getChildKeys(circleci, 'jobs'),
+					),
+				);
+				commands.push(
+					...this.getRunCommands(circleci).map((command) => ({
+						command,
+						file: '.circleci/config.yml',
+					})),
+				);
+			}
+
+			const jenkinsfile = await this.readFile('Jenkinsfile');
+			if (jenkinsfile !== undefined) {
+				const stages = [
+					...jenkinsfile.matchAll(/\bstage\s*\(\s*["']([^"']+)["']/g),
+				].map((match) => match[1]);
+				recommendations.push(
+					this.getPipelineRule('Jenkins', 'Jenkinsfile', 'stages', stages),
+				);
+				commands.push(
+					...[...jenkinsfile.matchAll(/\bsh\s*\(?\s*(["'])(.+?)\1/g)].map(
+						(match) => ({command: match[2], file: 'Jenkinsfile'}),
+					),
+				);
+			}
+
+			const commandsRule = this.getCommandsRule(commands);
+			if (commandsRule) {
+				recommendations.push(commandsRule);
+			}
+
+			return this.hasEnoughEvidence(this.getRuleEvidence(recommendations))
+				? recommendations
+				: [];
+		} catch (error) {
+			this.logger.error('Error scanning for CI configuration', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Read the GitHub Actions workflows of .github/workflows
+	 */
+	private async readWorkflows(): Promise<Workflow[]> {
+		const workflowsDirectory = path.join(this.rootPath, '.github', 'workflows');
+		const workflowFiles = (await this.fileIndex.getFiles()).filter(
+			(file) =>
+				path.dirname(file) === workflowsDirectory &&
+				['.yml', '.yaml'].includes(path.extname(file)),
+		);
+
+		const workflows: Workflow[] = [];
+		for (const workflowFile of workflowFiles) {
+			const file = path
+				.relative(this.rootPath, workflowFile)
+				.split(path.sep)
+				.join('/');
+			// eslint-disable-next-line no-await-in-loop
+			const content = await this.readFile(file);
+			if (content === undefined) {
+				continue;
+			}
+
+			workflows.push({
+				name:
+					this.getTopLevelValue(content, 'name') ??
+					path.basename(file, path.extname(file)),
+				file,
+				jobs: this.getChildKeys(content, 'jobs'),
+				commands: this.getRunCommands(content),
+			});
+		}
+
+		return workflows;
+	}
+
+	/**
+	 * Get the rule listing the GitHub Actions workflows and their jobs
+	 */
+	private getWorkflowsRule(workflows: Workflow[]): AiRule {
+		const listedWorkflows = workflows
+			.slice(0, maxListedItems)
+			.map((workflow) => {
+				const jobs =
+					workflow.jobs.length > 0
+						? `: ${this.listItems(workflow.jobs, 'jobs')}`
+						: '';
+				return `${workflow.name} (\`${workflow.file}\`${jobs})`;
+			})
+			.join(', ');
+		const more =
+			workflows.length > maxListedItems
+				? ` and ${workflows.length - maxListedItems} more workflows`
+				: '';
+
+		return {
+			category: Category.CICD,
+			rule: `CI runs on GitHub Actions with the workflows ${listedWorkflows}${more}. Change the existing workflow that covers a task instead of adding a new workflow for it.`,
+			files: workflows.map((workflow) => workflow.file),
+		};
+	}
+
+	/**
+	 * Get the rule naming a CI system with a single pipeline file
+	 * @param system Name of the CI system
+	 * @param file Pipeline file relative to the root
+	 * @param itemName What the items are, e.g. "jobs" or "stages"
+	 * @param items Jobs or stages of the pipeline
+	 */
+	private getPipelineRule(
+		system: string,
+		file: string,
+		itemName: string,
+		items: string[],
+	): AiRule {
+		const listedItems =
+			items.length > 0
+				? ` with the ${itemName} ${this.listItems(items, itemName)}`
+				: '';
+
+		return {
+			category: Category.CICD,
+			rule: `CI runs on ${system}, configured in \`${file}\`${listedItems}. Change the pipeline there instead of adding another CI configuration.`,
+			files: [file],
+		};
+	}
+
+	/**
+	 * Get the rule with the lint, test and build commands run by CI
+	 * Only the first command of each kind is listed, and the commands are also
+	 * collected into the Commands rules
+	 */
+	private getCommandsRule(commands: CiCommand[]): AiRule | undefined {
+		const canonicalCommands: Array<CiCommand & ProjectCommand> = [];
+
+		for (const [purpose, pattern] of commandPatterns) {
+			const command = commands.find(
+				(candidate) =>
+					pattern.test(candidate.command) &&
+					!canonicalCommands.some(
+						(canonical) => canonical.command === candidate.command,
+					),
+			);
+			if (command) {
+				canonicalCommands.push({...command, purpose});
+			}
+		}
+
+		if (canonicalCommands.length === 0) {
+			return undefined;
+		}
+
+		const quotedCommands = canonicalCommands.map(
+			(command) => `\`${command.command}\``,
+		);
+		const commandList =
+			quotedCommands.length > 1
+				? `${quotedCommands.slice(0, -1).join(', ')} and ${quotedCommands.at(-1)}`
+				: quotedCommands[0];
+
+		return {
+			category: Category.CICD,
+			rule: `CI checks changes with ${commandList}. Run the same commands locally before pushing.`,
+			files: [...new Set(canonicalCommands.map((command) => command.file))],
+			commands: canonicalCommands.map(({command, purpose}) => ({
+				command,
+				purpose,
+			})),
+		};
+	}
+
+	/**
+	 * List items, e.g. "lint, test and 2 more jobs"
+	 */
+	private listItems(items: string[], itemName: string): string {
+		const listedItems = items.slice(0, maxListedItems).join(', ');
+		return items.length > maxListedItems
+			? `${listedItems} and ${items.length - maxListedItems} more ${itemName}`
+			: listedItems;
+	}
+
+	/**
+	 * Get the value of a top-level key of a YAML file
+	 */
+	private getTopLevelValue(content: string, key: string): string | undefined {
+		const value = new RegExp(`^${key}:[ \\t]*(.+)$`, 'm').exec(content)?.[1];
+		return value
+			?.replace(/\s+#.*$/, '')
+			.trim()
+			.replace(/^["']|["']$/g, '');
+	}
+
+	/**
+	 * Get the top-level keys of a YAML file
+	 */
+	private getTopLevelKeys(content: string): string[] {
+		return [...content.matchAll(/^([\w.-]+):/gm)].map((match) => match[1]);
+	}
+
+	/**
+	 * Get the keys nested directly under a top-level key of a YAML file
+	 */
+	private getChildKeys(content: string, key: string): string[] {
+		const lines = content.split('\n');
+		const start = lines.findIndex((line) => line.trimEnd() === `${key}:`);
+		if (start === -1) {
+			return [];
+		}
+
+		const keys: string[] = [];
+		let childIndent: number | undefined;
+
+		for (const line of this.getBlockLines(lines, start)) {
+			const match = /^(\s+)([\w.-]+):/.exec(line);
+			childIndent ??= match?.[1].length;
+
+			if (match && match[1].length === childIndent) {
+				keys.push(match[2]);
+			}
+		}
+
+		return keys;
+	}
+
+	/**
+	 * Get the commands of run steps, either inline or as a block
+	 * Also reads the command key of CircleCI run steps
+	 */
+	private getRunCommands(content: string): string[] {
+		const lines = content.split('\n');
+		const commands: string[] = [];
+
+		for (const [index, line] of lines.entries()) {
+			const value = /^\s*(?:-\s*)?(?:run|command):[ \t]*(.*)$/.exec(line)?.[1];
+			if (value === undefined) {
+				continue;
+			}
+
+			if (/^[|>][-+]?$/.test(value.trim())) {
+				commands.push(
+					...this.getBlockLines(lines, index).map((blockLine) =>
+						blockLine.trim(),
+					),
+				);
+			} else if (value.trim()) {
+				commands.push(value.trim().replace(/^["']|["']$/g, ''));
+			}
+		}
+
+		return commands.filter((command) => command && !command.startsWith('#'));
+	}
+
+	/**
+	 * Get the items of list keys such as GitLab "script"
+	 */
+	private getListCommands(content: string, key: string): string[] {
+		const lines = content.split('\n');
+		const commands: string[] = [];
+		const keyPattern = new RegExp(`^\\s*${key}:[ \\t]*(.*)$`);
+
+		for (const [index, line] of lines.entries()) {
+			const value = keyPattern.exec(line)?.[1].trim();
+			if (value === undefined) {
+				continue;
+			}
+
+			if (value) {
+				commands.push(value.replace(/^["']|["']$/g, ''));
+				continue;
+			}
+
+			for (const blockLine of this.getBlockLines(lines, index)) {
+				const item = /^\s*-\s+(.+)$/.exec(blockLine)?.[1];
+				if (item) {
+					commands.push(item.trim().replace(/^["']|["']$/g, ''));
+				}
+			}
+		}
+
+		return commands;
+	}
+
+	/**
+	 * Get the non-empty lines indented deeper than the line at an index,
+	 * up to the first line that is not
+	 */
+	private getBlockLines(lines: string[], index: number): string[] {
+		const indent = /^\s*/.exec(lines[index])?.[0].length ?? 0;
+		const blockLines: string[] = [];
+
+		for (const line of lines.slice(index + 1)) {
+			if (!line.trim()) {
+				continue;
+			}
+
+			if ((/^\s*/.exec(line)?.[0].length ?? 0) <= indent) {
+				break;
+			}
+
+			blockLines.push(line);
+		}
+
+		return blockLines;
+	}
+
+	/**
+	 * Read a file of the project, undefined if it does not exist
+	 */
+	private async readFile(fileName: string): Promise<string | undefined> {
+		const filePath = path.join(this.rootPath, fileName);
+		if (!existsSync(filePath)) {
+			return undefined;
+		}
+
+		try {
+			return await fs.readFile(filePath, 'utf8');
+		} catch (error) {
+			this.logger.error(`Error reading ${fileName}`, error);
+			return undefined;
+		}
+	}
+}
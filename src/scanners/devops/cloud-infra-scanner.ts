@@ -0,0 +1,358 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, Severity, type AiRule} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * Matches Serverless Framework config files, e.g. serverless.yml
+ */
+const serverlessConfigPattern = /^serverless\.(?:ya?ml|json|[cm]?[jt]s)$/;
+
+/**
+ * Matches CloudFormation and SAM templates, e.g. template.yaml
+ */
+const templatePattern = /^template\.(?:ya?ml|json)$/;
+
+/**
+ * Matches the provider name of serverless.yml
+ */
+const serverlessProviderPattern =
+	/^provider:[ \t]*\n(?:[ \t]+.*\n)*?[ \t]+name:[ \t]*["']?([\w-]+)/m;
+
+/**
+ * Matches the default runtime of serverless.yml, e.g. nodejs20.x
+ */
+const serverlessRuntimePattern = /^[ \t]+runtime:[ \t]*["']?([\w.-]+)/m;
+
+/**
+ * Matches the Transform field of a YAML template and the list following it
+ */
+const yamlTransformPattern = /^Transform:[ \t]*(.*)\n?((?:[ \t]+-.*\n?)*)/m;
+
+/**
+ * Matches CDK stack files, e.g. lib/api-stack.ts or app/api_stack.py
+ */
+const cdkStackPattern =
+	/(?:^|[-_./])stack\.(?:ts|js|py|go)$|Stack\.(?:java|cs)$/i;
+
+/**
+ * Display names of the providers of the Serverless Framework
+ */
+const serverlessProviderNames: Record<string, string> = {
+	aws: 'AWS',
+	azure: 'Azure',
+	google: 'Google Cloud',
+	openwhisk: 'OpenWhisk',
+};
+
+/**
+ * Languages of CDK apps, detected from the command of the app field of
+ * cdk.json, e.g. "npx ts-node --prefer-ts-exts bin/app.ts"
+ */
+const cdkLanguages: Array<{name: string; pattern: RegExp}> = [
+	{name: 'TypeScript', pattern: /\.ts\b|ts-node|tsx\b/},
+	{name: 'JavaScript', pattern: /\.[cm]?js\b/},
+	{name: 'Python', pattern: /\.py\b|python/},
+	{name: 'Go', pattern: /\bgo run\b|\.go\b/},
+	{name: 'Java', pattern: /\bmvn\b|\bgradlew?\b/},
+	{name: 'C#', pattern: /\bdotnet\b/},
+];
+
+/**
+ * Matches the entry point file of the app command of cdk.json
+ */
+const cdkEntryPointPattern = /([\w./-]+\.(?:[cm]?[jt]s|py|go))\b/;
+
+/**
+ * Scanner to detect serverless and cloud deployment tools (Serverless
+ * Framework, AWS SAM, CloudFormation, AWS CDK, Vercel and Netlify)
+ */
+export class CloudInfraScanner extends BaseScanner {
+	public readonly name = 'cloud-infra';
+	public readonly watchedFiles = [
+		'serverless.*',
+		'template.yaml',
+		'template.yml',
+		'template.json',
+		'samconfig.toml',
+		'cdk.json',
+		'*stack.*',
+		'*Stack.*',
+		'vercel.json',
+		'netlify.toml',
+	];
+
+	/**
+	 * Scan the project to determine which deployment tools are used
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for cloud deployment tools');
+
+		try {
+			const files = (await this.fileIndex.getFiles()).map((file) =>
+				this.toRelative(file),
+			);
+			const findFiles = (pattern: RegExp) =>
+				files.filter((file) => pattern.test(path.posix.basename(file)));
+
+			const rules = [
+				await this.getServerlessRule(findFiles(serverlessConfigPattern)),
+				...(await this.getTemplateRules(
+					findFiles(templatePattern),
+					findFiles(/^samconfig\.toml$/),
+				)),
+				await this.getCdkRule(findFiles(/^cdk\.json$/), files),
+				this.getHostingRule(
+					'Vercel',
+					findFiles(/^vercel\.json$/),
+					'Configure routes, headers and functions there instead of in the dashboard.',
+				),
+				this.getHostingRule(
+					'Netlify',
+					findFiles(/^netlify\.toml$/),
+					'Configure the build, redirects and functions there instead of in the dashboard.',
+				),
+			];
+
+			// Every tool is detected on its own, so each needs enough evidence
+			return rules
+				.filter((rule) => rule !== undefined)
+				.filter((rule) => this.hasEnoughEvidence(this.getRuleEvidence([rule])));
+		} catch (error) {
+			this.logger.error('Error scanning for cloud deployment tools', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the rule for the Serverless Framework, undefined if not used
+	 */
+	private async getServerlessRule(
+		configFiles: string[],
+	): Promise<AiRule | undefined> {
+		if (configFiles.length === 0) {
+			return undefined;
+		}
+
+		const content = await this.readFile(configFiles[0]);
+		const provider = serverlessProviderPattern.exec(content)?.[1];
+		const runtime = serverlessRuntimePattern.exec(content)?.[1];
+		const target = [
+			provider ? (serverlessProviderNames[provider] ?? provider) : undefined,
+			runtime ? `\`${runtime}\`` : undefined,
+		].filter((part) => part !== undefined);
+		const targetLabel =
+			target.length > 0 ? ` on ${target.join(' with ')}` : '';
+
+		return {
+			category: Category.Infrastructure,
+			rule: `Functions are deployed with the Serverless Framework${targetLabel}, declared in ${this.formatFiles(configFiles)}. Add functions and their events there instead of creating cloud resources by hand, and deploy with \`serverless deploy\`.`,
+			severity: Severity.High,
+			files: configFiles,
+		};
+	}
+
+	/**
+	 * Get the rules for SAM and plain CloudFormation templates
+	 * Only templates with the AWS::Serverless transform are SAM templates
+	 */
+	private async getTemplateRules(
+		templateFiles: string[],
+		samConfigFiles: string[],
+	): Promise<AiRule[]> {
+		const samTemplates: string[] = [];
+		const cloudFormationTemplates: string[] = [];
+
+		for (const file of templateFiles) {
+			// eslint-disable-next-line no-await-in-loop
+			const content = await this.readFile(file);
+			const transforms = file.endsWith('.json')
+				? this.getJsonTransforms(content)
+				: this.getYamlTransforms(content);
+
+			if (
+				transforms.some((transform) => transform.startsWith('AWS::Serverless'))
+			) {
+				samTemplates.push(file);
+			} else if (/AWSTemplateFormatVersion|AWS::\w+::\w+/.test(content)) {
+				cloudFormationTemplates.push(file);
+			}
+		}
+
+		const rules: AiRule[] = [];
+
+		if (samTemplates.length > 0) {
+			rules.push({
+				category: Category.Infrastructure,
+				rule: `Infrastructure is deployed with AWS SAM, declared in ${this.formatFiles(samTemplates)}. Declare functions as \`AWS::Serverless::Function\` resources there, build with \`sam build\` and deploy with \`sam deploy\`.`,
+				severity: Severity.High,
+				files: [...samTemplates, ...samConfigFiles],
+			});
+		}
+
+		if (cloudFormationTemplates.length > 0) {
+			rules.push({
+				category: Category.Infrastructure,
+				rule: `Infrastructure is declared in the CloudFormation template ${this.formatFiles(cloudFormationTemplates)}. Change resources there instead of in the AWS console, and do not use SAM resource types such as \`AWS::Serverless::Function\`, as the template has no SAM transform.`,
+				severity: Severity.High,
+				files: cloudFormationTemplates,
+			});
+		}
+
+		return rules;
+	}
+
+	/**
+	 * Get the rule for an AWS CDK app, naming the language of its constructs
+	 */
+	private async getCdkRule(
+		cdkConfigFiles: string[],
+		files: string[],
+	): Promise<AiRule | undefined> {
+		if (cdkConfigFiles.length === 0) {
+			return undefined;
+		}
+
+		const configFile = cdkConfigFiles[0];
+		const appCommand = await this.readCdkAppCommand(configFile);
+		const language = appCommand
+			? cdkLanguages.find((candidate) => candidate.pattern.test(appCommand))
+			: undefined;
+		const entryPoint = appCommand
+			? cdkEntryPointPattern.exec(appCommand)?.[1]
+			: undefined;
+
+		// Stacks live next to cdk.json, e.g. in lib/ for TypeScript apps
+		const appDirectory = path.posix.dirname(configFile);
+		const stackFiles = files.filter(
+			(file) =>
+				(appDirectory === '.' || file.startsWith(`${appDirectory}/`)) &&
+				cdkStackPattern.test(file),
+		);
+
+		const languageLabel = language ? ` in ${language.name}` : '';
+		const sentences = [
+			`Infrastructure is defined with AWS CDK${languageLabel}.`,
+		];
+
+		if (entryPoint) {
+			const entryPath = path.posix.join(appDirectory, entryPoint);
+			sentences.push(`The app entry point is \`${entryPath}\`.`);
+		}
+
+		if (stackFiles.length > 0) {
+			sentences.push(
+				`Stacks are defined in ${this.formatFiles(stackFiles.slice(0, 3))}.`,
+			);
+		}
+
+		sentences.push(
+			`Define resources as CDK constructs${languageLabel} instead of writing CloudFormation templates, and review changes with \`cdk diff\` before \`cdk deploy\`.`,
+		);
+
+		return {
+			category: Category.Infrastructure,
+			rule: sentences.join(' '),
+			severity: Severity.High,
+			files: [configFile, ...stackFiles.slice(0, 3)],
+		};
+	}
+
+	/**
+	 * Get the rule for a hosting platform configured by a file
+	 */
+	private getHostingRule(
+		platform: string,
+		configFiles: string[],
+		usage: string,
+	): AiRule | undefined {
+		if (configFiles.length === 0) {
+			return undefined;
+		}
+
+		return {
+			category: Category.Infrastructure,
+			rule: `The project is deployed to ${platform}, configured in ${this.formatFiles(configFiles)}. ${usage}`,
+			files: configFiles,
+		};
+	}
+
+	/**
+	 * Get the transforms of a YAML template, e.g. ["AWS::Serverless-2016-10-31"]
+	 */
+	private getYamlTransforms(content: string): string[] {
+		const match = yamlTransformPattern.exec(content);
+		if (!match) {
+			return [];
+		}
+
+		const [, inlineValue, listItems] = match;
+		// Either a single value or a list, inline or on the following lines
+		const values = [
+			...inlineValue.replaceAll(/[[\]]/g, '').split(','),
+			...listItems.split('\n').map((item) => item.replace(/^\s*-/, '')),
+		];
+
+		return values
+			.map((value) => value.trim().replaceAll(/^["']|["']$/g, ''))
+			.filter((value) => value.length > 0);
+	}
+
+	/**
+	 * Get the transforms of a JSON template
+	 */
+	private getJsonTransforms(content: string): string[] {
+		try {
+			const template = JSON.parse(content) as {Transform?: unknown};
+			const transforms = Array.isArray(template.Transform)
+				? (template.Transform as unknown[])
+				: [template.Transform];
+			return transforms.filter(
+				(transform): transform is string => typeof transform === 'string',
+			);
+		} catch {
+			return [];
+		}
+	}
+
+	/**
+	 * Read the command that runs the CDK app from cdk.json
+	 */
+	private async readCdkAppCommand(
+		configFile: string,
+	): Promise<string | undefined> {
+		try {
+			const cdkConfig = JSON.parse(await this.readFile(configFile)) as {
+				app?: unknown;
+			};
+			return typeof cdkConfig.app === 'string' ? cdkConfig.app : undefined;
+		} catch (error) {
+			this.logger.error(`Error reading ${configFile}`, error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Format files as a list of code spans, e.g. "`a.yml` and `b.yml`"
+	 */
+	private formatFiles(files: string[]): string {
+		const names = files.map((file) => `\`${file}\``);
+		return names.length > 1
+			? `${names.slice(0, -1).join(', ')} and ${names.at(-1)}`
+			: names[0];
+	}
+
+	/**
+	 * Read a file relative to the root
+	 */
+	private async readFile(file: string): Promise<string> {
+		return fs.readFile(path.join(this.rootPath, file), 'utf8');
+	}
+
+	/**
+	 * Get a path relative to the root with forward slashes
+	 */
+	private toRelative(file: string): string {
+		return path.relative(this.rootPath, file).split(path.sep).join('/');
+	}
+}
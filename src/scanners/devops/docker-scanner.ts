@@ -0,0 +1,309 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, type AiRule, type Evidence} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * Parsed information from a Dockerfile
+ */
+type DockerfileInfo = {
+	baseImages: string[];
+	stageCount: number;
+	stageNames: string[];
+	exposedPorts: string[];
+};
+
+/**
+ * Weight of Docker files at the root of the scanned directory or of one of its
+ * packages, which build or run the project and are enough evidence on their own
+ */
+const projectFileWeight = 2;
+
+/**
+ * Manifests marking the root of a package, e.g. packages/api of a workspace
+ */
+const projectManifestNames = new Set([
+	'package.json',
+	'go.mod',
+	'Cargo.toml',
+	'pyproject.toml',
+	'requirements.txt',
+	'pom.xml',
+	'build.gradle',
+]);
+
+/**
+ * Scanner to detect Docker configuration (Dockerfiles and docker compose files)
+ */
+export class DockerScanner extends BaseScanner {
+	public readonly name = 'docker';
+	public readonly watchedFiles = [
+		'Dockerfile*',
+		'*.Dockerfile',
+		'compose.*',
+		'docker-compose.*',
+	];
+
+	// A single Dockerfile outside of a package, e.g. of an example, is not
+	// enough
+	protected readonly defaultMinEvidence = 2;
+
+	/**
+	 * Docker compose file names
+	 */
+	private readonly composeFileNames = [
+		'docker-compose.yml',
+		'docker-compose.yaml',
+		'compose.yml',
+		'compose.yaml',
+	];
+
+	/**
+	 * Scan the project to determine if and how Docker is configured
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for Docker configuration');
+
+		try {
+			const files = await this.fileIndex.getFiles();
+			const dockerfiles = files.filter((file) =>
+				this.isDockerfile(path.basename(file)),
+			);
+			const composeFiles = files.filter((file) =>
+				this.composeFileNames.includes(path.basename(file)),
+			);
+
+			// If no Docker files found, don't return any recommendations
+			if (dockerfiles.length === 0 && composeFiles.length === 0) {
+				return [];
+			}
+
+			const evidence = this.getEvidence(
+				[...dockerfiles, ...composeFiles],
+				files,
+			);
+			if (!this.hasEnoughEvidence(evidence)) {
+				return [];
+			}
+
+			const recommendations: AiRule[] = [];
+
+			for (const dockerfile of dockerfiles) {
+				// eslint-disable-next-line no-await-in-loop
+				const info = await this.parseDockerfile(dockerfile);
+				if (info) {
+					recommendations.push(
+						...this.getDockerfileRules(this.toRelative(dockerfile), info),
+					);
+				}
+			}
+
+			for (const composeFile of composeFiles) {
+				// eslint-disable-next-line no-await-in-loop
+				const services = await this.parseComposeServices(composeFile);
+				if (services.length > 0) {
+					recommendations.push({
+						category: Category.Containerization,
+						rule: `${this.toRelative(composeFile)} declares the services: ${services.join(', ')}. Reference these existing service names instead of inventing new ones.`,
+						files: [this.toRelative(composeFile)],
+					});
+				}
+			}
+
+			return recommendations;
+		} catch (error) {
+			this.logger.error('Error scanning for Docker configuration', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the evidence of Docker files
+	 * Files at the root or next to the manifest of a package count more than a
+	 * lone file in another directory, e.g. examples/demo/Dockerfile
+	 * @param dockerFiles Dockerfiles and compose files
+	 * @param files All files of the project
+	 */
+	private getEvidence(dockerFiles: string[], files: string[]): Evidence[] {
+		const projectDirectories = new Set([
+			this.rootPath,
+			...files
+				.filter((file) => projectManifestNames.has(path.basename(file)))
+				.map((file) => path.dirname(file)),
+		]);
+
+		return [
+			...dockerFiles.map((file) => ({
+				file: this.toRelative(file),
+				weight: projectDirectories.has(path.dirname(file))
+					? projectFileWeight
+					: 1,
+			})),
+			...files
+				.filter((file) => path.basename(file) === '.dockerignore')
+				.map((file) => ({file: this.toRelative(file)})),
+		];
+	}
+
+	/**
+	 * Check if a file name is a Dockerfile (Dockerfile, Dockerfile.* or
+	 * *.Dockerfile)
+	 */
+	private isDockerfile(fileName: string): boolean {
+		return (
+			fileName === 'Dockerfile' ||
+			fileName.startsWith('Dockerfile.') ||
+			fileName.endsWith('.Dockerfile')
+		);
+	}
+
+	/**
+	 * Get a path relative to the scanned root for display
+	 */
+	private toRelative(filePath: string): string {
+		return path.relative(this.rootPath, filePath).split(path.sep).join('/');
+	}
+
+	/**
+	 * Parse a Dockerfile to extract base images, stages and exposed ports
+	 */
+	private async parseDockerfile(
+		filePath: string,
+	): Promise<DockerfileInfo | undefined> {
+		try {
+			const content = await fs.readFile(filePath, 'utf8');
+			const info: DockerfileInfo = {
+				baseImages: [],
+				stageCount: 0,
+				stageNames: [],
+				exposedPorts: [],
+			};
+
+			for (const line of content.split('\n')) {
+				const trimmedLine = line.trim();
+
+				// FROM [--platform=<platform>] <image> [AS <name>]
+				const fromMatch =
+					/^from\s+(?:--\S+\s+)*(\S+)(?:\s+as\s+(\S+))?/i.exec(trimmedLine);
+				if (fromMatch) {
+					info.stageCount++;
+					const image = fromMatch[1];
+
+					// Stages built on top of a previous stage are not base images
+					if (
+						!info.stageNames.includes(image) &&
+						!info.baseImages.includes(image)
+					) {
+						info.baseImages.push(image);
+					}
+
+					if (fromMatch[2]) {
+						info.stageNames.push(fromMatch[2]);
+					}
+
+					continue;
+				}
+
+				// EXPOSE <port> [<port>/<protocol>...]
+				const exposeMatch = /^expose\s+(.+)/i.exec(trimmedLine);
+				if (exposeMatch) {
+					for (const port of exposeMatch[1].split(/\s+/)) {
+						if (port && !info.exposedPorts.includes(port)) {
+							info.exposedPorts.push(port);
+						}
+					}
+				}
+			}
+
+			return info;
+		} catch (error) {
+			this.logger.error(`Error reading Dockerfile ${filePath}`, error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Get rules derived from a parsed Dockerfile
+	 */
+	private getDockerfileRules(
+		relativePath: string,
+		info: DockerfileInfo,
+	): AiRule[] {
+		const rules: AiRule[] = [];
+
+		if (info.baseImages.length > 0) {
+			const imageLabel = info.baseImages.length > 1 ? 'images' : 'image';
+			rules.push({
+				category: Category.Containerization,
+				rule: `Use the existing base ${imageLabel} ${info.baseImages.join(', ')} from ${relativePath} instead of switching to a different one.`,
+				files: [relativePath],
+			});
+		}
+
+		if (info.stageCount > 1) {
+			const stageList =
+				info.stageNames.length > 0
+					? ` (stages: ${info.stageNames.join(', ')})`
+					: '';
+			rules.push({
+				category: Category.Containerization,
+				rule: `${relativePath} uses a multi-stage build${stageList}. Preserve the multi-stage build layout and keep build-only dependencies out of the final stage.`,
+				files: [relativePath],
+			});
+		}
+
+		if (info.exposedPorts.length > 0) {
+			rules.push({
+				category: Category.Containerization,
+				rule: `${relativePath} exposes port(s) ${info.exposedPorts.join(', ')}. Keep the application listening on these ports.`,
+				files: [relativePath],
+			});
+		}
+
+		return rules;
+	}
+
+	/**
+	 * Parse the top-level service names from a docker compose file
+	 */
+	private async parseComposeServices(filePath: string): Promise<string[]> {
+		try {
+			const content = await fs.readFile(filePath, 'utf8');
+			const services: string[] = [];
+			let inServices = false;
+			let serviceIndent: number | undefined;
+
+			for (const line of content.split('\n')) {
+				// Skip empty lines and comments
+				if (!line.trim() || line.trim().startsWith('#')) {
+					continue;
+				}
+
+				const indent = line.length - line.trimStart().length;
+
+				if (indent === 0) {
+					inServices = /^services\s*:/.test(line);
+					serviceIndent = undefined;
+					continue;
+				}
+
+				if (!inServices) {
+					continue;
+				}
+
+				// The first indented key under services defines the service indentation
+				serviceIndent ??= indent;
+
+				const keyMatch = /^\s*([\w.-]+)\s*:/.exec(line);
+				if (indent === serviceIndent && keyMatch) {
+					services.push(keyMatch[1]);
+				}
+			}
+
+			return services;
+		} catch (error) {
+			this.logger.error(`Error reading compose file ${filePath}`, error);
+			return [];
+		}
+	}
+}
@@ -0,0 +1,5 @@
+export {CIScanner} from './ci-scanner.js';
+export {CloudInfraScanner} from './cloud-infra-scanner.js';
+export {DockerScanner} from './docker-scanner.js';
+export {KubernetesScanner} from './kubernetes-scanner.js';
+export {TerraformScanner} from './terraform-scanner.js';
@@ -0,0 +1,233 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {
+	Category,
+	Confidence,
+	Severity,
+	type AiRule,
+	type Evidence,
+} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * Weight of Helm charts and of manifests at the root of the scanned
+ * directory, which are enough evidence on their own
+ */
+const strongEvidenceWeight = 2;
+
+/**
+ * Matches lock files written as YAML, e.g. pnpm-lock.yaml, which are never
+ * manifests and can be large
+ */
+const lockFilePattern = /(?:^|[-.])lock\.ya?ml$/;
+
+/**
+ * Number of bytes read from the start of a YAML file to tell if it can be a
+ * manifest, before reading the whole file
+ */
+const headerSize = 4096;
+
+/**
+ * Matches the top-level keys every Kubernetes manifest has
+ */
+const manifestKeyPattern = /^(?:apiVersion|kind)\s*:/m;
+
+/**
+ * Scanner to detect Kubernetes manifests and Helm charts in a project
+ */
+export class KubernetesScanner extends BaseScanner {
+	public readonly name = 'kubernetes';
+	// Manifests are recognized by the contents of any YAML file
+	public readonly confidence = Confidence.High;
+	public readonly watchedFiles = ['*.yaml', '*.yml'];
+	// A single nested resource, e.g. a fixture, is not enough
+	protected readonly defaultMinEvidence = 2;
+
+	/**
+	 * Scan the project to determine if and how Kubernetes is configured
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for Kubernetes configuration');
+
+		try {
+			const files = await this.fileIndex.getFiles();
+			const yamlFiles = files.filter(
+				(file) =>
+					(file.endsWith('.yaml') || file.endsWith('.yml')) &&
+					!lockFilePattern.test(path.basename(file)),
+			);
+
+			// Helm charts are identified by their Chart.yaml file
+			const chartDirectories = yamlFiles
+				.filter((file) => path.basename(file) === 'Chart.yaml')
+				.map((file) => path.dirname(file));
+
+			// Collect resource kinds from manifest files
+			const resourceKinds = new Set<string>();
+			const manifestKinds = new Map<string, Set<string>>();
+
+			for (const file of yamlFiles) {
+				// eslint-disable-next-line no-await-in-loop
+				const kinds = await this.getManifestKinds(file);
+				if (kinds.length > 0) {
+					manifestKinds.set(file, new Set(kinds));
+					for (const kind of kinds) {
+						resourceKinds.add(kind);
+					}
+				}
+			}
+
+			const manifestFiles = [...manifestKinds.keys()];
+
+			// If no Kubernetes configuration found, don't return any recommendations
+			if (chartDirectories.length === 0 && manifestFiles.length === 0) {
+				return [];
+			}
+
+			const evidence: Evidence[] = [
+				...chartDirectories.map((directory) => ({
+					file: this.toRelative(path.join(directory, 'Chart.yaml')),
+					weight: strongEvidenceWeight,
+				})),
+				// A nested manifest counts once per kind it declares, so a single
+				// file with a whole deployment is enough but a lone fixture is not
+				...[...manifestKinds].map(([file, kinds]) => {
+					const relativePath = this.toRelative(file);
+					const isAtRoot = !relativePath.includes('/');
+					return {
+						file: relativePath,
+						weight: isAtRoot ? strongEvidenceWeight : kinds.size,
+					};
+				}),
+			];
+			if (!this.hasEnoughEvidence(evidence)) {
+				return [];
+			}
+
+			return this.generateRecommendations(
+				chartDirectories,
+				manifestFiles,
+				[...resourceKinds].sort(),
+			);
+		} catch (error) {
+			this.logger.error('Error scanning for Kubernetes configuration', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get a path relative to the scanned root for display
+	 */
+	private toRelative(filePath: string): string {
+		const relativePath = path.relative(this.rootPath, filePath);
+		return relativePath ? relativePath.split(path.sep).join('/') : '.';
+	}
+
+	/**
+	 * Get the resource kinds declared in a YAML file
+	 * Only documents with both top-level apiVersion and kind keys are Kubernetes manifests
+	 */
+	private async getManifestKinds(filePath: string): Promise<string[]> {
+		try {
+			if (!(await this.hasManifestKeys(filePath))) {
+				return [];
+			}
+
+			const content = await fs.readFile(filePath, 'utf8');
+			const kinds: string[] = [];
+
+			// A YAML file can contain multiple documents separated by ---
+			for (const document of content.split(/^---\s*$/m)) {
+				const hasApiVersion = /^apiVersion\s*:\s*\S+/m.test(document);
+				const kind = /^kind\s*:\s*["']?(\w+)["']?/m.exec(document)?.[1];
+
+				if (hasApiVersion && kind) {
+					kinds.push(kind);
+				}
+			}
+
+			return kinds;
+		} catch (error) {
+			this.logger.error(`Error reading YAML file ${filePath}`, error);
+			return [];
+		}
+	}
+
+	/**
+	 * Check if the start of a YAML file has the keys of a manifest, so other
+	 * YAML files such as CI workflows are not read whole
+	 */
+	private async hasManifestKeys(filePath: string): Promise<boolean> {
+		const handle = await fs.open(filePath, 'r');
+		try {
+			const buffer = Buffer.alloc(headerSize);
+			const {bytesRead} = await handle.read(buffer, 0, headerSize, 0);
+			return manifestKeyPattern.test(
+				buffer.subarray(0, bytesRead).toString('utf8'),
+			);
+		} finally {
+			await handle.close();
+		}
+	}
+
+	/**
+	 * Generate recommendations based on Kubernetes findings
+	 */
+	private generateRecommendations(
+		chartDirectories: string[],
+		manifestFiles: string[],
+		resourceKinds: string[],
+	): AiRule[] {
+		const recommendations: AiRule[] = [];
+
+		if (chartDirectories.length > 0) {
+			const charts = chartDirectories.map((directory) =>
+				this.toRelative(directory),
+			);
+			recommendations.push(
+				{
+					category: Category.Kubernetes,
+					rule: `Kubernetes resources are templated with Helm (charts: ${charts.join(', ')}). Edit the chart templates instead of adding plain manifests.`,
+					files: charts.map((chart) => `${chart}/Chart.yaml`),
+				},
+				{
+					category: Category.Kubernetes,
+					rule: 'Do not hardcode image tags, replica counts, resources or environment-specific settings in Helm templates. Put them in values.yaml and reference them through .Values.',
+					severity: Severity.High,
+					files: charts.map((chart) => `${chart}/Chart.yaml`),
+				},
+			);
+		}
+
+		// Manifests outside of Helm charts are plain Kubernetes manifests
+		const plainManifests = manifestFiles.filter(
+			(file) =>
+				!chartDirectories.some((directory) =>
+					file.startsWith(directory + path.sep),
+				),
+		);
+		if (plainManifests.length > 0) {
+			const directories = [
+				...new Set(
+					plainManifests.map((file) => this.toRelative(path.dirname(file))),
+				),
+			];
+			recommendations.push({
+				category: Category.Kubernetes,
+				rule: `Kubernetes resources are defined as plain manifests in ${directories.join(', ')}. Follow the structure of the existing manifests when adding resources.`,
+				files: plainManifests.map((file) => this.toRelative(file)),
+			});
+		}
+
+		if (resourceKinds.length > 0) {
+			recommendations.push({
+				category: Category.Kubernetes,
+				rule: `Detected Kubernetes resource kinds: ${resourceKinds.join(', ')}.`,
+				severity: Severity.Info,
+				files: manifestFiles.map((file) => this.toRelative(file)),
+			});
+		}
+
+		return recommendations;
+	}
+}
@@ -0,0 +1,338 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, Severity, type AiRule} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * Parsed information from the .tf files of a single directory
+ */
+type TerraformModule = {
+	directoryPath: string;
+	files: string[];
+	providers: string[];
+	backend?: string;
+	localModuleSources: string[];
+};
+
+/**
+ * Scanner to detect Terraform configuration (providers, backends and root modules)
+ */
+export class TerraformScanner extends BaseScanner {
+	public readonly name = 'terraform';
+	public readonly watchedFiles = ['*.tf', '*.tfvars', '.terraform.lock.hcl'];
+
+	/**
+	 * Display names of well-known providers
+	 */
+	private readonly providerNames: Record<string, string> = {
+		aws: 'AWS',
+		google: 'GCP',
+		'google-beta': 'GCP',
+		azurerm: 'Azure',
+		azuread: 'Azure AD',
+		kubernetes: 'Kubernetes',
+		helm: 'Helm',
+		cloudflare: 'Cloudflare',
+		github: 'GitHub',
+		datadog: 'Datadog',
+	};
+
+	/**
+	 * Display names of well-known state backends
+	 */
+	private readonly backendNames: Record<string, string> = {
+		s3: 'an S3 backend',
+		gcs: 'a GCS backend',
+		azurerm: 'an Azure Storage backend',
+		remote: 'Terraform Cloud',
+		cloud: 'Terraform Cloud',
+		http: 'an HTTP backend',
+		pg: 'a PostgreSQL backend',
+		consul: 'a Consul backend',
+	};
+
+	/**
+	 * Scan the project to determine if and how Terraform is configured
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for Terraform configuration');
+
+		try {
+			const files = await this.fileIndex.getFiles();
+			const terraformFiles = files.filter((file) => file.endsWith('.tf'));
+
+			// If no Terraform files found, don't return any recommendations
+			if (
+				terraformFiles.length === 0 ||
+				!this.hasEnoughEvidence(
+					terraformFiles.map((file) => ({file: this.toRelative(file)})),
+				)
+			) {
+				return [];
+			}
+
+			const modules = await this.parseModules(terraformFiles);
+			const rootModules = this.getRootModules(modules);
+
+			const recommendations: AiRule[] = [];
+			for (const module of rootModules) {
+				recommendations.push(...this.getRootModuleRules(module));
+			}
+
+			recommendations.push(
+				...this.getProjectRules(
+					modules,
+					files.filter((file) => file.endsWith('.tfvars')),
+					files.filter(
+						(file) => path.basename(file) === '.terraform.lock.hcl',
+					),
+				),
+			);
+
+			return recommendations;
+		} catch (error) {
+			this.logger.error('Error scanning for Terraform configuration', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get a path relative to the scanned root for display
+	 */
+	private toRelative(filePath: string): string {
+		const relativePath = path.relative(this.rootPath, filePath);
+		return relativePath ? relativePath.split(path.sep).join('/') : '.';
+	}
+
+	/**
+	 * Group .tf files by directory and parse each directory as a module
+	 */
+	private async parseModules(
+		terraformFiles: string[],
+	): Promise<TerraformModule[]> {
+		const filesByDirectory = new Map<string, string[]>();
+		for (const file of terraformFiles) {
+			const directoryPath = path.dirname(file);
+			filesByDirectory.set(directoryPath, [
+				...(filesByDirectory.get(directoryPath) ?? []),
+				file,
+			]);
+		}
+
+		const modules: TerraformModule[] = [];
+		for (const [directoryPath, files] of filesByDirectory) {
+			// eslint-disable-next-line no-await-in-loop
+			const contents = await Promise.all(
+				files.map(async (file) => this.readFile(file)),
+			);
+			modules.push(
+				this.parseModule(directoryPath, files, contents.join('\n')),
+			);
+		}
+
+		return modules.sort((a, b) =>
+			a.directoryPath.localeCompare(b.directoryPath),
+		);
+	}
+
+	/**
+	 * Read a Terraform file, returning empty content on errors
+	 */
+	private async readFile(filePath: string): Promise<string> {
+		try {
+			return await fs.readFile(filePath, 'utf8');
+		} catch (error) {
+			this.logger.error(`Error reading Terraform file ${filePath}`, error);
+			return '';
+		}
+	}
+
+	/**
+	 * Remove the comments of Terraform code
+	 * Strings are matched first and kept, so "#" and "//" inside them, e.g. in
+	 * URLs, do not start a comment
+	 */
+	private stripComments(content: string): string {
+		return content.replaceAll(
+			/"(?:[^"\\\n]|\\.)*"|\/\*[\s\S]*?\*\/|(?:#|\/\/).*$/gm,
+			(match) => (match.startsWith('"') ? match : ''),
+		);
+	}
+
+	/**
+	 * Parse the providers, backend and local module sources of a module
+	 */
+	private parseModule(
+		directoryPath: string,
+		files: string[],
+		content: string,
+	): TerraformModule {
+		// Strip comments to avoid matching commented-out configuration
+		const code = this.stripComments(content);
+
+		const providers = new Set<string>();
+
+		// Providers declared in terraform { required_providers { ... } }
+		for (const block of this.getBlocks(code, /required_providers\s*\{/g)) {
+			for (const match of block.matchAll(/^\s*([\w-]+)\s*=/gm)) {
+				providers.add(match[1]);
+			}
+		}
+
+		// Providers configured with provider "name" { ... } blocks
+		for (const match of code.matchAll(/^\s*provider\s+"([\w-]+)"/gm)) {
+			providers.add(match[1]);
+		}
+
+		// Keys of nested provider objects (source, version) are not providers
+		providers.delete('source');
+		providers.delete('version');
+		providers.delete('configuration_aliases');
+
+		let backend = /\bbackend\s+"([\w-]+)"/.exec(code)?.[1];
+		if (!backend && /^\s*cloud\s*\{/m.test(code)) {
+			backend = 'cloud';
+		}
+
+		const localModuleSources = [
+			...code.matchAll(/\bsource\s*=\s*"(\.{1,2}\/[^"]*)"/g),
+		].map((match) => path.resolve(directoryPath, match[1]));
+
+		return {
+			directoryPath,
+			files,
+			providers: [...providers].sort(),
+			backend,
+			localModuleSources,
+		};
+	}
+
+	/**
+	 * Get the bodies of all blocks whose opening matches the pattern
+	 */
+	private getBlocks(code: string, opening: RegExp): string[] {
+		const blocks: string[] = [];
+
+		for (const match of code.matchAll(opening)) {
+			const start = match.index + match[0].length;
+			let depth = 1;
+			let index = start;
+
+			while (index < code.length && depth > 0) {
+				if (code[index] === '{') {
+					depth++;
+				} else if (code[index] === '}') {
+					depth--;
+				}
+
+				index++;
+			}
+
+			blocks.push(code.slice(start, index - 1));
+		}
+
+		return blocks;
+	}
+
+	/**
+	 * Root modules are directories not used as a module by another directory
+	 */
+	private getRootModules(modules: TerraformModule[]): TerraformModule[] {
+		const childModules = new Set(
+			modules.flatMap((module) => module.localModuleSources),
+		);
+
+		return modules.filter(
+			(module) => !childModules.has(module.directoryPath),
+		);
+	}
+
+	/**
+	 * Get the display name of a provider
+	 */
+	private getProviderName(provider: string): string {
+		return this.providerNames[provider] ?? provider;
+	}
+
+	/**
+	 * Get rules describing a single root module
+	 */
+	private getRootModuleRules(module: TerraformModule): AiRule[] {
+		const rules: AiRule[] = [];
+		const relativeDirectory = this.toRelative(module.directoryPath);
+		const location =
+			relativeDirectory === '.' ? 'the project root' : relativeDirectory;
+		const files = module.files.map((file) => this.toRelative(file));
+
+		if (module.providers.length > 0) {
+			const providerNames = [
+				...new Set(
+					module.providers.map((provider) => this.getProviderName(provider)),
+				),
+			];
+			const providerLabel =
+				providerNames.length > 1 ? 'providers' : 'provider';
+			rules.push({
+				category: Category.Infrastructure,
+				rule: `The Terraform root module in ${location} uses the ${providerNames.join(', ')} ${providerLabel}. Use the configured ${providerLabel} for new resources.`,
+				files,
+			});
+		}
+
+		if (module.backend && module.backend !== 'local') {
+			const backendName =
+				this.backendNames[module.backend] ??
+				`a "${module.backend}" backend`;
+			rules.push({
+				category: Category.Infrastructure,
+				rule: `Terraform state for ${location} is stored in ${backendName}. Don't suggest local state or changing the backend configuration.`,
+				severity: Severity.High,
+				files,
+			});
+		}
+
+		return rules;
+	}
+
+	/**
+	 * Get rules that apply to the whole Terraform project
+	 */
+	private getProjectRules(
+		modules: TerraformModule[],
+		variableFiles: string[],
+		lockFiles: string[],
+	): AiRule[] {
+		const rules: AiRule[] = [];
+
+		const localModules = [
+			...new Set(modules.flatMap((module) => module.localModuleSources)),
+		]
+			.map((directoryPath) => this.toRelative(directoryPath))
+			.sort();
+		if (localModules.length > 0) {
+			rules.push({
+				category: Category.Infrastructure,
+				rule: `Reusable Terraform modules are defined in ${localModules.join(', ')}. Extend these modules instead of duplicating resources in root modules.`,
+			});
+		}
+
+		if (variableFiles.length > 0) {
+			const relativeFiles = variableFiles.map((file) => this.toRelative(file));
+			rules.push({
+				category: Category.Infrastructure,
+				rule: `Environment-specific values are set in .tfvars files (${relativeFiles.join(', ')}). Declare new settings as variables instead of hardcoding them in .tf files.`,
+				files: relativeFiles,
+			});
+		}
+
+		if (lockFiles.length > 0) {
+			rules.push({
+				category: Category.Infrastructure,
+				rule: 'Provider versions are pinned in .terraform.lock.hcl. Keep the lock file committed and update providers with `terraform init -upgrade`.',
+				files: lockFiles.map((file) => this.toRelative(file)),
+			});
+		}
+
+		return rules;
+	}
+}
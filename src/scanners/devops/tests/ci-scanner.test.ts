@@ -0,0 +1,98 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {CIScanner} from '../ci-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+const workflow = `name: CI
+on: [push]
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: pnpm lint
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: pnpm test
+      - run: pnpm build
+`;
+
+const gitlabCi = `stages:
+  - test
+
+.base:
+  image: node:22
+
+unit:
+  stage: test
+  script:
+    - npm ci
+    - npm test
+`;
+
+describe('CIScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('GitHub Actions', () => {
+		it('should list the workflows and their jobs', async () => {
+			rootPath = await createFixture({'.github/workflows/ci.yml': workflow});
+
+			const rules = await new CIScanner(rootPath).scan();
+
+			expect(rules[0].rule).toBe(
+				'CI runs on GitHub Actions with the workflows CI (`.github/workflows/ci.yml`: lint, test). Change the existing workflow that covers a task instead of adding a new workflow for it.',
+			);
+		});
+
+		it('should surface the lint, test and build commands', async () => {
+			rootPath = await createFixture({'.github/workflows/ci.yml': workflow});
+
+			const rules = await new CIScanner(rootPath).scan();
+
+			expect(rules.flatMap((rule) => rule.commands ?? [])).toEqual([
+				{command: 'pnpm lint', purpose: 'lint'},
+				{command: 'pnpm test', purpose: 'test'},
+				{command: 'pnpm build', purpose: 'build'},
+			]);
+		});
+	});
+
+	describe('Other CI systems', () => {
+		it('should skip hidden GitLab jobs and keywords', async () => {
+			rootPath = await createFixture({'.gitlab-ci.yml': gitlabCi});
+
+			const rules = await new CIScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'CI runs on GitLab CI, configured in `.gitlab-ci.yml` with the jobs unit. Change the pipeline there instead of adding another CI configuration.',
+				'CI checks changes with `npm test`. Run the same commands locally before pushing.',
+			]);
+		});
+
+		it('should read the stages and commands of a Jenkinsfile', async () => {
+			rootPath = await createFixture({
+				Jenkinsfile:
+					"pipeline {\n  stages {\n    stage('Build') {\n      steps { sh 'make build' }\n    }\n  }\n}\n",
+			});
+
+			const rules = await new CIScanner(rootPath).scan();
+
+			expect(rules[0].rule).toBe(
+				'CI runs on Jenkins, configured in `Jenkinsfile` with the stages Build. Change the pipeline there instead of adding another CI configuration.',
+			);
+			expect(rules.flatMap((rule) => rule.commands ?? [])).toEqual([
+				{command: 'make build', purpose: 'build'},
+			]);
+		});
+
+		it('should not emit rules without a CI configuration', async () => {
+			rootPath = await createFixture({'docker-compose.yml': 'services: {}\n'});
+
+			expect(await new CIScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
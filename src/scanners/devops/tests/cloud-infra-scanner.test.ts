@@ -0,0 +1,81 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {CloudInfraScanner} from '../cloud-infra-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+describe('CloudInfraScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Serverless Framework', () => {
+		it('should name the provider and the runtime', async () => {
+			rootPath = await createFixture({
+				'serverless.yml':
+					'service: api\nprovider:\n  name: aws\n  runtime: nodejs20.x\nfunctions:\n  hello:\n    handler: handler.hello\n',
+			});
+
+			const rules = await new CloudInfraScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Functions are deployed with the Serverless Framework on AWS with `nodejs20.x`, declared in `serverless.yml`. Add functions and their events there instead of creating cloud resources by hand, and deploy with `serverless deploy`.',
+			]);
+		});
+	});
+
+	describe('Templates', () => {
+		it('should tell SAM templates from CloudFormation templates', async () => {
+			rootPath = await createFixture({
+				'template.yaml':
+					"AWSTemplateFormatVersion: '2010-09-09'\nTransform: AWS::Serverless-2016-10-31\nResources: {}\n",
+				'infra/template.yaml':
+					"AWSTemplateFormatVersion: '2010-09-09'\nResources: {}\n",
+			});
+
+			const rules = await new CloudInfraScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.files)).toEqual([
+				['template.yaml'],
+				['infra/template.yaml'],
+			]);
+			expect(rules[0].rule).toMatch('deployed with AWS SAM');
+			expect(rules[1].rule).toMatch('CloudFormation template');
+		});
+	});
+
+	describe('AWS CDK', () => {
+		it('should name the language, the entry point and the stacks', async () => {
+			rootPath = await createFixture({
+				'cdk.json': JSON.stringify({
+					app: 'npx ts-node --prefer-ts-exts bin/app.ts',
+				}),
+				'lib/api-stack.ts': 'export class ApiStack {}\n',
+			});
+
+			const rules = await new CloudInfraScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Infrastructure is defined with AWS CDK in TypeScript. The app entry point is `bin/app.ts`. Stacks are defined in `lib/api-stack.ts`. Define resources as CDK constructs in TypeScript instead of writing CloudFormation templates, and review changes with `cdk diff` before `cdk deploy`.',
+			]);
+		});
+	});
+
+	describe('Hosting', () => {
+		it('should point to the hosting config file', async () => {
+			rootPath = await createFixture({'vercel.json': '{}'});
+
+			const rules = await new CloudInfraScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'The project is deployed to Vercel, configured in `vercel.json`. Configure routes, headers and functions there instead of in the dashboard.',
+			]);
+		});
+
+		it('should not emit rules without deployment config', async () => {
+			rootPath = await createFixture({'lib/api-stack.ts': 'export {};\n'});
+
+			expect(await new CloudInfraScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
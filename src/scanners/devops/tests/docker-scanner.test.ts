@@ -0,0 +1,88 @@
+import fs from 'node:fs/promises';
+import os from 'node:os';
+import path from 'node:path';
+import {afterEach, beforeEach, describe, expect, it} from 'vitest';
+import {DockerScanner} from '../docker-scanner.js';
+
+const dockerfile = 'FROM node:22-alpine\nEXPOSE 3000\n';
+
+describe('DockerScanner', () => {
+	let rootPath: string;
+
+	beforeEach(async () => {
+		rootPath = await fs.mkdtemp(path.join(os.tmpdir(), 'psst-docker-'));
+		await fs.writeFile(
+			path.join(rootPath, 'package.json'),
+			JSON.stringify({name: 'library'}),
+		);
+	});
+
+	afterEach(async () => {
+		await fs.rm(rootPath, {recursive: true, force: true});
+	});
+
+	/**
+	 * Write a Dockerfile in a directory relative to the root
+	 */
+	async function writeDockerfile(directory: string): Promise<void> {
+		await fs.mkdir(path.join(rootPath, directory), {recursive: true});
+		await fs.writeFile(
+			path.join(rootPath, directory, 'Dockerfile'),
+			dockerfile,
+		);
+	}
+
+	describe('Dockerfiles', () => {
+		it('should parse Dockerfiles named after their image', async () => {
+			await fs.writeFile(path.join(rootPath, 'api.Dockerfile'), dockerfile);
+
+			const rules = await new DockerScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toContain(
+				'Use the existing base image node:22-alpine from api.Dockerfile instead of switching to a different one.',
+			);
+		});
+	});
+
+	describe('Evidence', () => {
+		it('should not emit rules for a single nested Dockerfile', async () => {
+			await writeDockerfile('examples/demo');
+
+			const rules = await new DockerScanner(rootPath).scan();
+
+			expect(rules).toEqual([]);
+		});
+
+		it('should emit rules for a Dockerfile at the root', async () => {
+			await writeDockerfile('.');
+
+			const rules = await new DockerScanner(rootPath).scan();
+
+			expect(rules.length).toBeGreaterThan(0);
+		});
+
+		it('should emit rules for the Dockerfile of a workspace package', async () => {
+			await writeDockerfile('packages/api');
+			await fs.writeFile(
+				path.join(rootPath, 'packages/api/package.json'),
+				JSON.stringify({name: 'api'}),
+			);
+
+			const rules = await new DockerScanner(rootPath).scan();
+
+			expect(rules.length).toBeGreaterThan(0);
+		});
+
+		it('should emit rules for a nested Dockerfile with a lower threshold', async () => {
+			await writeDockerfile('examples/demo');
+
+			const scanner = new DockerScanner(rootPath);
+			scanner.minEvidence = 1;
+			const rules = await scanner.scan();
+
+			expect(rules.flatMap((rule) => rule.files ?? [])).toContain(
+				'examples/demo/Dockerfile',
+			);
+		});
+	});
+});
@@ -0,0 +1,95 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {KubernetesScanner} from '../kubernetes-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+`;
+
+const service = `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`;
+
+describe('KubernetesScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	it('should detect plain manifests and their resource kinds', async () => {
+		rootPath = await createFixture({
+			'deployment.yaml': deployment,
+			'service.yaml': service,
+		});
+
+		const rules = (await new KubernetesScanner(rootPath).scan()).map(
+			(rule) => rule.rule,
+		);
+
+		expect(rules).toContain(
+			'Kubernetes resources are defined as plain manifests in .. Follow the structure of the existing manifests when adding resources.',
+		);
+		expect(rules).toContain(
+			'Detected Kubernetes resource kinds: Deployment, Service.',
+		);
+	});
+
+	it('should detect Helm charts', async () => {
+		rootPath = await createFixture({
+			'charts/web/Chart.yaml': 'apiVersion: v2\nname: web\nversion: 1.0.0\n',
+			'charts/web/values.yaml': 'replicaCount: 2\n',
+		});
+
+		const rules = (await new KubernetesScanner(rootPath).scan()).map(
+			(rule) => rule.rule,
+		);
+
+		expect(rules).toContain(
+			'Kubernetes resources are templated with Helm (charts: charts/web). Edit the chart templates instead of adding plain manifests.',
+		);
+	});
+
+	it('should detect a nested file declaring several resources', async () => {
+		rootPath = await createFixture({
+			'k8s/app.yaml': [
+				deployment,
+				service,
+				'apiVersion: networking.k8s.io/v1\nkind: Ingress\nmetadata:\n  name: web\n',
+			].join('---\n'),
+		});
+
+		const rules = (await new KubernetesScanner(rootPath).scan()).map(
+			(rule) => rule.rule,
+		);
+
+		expect(rules).toContain(
+			'Detected Kubernetes resource kinds: Deployment, Ingress, Service.',
+		);
+	});
+
+	it('should skip a single nested resource', async () => {
+		rootPath = await createFixture({
+			'test/fixtures/deployment.yaml': deployment,
+		});
+
+		const rules = await new KubernetesScanner(rootPath).scan();
+
+		expect(rules).toEqual([]);
+	});
+
+	it('should skip lock files and other YAML files', async () => {
+		rootPath = await createFixture({
+			'pnpm-lock.yaml': `lockfileVersion: '9.0'\n${deployment}`,
+			'.github/workflows/ci.yml': 'name: CI\non: push\n',
+		});
+
+		const rules = await new KubernetesScanner(rootPath).scan();
+
+		expect(rules).toEqual([]);
+	});
+});
@@ -0,0 +1,96 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {TerraformScanner} from '../terraform-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+const mainTf = `terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+
+  backend "s3" {
+    bucket = "state"
+  }
+}
+
+module "network" {
+  source = "../modules/network"
+}
+`;
+
+describe('TerraformScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Root modules', () => {
+		it('should describe the providers and the backend of root modules', async () => {
+			rootPath = await createFixture({
+				'infra/main.tf': mainTf,
+				'modules/network/main.tf': 'resource "aws_vpc" "main" {}\n',
+			});
+
+			const rules = await new TerraformScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'The Terraform root module in infra uses the AWS provider. Use the configured provider for new resources.',
+				"Terraform state for infra is stored in an S3 backend. Don't suggest local state or changing the backend configuration.",
+				'Reusable Terraform modules are defined in modules/network. Extend these modules instead of duplicating resources in root modules.',
+			]);
+		});
+
+		it('should ignore commented-out providers', async () => {
+			rootPath = await createFixture({
+				'main.tf': '# provider "google" {}\nprovider "aws" {}\n',
+			});
+
+			const rules = await new TerraformScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'The Terraform root module in the project root uses the AWS provider. Use the configured provider for new resources.',
+			]);
+		});
+
+		it('should keep "//" and "#" inside strings', async () => {
+			rootPath = await createFixture({
+				'infra/main.tf':
+					'module "network" {\n  source = "../modules//network"\n}\n\nprovider "aws" {\n  default_tags {\n    tags = {Team = "#infra"}\n  }\n}\n',
+				'modules/network/main.tf': 'resource "aws_vpc" "main" {}\n',
+			});
+
+			const rules = await new TerraformScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'The Terraform root module in infra uses the AWS provider. Use the configured provider for new resources.',
+				'Reusable Terraform modules are defined in modules/network. Extend these modules instead of duplicating resources in root modules.',
+			]);
+		});
+	});
+
+	describe('Project files', () => {
+		it('should point to the variable files and the lock file', async () => {
+			rootPath = await createFixture({
+				'main.tf': 'resource "null_resource" "noop" {}\n',
+				'prod.tfvars': 'region = "eu-west-1"\n',
+				'.terraform.lock.hcl': '',
+			});
+
+			const rules = await new TerraformScanner(rootPath).scan();
+
+			expect(rules.flatMap((rule) => rule.files ?? [])).toEqual([
+				'prod.tfvars',
+				'.terraform.lock.hcl',
+			]);
+		});
+
+		it('should not emit rules without .tf files', async () => {
+			rootPath = await createFixture({'prod.tfvars': 'region = "eu-west-1"\n'});
+
+			expect(await new TerraformScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
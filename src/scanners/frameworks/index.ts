@@ -1,2 +1,3 @@
 export {NextjsScanner} from './nextjs-scanner.js';
+export {ReactScanner} from './react-scanner.js';
 export {VueScanner} from './vue-scanner.js';
@@ -1,13 +1,25 @@
 import {existsSync} from 'node:fs';
 import fs from 'node:fs/promises';
 import path from 'node:path';
-import {Category, type AiRule} from '../../types.js';
+import {Category, Severity, type AiRule, type Evidence} from '../../types.js';
+import {getMajorVersion} from '../../utils/version.js';
 import {BaseScanner} from '../base/base-scanner.js';
 
 /**
  * Scanner to detect if NextJS is used in the project and extract configuration rules
  */
 export class NextjsScanner extends BaseScanner {
+	public readonly name = 'nextjs';
+	public readonly watchedFiles = [
+		'package.json',
+		'package-lock.json',
+		'pnpm-lock.yaml',
+		'yarn.lock',
+		'bun.lock',
+		'next.config.*',
+	];
+	public readonly versionedDependencies = ['next'];
+
 	/**
 	 * Scan the project to determine if NextJS is used and extract rules
 	 */
@@ -16,7 +28,8 @@ export class NextjsScanner extends BaseScanner {
 
 		try {
 			// Check if NextJS is used in the project
-			if (await this.isNextjsProject()) {
+			const evidence = await this.getEvidence();
+			if (this.hasEnoughEvidence(evidence)) {
 				const rules: AiRule[] = [
 					{
 						category: Category.NextJs,
@@ -24,6 +37,12 @@ export class NextjsScanner extends BaseScanner {
 					},
 				];
 
+				// Add guidance specific to the Next.js version in use
+				const nextVersion = await this.resolveDependencyVersion('next');
+				if (nextVersion) {
+					rules.push(...this.getVersionRules(nextVersion));
+				}
+
 				// Extract configuration rules
 				const configRules = await this.extractConfigRules();
 				rules.push(...configRules);
@@ -40,9 +59,11 @@ export class NextjsScanner extends BaseScanner {
 	}
 
 	/**
-	 * Check if NextJS is used in the project
+	 * Get the files showing that NextJS is used in the project
 	 */
-	private async isNextjsProject(): Promise<boolean> {
+	private async getEvidence(): Promise<Evidence[]> {
+		const evidence: Evidence[] = [];
+
 		// Check for next.config.js or next.config.ts files
 		const nextConfigFiles = [
 			'next.config.js',
@@ -54,12 +75,16 @@ export class NextjsScanner extends BaseScanner {
 		for (const configFile of nextConfigFiles) {
 			const configPath = path.join(this.rootPath, configFile);
 			if (existsSync(configPath)) {
-				return true;
+				evidence.push({file: configFile});
 			}
 		}
 
 		// Check if next.js is in dependencies
-		return this.hasDependency('next');
+		if (await this.hasDependency('next')) {
+			evidence.push({file: 'package.json'});
+		}
+
+		return evidence;
 	}
 
 	/**
@@ -87,6 +112,49 @@ export class NextjsScanner extends BaseScanner {
 		}
 	}
 
+	/**
+	 * Get rules specific to a Next.js version
+	 * No rules are returned when the major version can't be determined
+	 */
+	private getVersionRules(version: string): AiRule[] {
+		const majorVersion = getMajorVersion(version);
+		if (majorVersion === undefined) {
+			return [];
+		}
+
+		if (majorVersion >= 15) {
+			return [
+				{
+					category: Category.NextJs,
+					rule: `Next.js ${majorVersion} is in use: \`params\`, \`searchParams\`, \`cookies()\` and \`headers()\` are asynchronous and must be awaited.`,
+					severity: Severity.High,
+				},
+				{
+					category: Category.NextJs,
+					rule: `fetch requests and GET route handlers are not cached by default in Next.js ${majorVersion}. Opt into caching explicitly where needed.`,
+				},
+			];
+		}
+
+		if (majorVersion >= 13) {
+			return [
+				{
+					category: Category.NextJs,
+					rule: `Next.js ${majorVersion} is in use: \`params\`, \`searchParams\`, \`cookies()\` and \`headers()\` are synchronous. Do not await them like in Next.js 15.`,
+					severity: Severity.High,
+				},
+			];
+		}
+
+		return [
+			{
+				category: Category.NextJs,
+				rule: `Next.js ${majorVersion} does not support the App Router. Use the Pages Router with getServerSideProps or getStaticProps for data fetching.`,
+				severity: Severity.High,
+			},
+		];
+	}
+
 	/**
 	 * Extract configuration rules from NextJS config
 	 */
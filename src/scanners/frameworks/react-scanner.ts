@@ -0,0 +1,104 @@
+import {Category, Severity, type AiRule} from '../../types.js';
+import {parseVersion, type Version} from '../../utils/version.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * Scanner to detect the React version and emit version-specific guidance
+ */
+export class ReactScanner extends BaseScanner {
+	public readonly name = 'react';
+	public readonly watchedFiles = [
+		'package.json',
+		'package-lock.json',
+		'pnpm-lock.yaml',
+		'yarn.lock',
+		'bun.lock',
+	];
+	public readonly versionedDependencies = ['react', 'react-native'];
+
+	/**
+	 * Scan the project to determine if and which version of React is used
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for React');
+
+		try {
+			const reactVersion = await this.resolveDependencyVersion('react');
+
+			// If React is not a dependency, don't return any recommendations
+			if (
+				!reactVersion ||
+				!this.hasEnoughEvidence([{file: 'package.json'}])
+			) {
+				return [];
+			}
+
+			const version = parseVersion(reactVersion);
+			// React Native apps are not mounted with react-dom
+			const isReactNative =
+				(await this.resolveDependencyVersion('react-native')) !== undefined;
+
+			// Fall back to generic guidance when the version can't be determined
+			if (!version) {
+				return [
+					{
+						category: Category.React,
+						rule: 'Use React function components and hooks.',
+						files: ['package.json'],
+					},
+				];
+			}
+
+			return this.getVersionRules(version, isReactNative).map((rule) => ({
+				category: Category.React,
+				rule,
+				severity: Severity.High,
+				files: ['package.json'],
+				values: {version: `${version.major}`},
+			}));
+		} catch (error) {
+			this.logger.error('Error scanning for React', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the rules for a React version
+	 */
+	private getVersionRules(version: Version, isReactNative: boolean): string[] {
+		const {major, minor} = version;
+		const rules = [`Use React ${major}.`];
+
+		if (major >= 19) {
+			rules.push(
+				'Prefer the `use` hook for reading promises and context, and Actions with `useActionState` and `useFormStatus` for form submissions.',
+				'Pass `ref` as a regular prop to function components instead of wrapping them in `forwardRef`.',
+			);
+		} else if (major === 18) {
+			if (!isReactNative) {
+				rules.push(
+					'Mount the app with `createRoot` from react-dom/client instead of `ReactDOM.render`.',
+				);
+			}
+
+			rules.push(
+				'Do not use React 19 APIs such as the `use` hook, `useActionState` or `ref` as a prop.',
+			);
+		} else {
+			rules.push(
+				'Do not use React 18+ APIs such as `createRoot`, `useId`, `useTransition` or `useDeferredValue`.',
+			);
+		}
+
+		// Hooks were introduced in React 16.8
+		if (major > 16 || (major === 16 && minor >= 8)) {
+			rules.push('Use function components and hooks for new components.');
+		} else {
+			rules.push(
+				'Hooks are not available in this React version. Use class components for state and lifecycle methods.',
+			);
+		}
+
+		return rules;
+	}
+}
@@ -0,0 +1,76 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {ReactScanner} from '../react-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+describe('ReactScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Versions', () => {
+		it('should use the APIs of React 19', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {react: '^19.0.0'}}),
+			});
+
+			const rules = await new ReactScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Use React 19.',
+				'Prefer the `use` hook for reading promises and context, and Actions with `useActionState` and `useFormStatus` for form submissions.',
+				'Pass `ref` as a regular prop to function components instead of wrapping them in `forwardRef`.',
+				'Use function components and hooks for new components.',
+			]);
+		});
+
+		it('should not mount React Native apps with react-dom', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					dependencies: {react: '18.2.0', 'react-native': '0.74.0'},
+				}),
+			});
+
+			const rules = await new ReactScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Use React 18.',
+				'Do not use React 19 APIs such as the `use` hook, `useActionState` or `ref` as a prop.',
+				'Use function components and hooks for new components.',
+			]);
+		});
+
+		it('should use class components before hooks existed', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {react: '16.4.0'}}),
+			});
+
+			const rules = await new ReactScanner(rootPath).scan();
+
+			expect(rules.at(-1)?.rule).toBe(
+				'Hooks are not available in this React version. Use class components for state and lifecycle methods.',
+			);
+		});
+
+		it('should read the version locked in bun.lock', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {react: 'latest'}}),
+				'bun.lock':
+					'{\n  "lockfileVersion": 1,\n  "packages": {\n    "react": ["react@16.4.0", "", {}, "sha512-abc"],\n  },\n}\n',
+			});
+
+			const rules = await new ReactScanner(rootPath).scan();
+
+			expect(rules[0].rule).toBe('Use React 16.');
+		});
+
+		it('should not emit rules without React', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {vue: '^3.4.0'}}),
+			});
+
+			expect(await new ReactScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
@@ -0,0 +1,95 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {VueScanner} from '../vue-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+const scriptSetupComponent =
+	'<script setup lang="ts">\nconst count = ref(0);\n</script>\n';
+
+const optionsApiComponent = `<script>
+export default {
+  data() {
+    return {count: 0};
+  },
+};
+</script>
+`;
+
+describe('VueScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Component API', () => {
+		it('should ask for <script setup> in Vue 3', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {vue: '^3.4.0'}}),
+				'src/App.vue': scriptSetupComponent,
+			});
+
+			const rules = await new VueScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Use Vue.js 3.x as the framework.',
+				'Write components with the Composition API in `<script setup>`, with `ref`, `computed` and composables instead of the Options API or mixins, and declare props and events with `defineProps` and `defineEmits`.',
+			]);
+		});
+
+		it('should follow the Options API when most components use it', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {vue: '^3.4.0'}}),
+				'src/App.vue': scriptSetupComponent,
+				'src/Cart.vue': optionsApiComponent,
+				'src/List.vue': optionsApiComponent,
+			});
+
+			const rules = await new VueScanner(rootPath).scan();
+
+			expect(rules[1].rule).toBe(
+				'Most components are written with the Options API (`data`, `computed`, `methods`), which Vue 3 still supports. Follow it when changing them instead of mixing both APIs in a component.',
+			);
+			expect(rules[1].files).toEqual([
+				'package.json',
+				'src/Cart.vue',
+				'src/List.vue',
+			]);
+		});
+
+		it('should not use Vue 3 APIs in Vue 2', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {vue: '^2.6.14'}}),
+				'src/App.vue': optionsApiComponent,
+			});
+
+			const rules = await new VueScanner(rootPath).scan();
+
+			expect(rules[1].rule).toBe(
+				'This is a Vue 2 project. Write components with the Options API (`data`, `computed`, `methods`, `watch`). Do not use Vue 3 only APIs such as `createApp`, `Teleport` or components with several root elements.',
+			);
+		});
+	});
+
+	describe('Nuxt', () => {
+		it('should describe the conventions of Nuxt 3', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {nuxt: '^3.10.0'}}),
+				'nuxt.config.ts': 'export default defineNuxtConfig({});\n',
+			});
+
+			const rules = await new VueScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toContain(
+				'This is a Nuxt 3 app. Pages in `pages/` are routed by file name and server routes live in `server/api/`. Load data with `useFetch` or `useAsyncData` instead of fetching in `onMounted`, and rely on auto-imports of composables and components instead of importing them or adding routes by hand.',
+			);
+		});
+
+		it('should not emit rules for projects without Vue', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {react: '^19.0.0'}}),
+			});
+
+			expect(await new VueScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
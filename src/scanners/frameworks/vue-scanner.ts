@@ -1,13 +1,56 @@
 import {existsSync} from 'node:fs';
 import fs from 'node:fs/promises';
 import path from 'node:path';
-import {Category, type AiRule} from '../../types.js';
+import {Category, Severity, type AiRule, type Evidence} from '../../types.js';
+import {
+	getDefaultConcurrency,
+	mapWithConcurrency,
+} from '../../utils/concurrency.js';
+import {parseVersion, type Version} from '../../utils/version.js';
 import {BaseScanner} from '../base/base-scanner.js';
 
+/**
+ * Matches the <script setup> block of a single-file component
+ */
+const scriptSetupPattern = /<script\b[^>]*\bsetup\b/;
+
+/**
+ * Matches a component written with the Options API, e.g. export default {
+ * data() {...} }
+ */
+const optionsApiPattern =
+	/export\s+default\s*(?:defineComponent\s*\(\s*)?{[\s\S]*?\b(?:data\s*\(|methods\s*:|computed\s*:|props\s*:)/;
+
+/**
+ * Which API the single-file components of the project are written with
+ */
+type ComponentApiUsage = {
+	// Files using <script setup> and the Options API
+	scriptSetupFiles: string[];
+	optionsApiFiles: string[];
+};
+
 /**
  * Scanner to detect Vue.js version and configuration patterns in a project
+ * The major version decides between the Options API and the Composition API
  */
 export class VueScanner extends BaseScanner {
+	public readonly name = 'vue';
+	public readonly watchedFiles = [
+		'package.json',
+		'package-lock.json',
+		'pnpm-lock.yaml',
+		'yarn.lock',
+		'bun.lock',
+		'vue.config.*',
+		'vite.config.*',
+		'nuxt.config.*',
+		'quasar.conf*',
+		'tsconfig.json',
+		'*.vue',
+	];
+	public readonly versionedDependencies = ['vue', 'nuxt'];
+
 	/**
 	 * Scan the project to determine if Vue.js is used and extract configuration rules
 	 */
@@ -16,15 +59,19 @@ export class VueScanner extends BaseScanner {
 
 		try {
 			// Check if Vue.js is used in the project
-			if (await this.isVueProject()) {
+			if (this.hasEnoughEvidence(await this.getEvidence())) {
 				const rules: AiRule[] = [];
 
 				// Determine Vue version and add appropriate rule
-				const vueVersion = await this.detectVueVersion();
+				const declaredVersion = await this.detectVueVersion();
+				const vueVersion = declaredVersion
+					? parseVersion(declaredVersion)
+					: undefined;
 				if (vueVersion) {
 					rules.push({
 						category: Category.Vue,
-						rule: `Use Vue.js ${vueVersion} as the framework.`,
+						rule: `Use Vue.js ${vueVersion.major}.x as the framework.`,
+						values: {version: `${vueVersion.major}`},
 					});
 				} else {
 					rules.push({
@@ -33,6 +80,11 @@ export class VueScanner extends BaseScanner {
 					});
 				}
 
+				const componentApiRule = await this.getComponentApiRule(vueVersion);
+				if (componentApiRule) {
+					rules.push(componentApiRule);
+				}
+
 				// Extract configuration rules from various Vue config files
 				const configRules = await this.extractConfigRules();
 				rules.push(...configRules);
@@ -49,35 +101,32 @@ export class VueScanner extends BaseScanner {
 	}
 
 	/**
-	 * Check if Vue.js is used in the project
+	 * Get the files showing that Vue.js is used in the project
+	 * Vue is detected from its packages, Vue CLI and Nuxt config files, a Vite
+	 * config with the Vue plugin, or single-file components
 	 */
-	private async isVueProject(): Promise<boolean> {
-		// Check for Vue-specific config files
+	private async getEvidence(): Promise<Evidence[]> {
 		const vueConfigFiles = [
 			'vue.config.js',
 			'vue.config.ts',
-			'vite.config.js',
-			'vite.config.ts',
 			'nuxt.config.js',
+			'nuxt.config.mjs',
 			'nuxt.config.ts',
 		];
+		const evidence: Evidence[] = vueConfigFiles
+			.filter((configFile) => existsSync(path.join(this.rootPath, configFile)))
+			.map((file) => ({file}));
 
-		// Check config files for Vue-related content
-		const configFileChecks = vueConfigFiles.map(async (configFile) => {
-			const configPath = path.join(this.rootPath, configFile);
-			if (!existsSync(configPath)) {
-				return false;
-			}
-
-			return this.hasVueConfiguration(configPath);
-		});
-
-		const configResults = await Promise.all(configFileChecks);
-		const hasVueConfig = configResults.some(Boolean);
-
-		if (hasVueConfig) {
-			return true;
-		}
+		const viteConfigFiles = ['vite.config.js', 'vite.config.ts'];
+		const viteConfigChecks = viteConfigFiles.map(async (configFile) =>
+			this.hasVuePlugin(path.join(this.rootPath, configFile)),
+		);
+		const hasVuePlugin = await Promise.all(viteConfigChecks);
+		evidence.push(
+			...viteConfigFiles
+				.filter((_configFile, index) => hasVuePlugin[index])
+				.map((file) => ({file})),
+		);
 
 		// Check dependency patterns
 		const dependencyChecks = [
@@ -88,31 +137,43 @@ export class VueScanner extends BaseScanner {
 		];
 
 		const dependencyResults = await Promise.all(dependencyChecks);
-		return dependencyResults.some(Boolean);
+		if (dependencyResults.some(Boolean)) {
+			evidence.push({file: 'package.json'});
+		}
+
+		const componentFiles = await this.getComponentFiles();
+		evidence.push(...componentFiles.map((file) => ({file})));
+
+		return evidence;
 	}
 
 	/**
-	 * Check if a config file contains Vue-related configuration
+	 * Check if a Vite config file loads the Vue plugin
 	 */
-	private async hasVueConfiguration(configPath: string): Promise<boolean> {
+	private async hasVuePlugin(configPath: string): Promise<boolean> {
+		if (!existsSync(configPath)) {
+			return false;
+		}
+
 		try {
 			const fileContent = await fs.readFile(configPath, 'utf8');
-
-			// Check for Vue-specific keywords in config files
-			return (
-				fileContent.includes('vue') ||
-				fileContent.includes('Vue') ||
-				fileContent.includes('@vue') ||
-				fileContent.includes('nuxt') ||
-				fileContent.includes('Nuxt') ||
-				fileContent.includes('quasar') ||
-				fileContent.includes('Quasar')
-			);
+			return fileContent.includes('@vitejs/plugin-vue');
 		} catch {
 			return false;
 		}
 	}
 
+	/**
+	 * Get the single-file components of the project, relative to the root
+	 */
+	private async getComponentFiles(): Promise<string[]> {
+		return (await this.fileIndex.getFiles())
+			.filter((file) => file.endsWith('.vue'))
+			.map((file) =>
+				path.relative(this.rootPath, file).split(path.sep).join('/'),
+			);
+	}
+
 	/**
 	 * Check if package.json has a specific dependency
 	 */
@@ -157,8 +218,15 @@ export class VueScanner extends BaseScanner {
 
 	/**
 	 * Detect the Vue.js version being used
+	 * @returns The installed or locked version, else the range of package.json
 	 */
 	private async detectVueVersion(): Promise<string | undefined> {
+		// Prefer the installed or locked version over the declared range
+		const resolvedVersion = await this.resolveDependencyVersion('vue');
+		if (resolvedVersion) {
+			return resolvedVersion;
+		}
+
 		const packageJsonPath = path.join(this.rootPath, 'package.json');
 
 		if (!existsSync(packageJsonPath)) {
@@ -197,7 +265,7 @@ export class VueScanner extends BaseScanner {
 					continue;
 				}
 
-				return this.parseVueVersion(vueVersion);
+				return vueVersion;
 			}
 
 			return undefined;
@@ -208,27 +276,96 @@ export class VueScanner extends BaseScanner {
 	}
 
 	/**
-	 * Parse Vue version string to extract major version
+	 * Get the rule on the API to write components with
+	 * Vue 3 components use the Composition API, unless the existing components
+	 * mostly use the Options API. Vue 2 components use the Options API, unless
+	 * the existing components of a Vue 2.7 project use <script setup>.
+	 * @param vueVersion Version of Vue, undefined if unknown
 	 */
-	private parseVueVersion(version: string): string {
-		// Extract major version number
-		if (
-			version.startsWith('^3') ||
-			version.startsWith('~3') ||
-			version.startsWith('3')
-		) {
-			return '3.x';
+	private async getComponentApiRule(
+		vueVersion: Version | undefined,
+	): Promise<AiRule | undefined> {
+		const {scriptSetupFiles, optionsApiFiles} =
+			await this.getComponentApiUsage();
+		const prefersOptionsApi =
+			optionsApiFiles.length > scriptSetupFiles.length;
+		const evidenceFiles = prefersOptionsApi
+			? optionsApiFiles
+			: scriptSetupFiles;
+		const baseRule = {
+			category: Category.Vue,
+			severity: Severity.High,
+			files: [
+				...(vueVersion ? ['package.json'] : []),
+				...evidenceFiles.slice(0, 3),
+			],
+		};
+
+		if (vueVersion === undefined) {
+			if (evidenceFiles.length === 0) {
+				return undefined;
+			}
+
+			return {
+				...baseRule,
+				rule: prefersOptionsApi
+					? 'Components are written with the Options API (`data`, `computed`, `methods`). Follow it in new components.'
+					: 'Components are written with the Composition API in `<script setup>`. Follow it in new components.',
+			};
 		}
 
+		if (vueVersion.major >= 3) {
+			return {
+				...baseRule,
+				rule: prefersOptionsApi
+					? 'Most components are written with the Options API (`data`, `computed`, `methods`), which Vue 3 still supports. Follow it when changing them instead of mixing both APIs in a component.'
+					: 'Write components with the Composition API in `<script setup>`, with `ref`, `computed` and composables instead of the Options API or mixins, and declare props and events with `defineProps` and `defineEmits`.',
+			};
+		}
+
+		const vue3Apis =
+			'Do not use Vue 3 only APIs such as `createApp`, `Teleport` or components with several root elements.';
+
+		// Vue 2.7 backports the Composition API and <script setup>
 		if (
-			version.startsWith('^2') ||
-			version.startsWith('~2') ||
-			version.startsWith('2')
+			vueVersion.minor >= 7 &&
+			!prefersOptionsApi &&
+			evidenceFiles.length > 0
 		) {
-			return '2.x';
+			return {
+				...baseRule,
+				rule: `This is a Vue 2.7 project, components are written with the Composition API in \`<script setup>\`. Follow it in new components. ${vue3Apis}`,
+			};
 		}
 
-		return version;
+		return {
+			...baseRule,
+			rule: `This is a Vue 2 project. Write components with the Options API (\`data\`, \`computed\`, \`methods\`, \`watch\`). ${vue3Apis}`,
+		};
+	}
+
+	/**
+	 * Find which API the single-file components are written with
+	 */
+	private async getComponentApiUsage(): Promise<ComponentApiUsage> {
+		const componentFiles = await this.getComponentFiles();
+		const contents = await mapWithConcurrency(
+			componentFiles,
+			getDefaultConcurrency(),
+			async (file) =>
+				fs.readFile(path.join(this.rootPath, file), 'utf8').catch(() => ''),
+		);
+
+		return {
+			scriptSetupFiles: componentFiles.filter((_file, index) =>
+				scriptSetupPattern.test(contents[index]),
+			),
+			optionsApiFiles: componentFiles.filter(
+				(_file, index) =>
+					!scriptSetupPattern.test(contents[index]) &&
+					optionsApiPattern.test(contents[index]),
+			),
+		};
 	}
 
 	/**
@@ -242,7 +379,6 @@ export class VueScanner extends BaseScanner {
 		await this.checkViteVueProject(rules);
 		await this.checkNuxtProject(rules);
 		await this.checkQuasarProject(rules);
-		await this.checkCompositionApi(rules);
 		await this.checkVueRouter(rules);
 		await this.checkVuex(rules);
 		await this.checkPinia(rules);
@@ -340,21 +476,19 @@ export class VueScanner extends BaseScanner {
 
 	/**
 	 * Check for Nuxt.js project configuration
+	 * Nuxt 3 changed the data fetching and routing APIs, so the rule depends on
+	 * the major version
 	 */
 	private async checkNuxtProject(rules: AiRule[]): Promise<void> {
-		const nuxtConfigPath = path.join(this.rootPath, 'nuxt.config.js');
-		const nuxtConfigTsPath = path.join(this.rootPath, 'nuxt.config.ts');
-
-		if (existsSync(nuxtConfigPath) || existsSync(nuxtConfigTsPath)) {
-			rules.push({
-				category: Category.Vue,
-				rule: 'Use Nuxt.js for server-side rendering and static site generation.',
-			});
+		const configPath = ['nuxt.config.ts', 'nuxt.config.mjs', 'nuxt.config.js']
+			.map((configFile) => path.join(this.rootPath, configFile))
+			.find((candidate) => existsSync(candidate));
 
-			const configPath = existsSync(nuxtConfigTsPath)
-				? nuxtConfigTsPath
-				: nuxtConfigPath;
+		if (configPath || (await this.hasDependency('nuxt'))) {
+			rules.push(await this.getNuxtRule(configPath));
+		}
 
+		if (configPath) {
 			try {
 				const fileContent = await fs.readFile(configPath, 'utf8');
 
@@ -385,6 +519,40 @@ export class VueScanner extends BaseScanner {
 		}
 	}
 
+	/**
+	 * Get the rule for Nuxt, with the APIs of its major version
+	 * @param configPath Absolute path of the Nuxt config file, if any
+	 */
+	private async getNuxtRule(configPath: string | undefined): Promise<AiRule> {
+		const nuxtVersion = await this.resolveDependencyVersion('nuxt');
+		const major = nuxtVersion ? parseVersion(nuxtVersion)?.major : undefined;
+		const configFile = configPath
+			? path.relative(this.rootPath, configPath).split(path.sep).join('/')
+			: undefined;
+		const baseRule = {
+			category: Category.Vue,
+			severity: Severity.High,
+			files: [
+				...(nuxtVersion ? ['package.json'] : []),
+				...(configFile ? [configFile] : []),
+			],
+		};
+
+		if (major !== undefined && major < 3) {
+			return {
+				...baseRule,
+				rule: `This is a Nuxt ${major} app. Pages in \`pages/\` are routed by file name, load page data with the \`asyncData\` and \`fetch\` hooks, and register modules and plugins in the Nuxt config instead of in \`main.js\`.`,
+			};
+		}
+
+		const versionLabel = major === undefined ? '' : ` ${major}`;
+
+		return {
+			...baseRule,
+			rule: `This is a Nuxt${versionLabel} app. Pages in \`pages/\` are routed by file name and server routes live in \`server/api/\`. Load data with \`useFetch\` or \`useAsyncData\` instead of fetching in \`onMounted\`, and rely on auto-imports of composables and components instead of importing them or adding routes by hand.`,
+		};
+	}
+
 	/**
 	 * Check for Quasar project configuration
 	 */
@@ -404,22 +572,6 @@ export class VueScanner extends BaseScanner {
 		}
 	}
 
-	/**
-	 * Check for Vue Composition API usage
-	 */
-	private async checkCompositionApi(rules: AiRule[]): Promise<void> {
-		// Check if using Vue 3 or @vue/composition-api for Vue 2
-		const hasCompositionApi = await this.hasDependency('@vue/composition-api');
-		const vueVersion = await this.detectVueVersion();
-
-		if (vueVersion === '3.x' || hasCompositionApi) {
-			rules.push({
-				category: Category.Vue,
-				rule: 'Use Vue Composition API for component logic organization.',
-			});
-		}
-	}
-
 	/**
 	 * Check for Vue Router usage
 	 */
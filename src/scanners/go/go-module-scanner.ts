@@ -0,0 +1,281 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {
+	Category,
+	CommandPurpose,
+	Severity,
+	type AiRule,
+	type Evidence,
+} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * Parsed information from a go.mod file
+ */
+type GoModule = {
+	modulePath?: string;
+	directDependencies: string[];
+};
+
+/**
+ * Scanner to detect Go module configuration (go.mod and go.work files)
+ * This scanner extracts the module path and direct dependencies, the Go
+ * version is covered by GoVersionScanner
+ */
+export class GoModuleScanner extends BaseScanner {
+	public readonly name = 'go-module';
+	public readonly watchedFiles = ['go.mod', 'go.work'];
+
+	/**
+	 * Well-known Go libraries and the rule to emit when they are required
+	 */
+	private readonly knownLibraries: Record<string, string> = {
+		'github.com/gin-gonic/gin':
+			'Use Gin (github.com/gin-gonic/gin) for HTTP routing and middleware.',
+		'github.com/labstack/echo':
+			'Use Echo (github.com/labstack/echo) for HTTP routing and middleware.',
+		'github.com/gofiber/fiber':
+			'Use Fiber (github.com/gofiber/fiber) for HTTP routing and middleware.',
+		'github.com/go-chi/chi':
+			'Use chi (github.com/go-chi/chi) for HTTP routing and middleware.',
+		'gorm.io/gorm': 'Use GORM (gorm.io/gorm) for database access.',
+		'github.com/jmoiron/sqlx':
+			'Use sqlx (github.com/jmoiron/sqlx) for database access.',
+		'github.com/stretchr/testify':
+			'Use testify (github.com/stretchr/testify) assertions in tests.',
+		'github.com/spf13/cobra':
+			'Use Cobra (github.com/spf13/cobra) for CLI commands.',
+		'github.com/spf13/viper':
+			'Use Viper (github.com/spf13/viper) for configuration.',
+		'go.uber.org/zap': 'Use zap (go.uber.org/zap) for structured logging.',
+		'github.com/sirupsen/logrus':
+			'Use logrus (github.com/sirupsen/logrus) for structured logging.',
+		'google.golang.org/grpc':
+			'Use gRPC (google.golang.org/grpc) for service communication.',
+	};
+
+	/**
+	 * Go commands every Go module can run, by purpose
+	 */
+	private readonly goCommands: Record<string, CommandPurpose> = {
+		'go build ./...': CommandPurpose.Build,
+		'go test ./...': CommandPurpose.Test,
+		'go vet ./...': CommandPurpose.Lint,
+		'gofmt -w .': CommandPurpose.Format,
+	};
+
+	/**
+	 * Scan the project to determine Go module and workspace configuration
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for Go module configuration');
+
+		try {
+			const goModule = await this.readGoModule();
+			const workspaceModules = await this.readGoWorkspace();
+			const evidence: Evidence[] = [
+				...(goModule ? [{file: 'go.mod'}] : []),
+				...(workspaceModules ? [{file: 'go.work'}] : []),
+			];
+			if (evidence.length === 0 || !this.hasEnoughEvidence(evidence)) {
+				return [];
+			}
+
+			const recommendations: AiRule[] = [];
+
+			// Check go.mod for module information
+			if (goModule) {
+				recommendations.push(
+					...this.getModuleRules(goModule),
+					...this.getCommandRules(),
+				);
+			}
+
+			// Check go.work for workspace information
+			if (workspaceModules) {
+				recommendations.push(this.getWorkspaceRule(workspaceModules));
+			}
+
+			return recommendations;
+		} catch (error) {
+			this.logger.error('Error scanning for Go module configuration', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Read and parse the go.mod file
+	 */
+	private async readGoModule(): Promise<GoModule | undefined> {
+		const goModulePath = path.join(this.rootPath, 'go.mod');
+
+		if (!existsSync(goModulePath)) {
+			return undefined;
+		}
+
+		try {
+			const content = await fs.readFile(goModulePath, 'utf8');
+			return this.parseGoModule(content);
+		} catch (error) {
+			this.logger.error('Error reading go.mod file', error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Parse go.mod content into module path and direct dependencies
+	 */
+	private parseGoModule(content: string): GoModule {
+		const modulePath = /^module\s+(\S+)/m.exec(content)?.[1];
+		const directDependencies: string[] = [];
+
+		// Collect requirements from both block and single-line require directives
+		const requireLines: string[] = [];
+		for (const block of content.matchAll(/^require\s*\(([\s\S]*?)^\)/gm)) {
+			requireLines.push(...block[1].split('\n'));
+		}
+
+		for (const line of content.matchAll(/^require\s+([^(\s].*)$/gm)) {
+			requireLines.push(line[1]);
+		}
+
+		for (const line of requireLines) {
+			const trimmedLine = line.trim();
+
+			// Skip empty lines, comments and indirect dependencies
+			if (
+				!trimmedLine ||
+				trimmedLine.startsWith('//') ||
+				trimmedLine.includes('// indirect')
+			) {
+				continue;
+			}
+
+			const dependency = trimmedLine.split(/\s+/)[0];
+			if (dependency) {
+				directDependencies.push(dependency);
+			}
+		}
+
+		return {modulePath, directDependencies};
+	}
+
+	/**
+	 * Read the go.work file and return the modules it uses
+	 */
+	private async readGoWorkspace(): Promise<string[] | undefined> {
+		const goWorkPath = path.join(this.rootPath, 'go.work');
+
+		if (!existsSync(goWorkPath)) {
+			return undefined;
+		}
+
+		try {
+			const content = await fs.readFile(goWorkPath, 'utf8');
+			const modules: string[] = [];
+
+			// Collect modules from both block and single-line use directives
+			for (const block of content.matchAll(/^use\s*\(([\s\S]*?)^\)/gm)) {
+				for (const line of block[1].split('\n')) {
+					const modulePath = line.replace(/\/\/.*$/, '').trim();
+					if (modulePath) {
+						modules.push(modulePath);
+					}
+				}
+			}
+
+			for (const line of content.matchAll(/^use\s+([^(\s]\S*)/gm)) {
+				modules.push(line[1]);
+			}
+
+			return modules;
+		} catch (error) {
+			this.logger.error('Error reading go.work file', error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Get the rules with the go commands to build, test, vet and format the
+	 * module, which are collected into the Commands rules
+	 */
+	private getCommandRules(): AiRule[] {
+		return Object.entries(this.goCommands).map(([command, purpose]) => ({
+			category: Category.Commands,
+			rule: `Run \`${command}\`.`,
+			files: ['go.mod'],
+			commands: [{command, purpose}],
+		}));
+	}
+
+	/**
+	 * Get rules derived from the go.mod file
+	 */
+	private getModuleRules(goModule: GoModule): AiRule[] {
+		const rules: AiRule[] = [];
+
+		if (goModule.modulePath) {
+			rules.push({
+				category: Category.Go,
+				files: ['go.mod'],
+				rule: `The Go module path is ${goModule.modulePath}. Use it as the import prefix for packages inside this module.`,
+				severity: Severity.High,
+			});
+		}
+
+		if (this.hasStandardLayout()) {
+			rules.push({
+				category: Category.Go,
+				files: ['go.mod'],
+				rule: 'Follow the standard Go project layout: entry points in cmd/, private packages in internal/, and no top-level src/ directory.',
+			});
+		}
+
+		// Add rules for well-known libraries found in direct dependencies
+		for (const dependency of goModule.directDependencies) {
+			const libraryRule = this.getLibraryRule(dependency);
+			if (libraryRule) {
+				rules.push({
+					category: Category.Go,
+					files: ['go.mod'],
+					rule: libraryRule,
+				});
+			}
+		}
+
+		return rules;
+	}
+
+	/**
+	 * Check if the module follows the standard Go project layout, with cmd/ and
+	 * internal/ directories and no top-level src/ directory
+	 */
+	private hasStandardLayout(): boolean {
+		const exists = (directory: string) =>
+			existsSync(path.join(this.rootPath, directory));
+		return exists('cmd') && exists('internal') && !exists('src');
+	}
+
+	/**
+	 * Get the rule for a well-known library, ignoring major version suffixes
+	 */
+	private getLibraryRule(dependency: string): string | undefined {
+		const dependencyWithoutVersion = dependency.replace(/\/v\d+$/, '');
+		return this.knownLibraries[dependencyWithoutVersion];
+	}
+
+	/**
+	 * Get the rule describing a Go workspace
+	 */
+	private getWorkspaceRule(modules: string[]): AiRule {
+		const moduleList =
+			modules.length > 0 ? ` with modules: ${modules.join(', ')}` : '';
+
+		return {
+			category: Category.Go,
+			files: ['go.work'],
+			rule: `This is a Go workspace (go.work)${moduleList}. Keep dependency changes in the go.mod of the module being edited and run \`go work sync\` after updating them.`,
+		};
+	}
+}
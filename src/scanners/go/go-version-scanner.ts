@@ -1,7 +1,7 @@
 import {existsSync} from 'node:fs';
 import fs from 'node:fs/promises';
 import path from 'node:path';
-import {Category, type AiRule} from '../../types.js';
+import {Category, Severity, type AiRule} from '../../types.js';
 import {BaseScanner} from '../base/base-scanner.js';
 
 /**
@@ -9,6 +9,9 @@ import {BaseScanner} from '../base/base-scanner.js';
  * This scanner checks go.mod files and build tags for Go version information
  */
 export class GoVersionScanner extends BaseScanner {
+	public readonly name = 'go-version';
+	public readonly watchedFiles = ['go.mod', '*.go'];
+
 	/**
 	 * Scan the project to determine Go version requirements and constraints
 	 */
@@ -17,7 +20,10 @@ export class GoVersionScanner extends BaseScanner {
 
 		try {
 			// Check if this is a Go project by looking for go.mod
-			if (!(await this.isGoProject())) {
+			if (
+				!(await this.isGoProject()) ||
+				!this.hasEnoughEvidence([{file: 'go.mod'}])
+			) {
 				return [];
 			}
 
@@ -29,6 +35,8 @@ export class GoVersionScanner extends BaseScanner {
 				recommendations.push({
 					category: Category.Go,
 					rule: `Use Go version ${goModuleVersion} as specified in go.mod.`,
+					severity: Severity.High,
+					values: {version: goModuleVersion},
 				});
 
 				// Add version-specific recommendations
@@ -52,6 +60,7 @@ export class GoVersionScanner extends BaseScanner {
 				recommendations.push({
 					category: Category.Go,
 					rule: 'Keep Go version up to date with the latest stable release for security and performance improvements.',
+					severity: Severity.Info,
 				});
 			}
 
@@ -133,7 +142,7 @@ export class GoVersionScanner extends BaseScanner {
 		const goFiles: string[] = [];
 
 		try {
-			const files = await this.getFilesRecursively(this.rootPath);
+			const files = await this.fileIndex.getFiles();
 			return files.filter((file) => file.endsWith('.go'));
 		} catch (error) {
 			this.logger.error('Error finding Go files', error);
@@ -141,44 +150,6 @@ export class GoVersionScanner extends BaseScanner {
 		}
 	}
 
-	/**
-	 * Get all files recursively from a directory
-	 */
-	private async getFilesRecursively(directoryPath: string): Promise<string[]> {
-		const files: string[] = [];
-
-		try {
-			const entries = await fs.readdir(directoryPath, {withFileTypes: true});
-
-			const entryPromises = entries.map(async (entry) => {
-				const fullPath = path.join(directoryPath, entry.name);
-
-				// Skip vendor, node_modules, and .git directories
-				if (
-					entry.isDirectory() &&
-					['vendor', 'node_modules', '.git'].includes(entry.name)
-				) {
-					return [];
-				}
-
-				if (entry.isDirectory()) {
-					return this.getFilesRecursively(fullPath);
-				}
-
-				return [fullPath];
-			});
-
-			const entryResults = await Promise.all(entryPromises);
-			for (const entryFiles of entryResults) {
-				files.push(...entryFiles);
-			}
-		} catch (error) {
-			this.logger.error(`Error reading directory ${directoryPath}`, error);
-		}
-
-		return files;
-	}
-
 	/**
 	 * Extract build constraints from a Go file
 	 */
@@ -241,6 +212,7 @@ export class GoVersionScanner extends BaseScanner {
 				recommendations.push({
 					category: Category.Go,
 					rule: 'Go 1.21+ includes enhanced slices package and other performance improvements.',
+					severity: Severity.Info,
 				});
 			}
 
@@ -248,6 +220,7 @@ export class GoVersionScanner extends BaseScanner {
 				recommendations.push({
 					category: Category.Go,
 					rule: 'Go 1.20+ supports workspace mode and improved fuzzing capabilities.',
+					severity: Severity.Info,
 				});
 			}
 
@@ -268,7 +241,9 @@ export class GoVersionScanner extends BaseScanner {
 			if (minorVersion < 18) {
 				recommendations.push({
 					category: Category.Go,
-					rule: 'Consider upgrading to Go 1.18+ to use generics and other modern features.',
+					rule: `Do not use generics, this module targets Go ${majorMinor} and they require Go 1.18 or newer.`,
+					severity: Severity.High,
+					files: ['go.mod'],
 				});
 			}
 		}
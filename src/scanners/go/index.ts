@@ -1 +1,2 @@
+export {GoModuleScanner} from './go-module-scanner.js';
 export {GoVersionScanner} from './go-version-scanner.js';
@@ -0,0 +1,103 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {GoModuleScanner} from '../go-module-scanner.js';
+import {GoVersionScanner} from '../go-version-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+import {Severity} from '../../../types.js';
+
+const goModule = `module example.com/shop
+
+go 1.17
+
+require (
+	github.com/gin-gonic/gin v1.9.1
+	golang.org/x/text v0.14.0 // indirect
+)
+`;
+
+describe('GoModuleScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	it('should describe the module path and known libraries', async () => {
+		rootPath = await createFixture({'go.mod': goModule});
+
+		const rules = (await new GoModuleScanner(rootPath).scan()).map(
+			(rule) => rule.rule,
+		);
+
+		expect(rules).toContain(
+			'The Go module path is example.com/shop. Use it as the import prefix for packages inside this module.',
+		);
+		expect(rules).toContain(
+			'Use Gin (github.com/gin-gonic/gin) for HTTP routing and middleware.',
+		);
+		expect(rules).toContain('Run `go test ./...`.');
+	});
+
+	it('should leave the Go version to GoVersionScanner', async () => {
+		rootPath = await createFixture({'go.mod': goModule});
+
+		const moduleRules = await new GoModuleScanner(rootPath).scan();
+		const versionRules = await new GoVersionScanner(rootPath).scan();
+
+		expect(moduleRules.some((rule) => rule.rule.includes('1.17'))).toBe(
+			false,
+		);
+		expect(moduleRules.some((rule) => /generics/i.test(rule.rule))).toBe(
+			false,
+		);
+		expect(versionRules.map((rule) => rule.rule)).toContain(
+			'Use Go version 1.17 as specified in go.mod.',
+		);
+	});
+
+	it('should only describe the standard layout when the module has it', async () => {
+		const layoutRule =
+			'Follow the standard Go project layout: entry points in cmd/, private packages in internal/, and no top-level src/ directory.';
+		rootPath = await createFixture({
+			'go.mod': goModule,
+			'cmd/shop/main.go': 'package main\n',
+			'internal/cart/cart.go': 'package cart\n',
+		});
+		const standardRules = await new GoModuleScanner(rootPath).scan();
+		await removeFixture(rootPath);
+		rootPath = await createFixture({
+			'go.mod': goModule,
+			'main.go': 'package main\n',
+		});
+		const flatRules = await new GoModuleScanner(rootPath).scan();
+
+		expect(standardRules.map((rule) => rule.rule)).toContain(layoutRule);
+		expect(flatRules.map((rule) => rule.rule)).not.toContain(layoutRule);
+	});
+
+	it('should not suggest generics before Go 1.18', async () => {
+		rootPath = await createFixture({'go.mod': goModule});
+
+		const rules = await new GoVersionScanner(rootPath).scan();
+		const genericsRule = rules.find((rule) =>
+			rule.rule.startsWith('Do not use generics'),
+		);
+
+		expect(genericsRule?.rule).toBe(
+			'Do not use generics, this module targets Go 1.17 and they require Go 1.18 or newer.',
+		);
+		expect(genericsRule?.severity).toBe(Severity.High);
+		expect(rules.some((rule) => rule.rule.includes('Consider'))).toBe(false);
+	});
+
+	it('should list the modules of a go.work workspace', async () => {
+		rootPath = await createFixture({
+			'go.work': 'go 1.21\n\nuse (\n\t./api\n\t./worker\n)\n',
+		});
+
+		const rules = await new GoModuleScanner(rootPath).scan();
+
+		expect(rules.map((rule) => rule.rule)).toEqual([
+			'This is a Go workspace (go.work) with modules: ./api, ./worker. Keep dependency changes in the go.mod of the module being edited and run `go work sync` after updating them.',
+		]);
+	});
+});
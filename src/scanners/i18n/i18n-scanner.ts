@@ -0,0 +1,277 @@
+import path from 'node:path';
+import {
+	Category,
+	Confidence,
+	Severity,
+	type AiRule,
+	type Evidence,
+} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+import {readDependencies} from '../base/scanner-helpers.js';
+
+/**
+ * An internationalization library and the packages of its integrations
+ */
+type I18nLibrary = {
+	name: string;
+	// Packages of the library, by precedence, and how to render a translated
+	// string with each of them
+	packages: Record<string, string>;
+};
+
+/**
+ * Translation files of the project, grouped by their directory
+ */
+type TranslationFiles = {
+	directory: string;
+	files: string[];
+	languages: string[];
+};
+
+/**
+ * Internationalization libraries detected from their packages
+ */
+const i18nLibraries: I18nLibrary[] = [
+	{
+		name: 'next-intl',
+		packages: {
+			'next-intl':
+				'Render it with `t` from `useTranslations()` in client components and from `getTranslations()` in server components.',
+		},
+	},
+	{
+		name: 'react-intl',
+		packages: {
+			'react-intl':
+				'Render it with `<FormattedMessage id="..." />`, or with `intl.formatMessage()` from `useIntl()` outside of JSX.',
+		},
+	},
+	{
+		name: 'i18next',
+		packages: {
+			'next-i18next':
+				'Render it with `t` from `useTranslation()`, and load the namespaces of a page with `serverSideTranslations()`.',
+			'react-i18next':
+				'Render it with `t` from `useTranslation()`, or with the `<Trans>` component for text with markup.',
+			i18next: 'Render it with `i18next.t()`.',
+		},
+	},
+	{
+		name: 'Vue I18n',
+		packages: {
+			'vue-i18n':
+				'Render it with `$t()` in templates, or with `t` from `useI18n()` in `<script setup>`.',
+		},
+	},
+];
+
+/**
+ * Names of the directories holding translation files, e.g. public/locales
+ */
+const translationDirectoryNames = new Set([
+	'locales',
+	'locale',
+	'lang',
+	'langs',
+	'i18n',
+	'messages',
+	'translations',
+]);
+
+/**
+ * Matches language codes, e.g. "en", "pt-BR", "zh_Hant"
+ */
+const languagePattern = /^[a-z]{2}(?:[-_](?:[A-Z]{2}|[A-Z][a-z]{3}))?$/;
+
+/**
+ * Maximum number of languages named in the rule, enough for most projects
+ * to get the full list
+ */
+const maxListedLanguages = 30;
+
+/**
+ * Scanner to detect internationalization (i18next, react-intl, next-intl,
+ * Vue I18n, locale directories), so user-facing text is not hardcoded
+ */
+export class I18nScanner extends BaseScanner {
+	public readonly name = 'i18n';
+	// Locale files only matter by name, which the cache fingerprint already
+	// includes for every file
+	public readonly watchedFiles = ['package.json'];
+
+	/**
+	 * Scan the project to determine how user-facing text is translated
+	 * Several libraries get one rule each, as the primary one can't be told
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for internationalization');
+
+		try {
+			const dependencies = await readDependencies(this.rootPath);
+			const translationFiles = await this.findTranslationFiles();
+			const rules: AiRule[] = [];
+
+			for (const library of i18nLibraries) {
+				const packageName = Object.keys(library.packages).find((name) =>
+					dependencies.includes(name),
+				);
+				if (packageName) {
+					rules.push(
+						this.getLibraryRule(library, packageName, translationFiles),
+					);
+				}
+			}
+
+			const evidence: Evidence[] = [
+				...(rules.length > 0 ? [{file: 'package.json'}] : []),
+				...(translationFiles?.files ?? []).map((file) => ({file})),
+			];
+
+			// Translation files without a known library give a medium confidence
+			if (rules.length === 0 && translationFiles) {
+				rules.push({
+					category: Category.Internationalization,
+					rule: `User-facing text is translated. ${this.describeTranslationFiles(translationFiles)} Do not hardcode user-facing text: add a key to the translation files${this.getEveryLanguageLabel(translationFiles)} and read it through the translation helper of the project.`,
+					severity: Severity.High,
+					confidence: Confidence.Medium,
+					files: translationFiles.files.slice(0, 5),
+				});
+			}
+
+			return this.hasEnoughEvidence(evidence) ? rules : [];
+		} catch (error) {
+			this.logger.error('Error scanning for internationalization', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the rule for a library detected from its packages
+	 * @param library Detected library
+	 * @param packageName Package of the library in package.json
+	 * @param translationFiles Translation files of the project, if found
+	 */
+	private getLibraryRule(
+		library: I18nLibrary,
+		packageName: string,
+		translationFiles: TranslationFiles | undefined,
+	): AiRule {
+		const packageLabel =
+			packageName === library.name ? '' : ` (\`${packageName}\`)`;
+		const sentences = [
+			`User-facing text is translated with ${library.name}${packageLabel}.`,
+		];
+
+		if (translationFiles) {
+			sentences.push(this.describeTranslationFiles(translationFiles));
+		}
+
+		sentences.push(
+			`Do not hardcode user-facing text in components: add a key to the translation files${this.getEveryLanguageLabel(translationFiles)}.`,
+			library.packages[packageName],
+		);
+
+		return {
+			category: Category.Internationalization,
+			rule: sentences.join(' '),
+			severity: Severity.High,
+			files: [
+				'package.json',
+				...(translationFiles?.files.slice(0, 5) ?? []),
+			],
+		};
+	}
+
+	/**
+	 * Get " of every language" when there are several languages to translate
+	 */
+	private getEveryLanguageLabel(
+		translationFiles: TranslationFiles | undefined,
+	): string {
+		return translationFiles && translationFiles.languages.length > 1
+			? ' of every language'
+			: '';
+	}
+
+	/**
+	 * Describe where the translation files live and which languages they have
+	 * e.g. "Translation files live in `locales`, for the languages de and en."
+	 */
+	private describeTranslationFiles(translationFiles: TranslationFiles): string {
+		const {directory, languages} = translationFiles;
+		if (languages.length < 2) {
+			return `Translation files live in \`${directory}\`.`;
+		}
+
+		const names =
+			languages.length > maxListedLanguages
+				? `${languages.slice(0, maxListedLanguages).join(', ')} and ${languages.length - maxListedLanguages} more`
+				: `${languages.slice(0, -1).join(', ')} and ${languages.at(-1)}`;
+
+		return `Translation files live in \`${directory}\`, for the languages ${names}.`;
+	}
+
+	/**
+	 * Find the JSON translation files, named by language, e.g. locales/en.json,
+	 * or in a directory per language, e.g. locales/en/common.json
+	 * @returns The translation directory with the most files, undefined if none
+	 */
+	private async findTranslationFiles(): Promise<TranslationFiles | undefined> {
+		const directories = new Map<string, TranslationFiles>();
+
+		for (const file of await this.fileIndex.getFiles()) {
+			const relativePath = this.toRelative(file);
+			if (!relativePath.endsWith('.json')) {
+				continue;
+			}
+
+			const segments = relativePath.split('/');
+			const directoryIndex = segments.findIndex(
+				(segment, index) =>
+					index < segments.length - 1 &&
+					translationDirectoryNames.has(segment),
+			);
+			if (directoryIndex === -1) {
+				continue;
+			}
+
+			const language = segments[directoryIndex + 1].replace(/\.json$/, '');
+			if (!languagePattern.test(language)) {
+				continue;
+			}
+
+			const directory = segments.slice(0, directoryIndex + 1).join('/');
+			const entry = directories.get(directory) ?? {
+				directory,
+				files: [],
+				languages: [],
+			};
+			entry.files.push(relativePath);
+			if (!entry.languages.includes(language)) {
+				entry.languages.push(language);
+			}
+
+			directories.set(directory, entry);
+		}
+
+		const [translationFiles] = [...directories.values()].sort(
+			(first, second) => second.files.length - first.files.length,
+		);
+		if (!translationFiles) {
+			return undefined;
+		}
+
+		return {
+			...translationFiles,
+			files: translationFiles.files.sort(),
+			languages: translationFiles.languages.sort(),
+		};
+	}
+
+	/**
+	 * Get a path relative to the root with forward slashes
+	 */
+	private toRelative(file: string): string {
+		return path.relative(this.rootPath, file).split(path.sep).join('/');
+	}
+}
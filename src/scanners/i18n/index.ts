@@ -0,0 +1 @@
+export {I18nScanner} from './i18n-scanner.js';
@@ -0,0 +1,54 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {I18nScanner} from '../i18n-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+import {Confidence} from '../../../types.js';
+
+describe('I18nScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Libraries', () => {
+		it('should name the integration and the translation files', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					dependencies: {i18next: '^23.0.0', 'react-i18next': '^14.0.0'},
+				}),
+				'public/locales/en/common.json': '{}',
+				'public/locales/de/common.json': '{}',
+			});
+
+			const rules = await new I18nScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'User-facing text is translated with i18next (`react-i18next`). Translation files live in `public/locales`, for the languages de and en. Do not hardcode user-facing text in components: add a key to the translation files of every language. Render it with `t` from `useTranslation()`, or with the `<Trans>` component for text with markup.',
+			]);
+		});
+	});
+
+	describe('Translation files', () => {
+		it('should be less certain without a library', async () => {
+			rootPath = await createFixture({
+				'messages/en.json': '{}',
+				'messages/fr.json': '{}',
+			});
+
+			const rules = await new I18nScanner(rootPath).scan();
+
+			expect(rules).toHaveLength(1);
+			expect(rules[0].confidence).toBe(Confidence.Medium);
+			expect(rules[0].files).toEqual(['messages/en.json', 'messages/fr.json']);
+		});
+
+		it('should not emit rules for directories without languages', async () => {
+			rootPath = await createFixture({
+				'package.json': '{}',
+				'src/i18n/config.ts': 'export {};\n',
+			});
+
+			expect(await new I18nScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
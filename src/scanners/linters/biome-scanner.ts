@@ -0,0 +1,216 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, Severity, type AiRule} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+import {prettierConfigFileNames} from './prettier-scanner.js';
+
+/**
+ * Parts of biome.json that describe linting and formatting
+ */
+type BiomeConfig = {
+	formatter?: {
+		enabled?: boolean;
+		indentStyle?: 'tab' | 'space';
+		indentWidth?: number;
+		lineWidth?: number;
+	};
+	linter?: {
+		enabled?: boolean;
+	};
+	javascript?: {
+		formatter?: {
+			quoteStyle?: 'double' | 'single';
+			semicolons?: 'always' | 'asNeeded';
+		};
+	};
+};
+
+/**
+ * Scanner to detect Biome linting and formatting configuration in a project
+ */
+export class BiomeScanner extends BaseScanner {
+	public readonly name = 'biome';
+	public readonly watchedFiles = [
+		'package.json',
+		'biome.json',
+		'biome.jsonc',
+		'.prettierrc*',
+		'prettier.config.*',
+	];
+
+	/**
+	 * Biome configuration file names
+	 */
+	private readonly configFileNames = ['biome.json', 'biome.jsonc'];
+
+	/**
+	 * Scan the project to determine if and how Biome is configured
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for Biome configuration');
+
+		try {
+			const configFile = this.configFileNames.find((fileName) =>
+				existsSync(path.join(this.rootPath, fileName)),
+			);
+			const packageJson = await this.readPackageJson();
+			const hasDependency = this.hasDependency(packageJson, '@biomejs/biome');
+
+			// If Biome is not used, don't return any recommendations
+			if (!configFile && !hasDependency) {
+				return [];
+			}
+
+			const config = configFile
+				? await this.readBiomeConfig(configFile)
+				: undefined;
+			const files = configFile ? [configFile] : ['package.json'];
+			if (!this.hasEnoughEvidence(files.map((file) => ({file})))) {
+				return [];
+			}
+
+			const recommendations: AiRule[] = [];
+
+			const linterEnabled = config?.linter?.enabled !== false;
+			const formatterEnabled = config?.formatter?.enabled !== false;
+			const tasks = [
+				...(linterEnabled ? ['linting'] : []),
+				...(formatterEnabled ? ['formatting'] : []),
+			];
+
+			if (tasks.length > 0) {
+				recommendations.push({
+					category: Category.Biome,
+					rule: `Use Biome for ${tasks.join(' and ')}. Run \`biome check --write\` to fix issues instead of fixing them by hand.`,
+					files,
+				});
+			}
+
+			if (formatterEnabled) {
+				recommendations.push({
+					category: Category.Biome,
+					rule: `Formatting is enforced by Biome. Write code in its style: ${this.describeStyle(config)}.`,
+					files,
+				});
+
+				// Two formatters fight over the same files, the rule describes the
+				// conflict so the aggregator does not add another one
+				if (this.hasPrettier(packageJson)) {
+					recommendations.push({
+						category: Category.Biome,
+						rule: 'Both Biome and Prettier are configured and will likely format files differently. Check which formatter owns a file before formatting it, and do not add Prettier to files formatted by Biome.',
+						severity: Severity.High,
+						files,
+						conflictGroup: 'formatter',
+					});
+				}
+			}
+
+			return recommendations;
+		} catch (error) {
+			this.logger.error('Error scanning for Biome configuration', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Read and parse the Biome configuration file, which may contain comments
+	 */
+	private async readBiomeConfig(
+		configFile: string,
+	): Promise<BiomeConfig | undefined> {
+		try {
+			const content = await fs.readFile(
+				path.join(this.rootPath, configFile),
+				'utf8',
+			);
+			const json = content
+				.replaceAll(/\/\*[\s\S]*?\*\//g, '')
+				.replaceAll(/^\s*\/\/.*$/gm, '')
+				.replaceAll(/,(\s*[}\]])/g, '$1');
+
+			return JSON.parse(json) as BiomeConfig;
+		} catch (error) {
+			this.logger.error(`Error reading ${configFile}`, error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Describe the code style enforced by Biome, using its defaults for
+	 * options that are not set
+	 */
+	private describeStyle(config: BiomeConfig | undefined): string {
+		const javascriptFormatter = config?.javascript?.formatter;
+		const semicolons =
+			javascriptFormatter?.semicolons === 'asNeeded'
+				? 'semicolons only where required'
+				: 'semicolons';
+		const quotes =
+			javascriptFormatter?.quoteStyle === 'single'
+				? 'single quotes'
+				: 'double quotes';
+		const indentation =
+			config?.formatter?.indentStyle === 'space'
+				? `${config.formatter.indentWidth ?? 2} spaces for indentation`
+				: 'tabs for indentation';
+		const lineWidth = config?.formatter?.lineWidth ?? 80;
+
+		return `${semicolons}, ${quotes}, ${indentation}, lines up to ${lineWidth} characters`;
+	}
+
+	/**
+	 * Check if Prettier is configured or installed
+	 */
+	private hasPrettier(
+		packageJson: Record<string, unknown> | undefined,
+	): boolean {
+		return (
+			prettierConfigFileNames.some((fileName) =>
+				existsSync(path.join(this.rootPath, fileName)),
+			) ||
+			packageJson?.prettier !== undefined ||
+			this.hasDependency(packageJson, 'prettier')
+		);
+	}
+
+	/**
+	 * Check if package.json declares a dependency
+	 */
+	private hasDependency(
+		packageJson: Record<string, unknown> | undefined,
+		dependency: string,
+	): boolean {
+		const dependencyFields = ['dependencies', 'devDependencies'] as const;
+
+		return dependencyFields.some((field) => {
+			const dependencies = packageJson?.[field];
+			return (
+				typeof dependencies === 'object' &&
+				dependencies !== null &&
+				dependency in dependencies
+			);
+		});
+	}
+
+	/**
+	 * Read and parse package.json
+	 */
+	private async readPackageJson(): Promise<
+		Record<string, unknown> | undefined
+	> {
+		const packageJsonPath = path.join(this.rootPath, 'package.json');
+		if (!existsSync(packageJsonPath)) {
+			return undefined;
+		}
+
+		try {
+			const content = await fs.readFile(packageJsonPath, 'utf8');
+			return JSON.parse(content) as Record<string, unknown>;
+		} catch (error) {
+			this.logger.error('Error reading package.json', error);
+			return undefined;
+		}
+	}
+}
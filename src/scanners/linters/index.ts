@@ -1,2 +1,3 @@
+export {BiomeScanner} from './biome-scanner.js';
 export {LintingScanner} from './linting-scanner.js';
 export {XoScanner} from './xo-scanner.js';
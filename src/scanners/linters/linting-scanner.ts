@@ -8,6 +8,15 @@ import {BaseScanner} from '../base/base-scanner.js';
  * Scanner to detect which linting tool is used in the project
  */
 export class LintingScanner extends BaseScanner {
+	public readonly name = 'linter';
+	public readonly watchedFiles = [
+		'package.json',
+		'xo.config.js',
+		'.eslintrc*',
+		'eslint.config.*',
+		'tslint.json',
+	];
+
 	/**
 	 * Scan the project to determine which linting tool is used
 	 */
@@ -16,33 +25,21 @@ export class LintingScanner extends BaseScanner {
 
 		try {
 			// Check if xo is used
-			if (await this.hasXo()) {
-				return [
-					{
-						category: Category.Linting,
-						rule: 'Use xo for linting.',
-					},
-				];
+			const xoFile = await this.findXo();
+			if (xoFile) {
+				return this.getLinterRules('xo', xoFile);
 			}
 
 			// Check if eslint is used
-			if (await this.hasEslint()) {
-				return [
-					{
-						category: Category.Linting,
-						rule: 'Use eslint for linting.',
-					},
-				];
+			const eslintFile = await this.findEslint();
+			if (eslintFile) {
+				return this.getLinterRules('eslint', eslintFile);
 			}
 
 			// Check if tslint is used
-			if (await this.hasTslint()) {
-				return [
-					{
-						category: Category.Linting,
-						rule: 'Use tslint for linting.',
-					},
-				];
+			const tslintFile = await this.findTslint();
+			if (tslintFile) {
+				return this.getLinterRules('tslint', tslintFile);
 			}
 
 			// If no linting tool detected, don't return any recommendation
@@ -54,23 +51,40 @@ export class LintingScanner extends BaseScanner {
 	}
 
 	/**
-	 * Check if xo is used
+	 * Get the rules for the linter, if the file it was found in is enough
+	 * evidence
+	 */
+	private getLinterRules(linter: string, file: string): AiRule[] {
+		if (!this.hasEnoughEvidence([{file}])) {
+			return [];
+		}
+
+		return [
+			{
+				category: Category.Linting,
+				rule: `Use ${linter} for linting.`,
+			},
+		];
+	}
+
+	/**
+	 * Find the file showing that xo is used
 	 */
-	private async hasXo(): Promise<boolean> {
+	private async findXo(): Promise<string | undefined> {
 		// Check for xo config file
 		const xoConfigPath = path.join(this.rootPath, 'xo.config.js');
 		if (existsSync(xoConfigPath)) {
-			return true;
+			return 'xo.config.js';
 		}
 
 		// Check package.json for xo dependency
-		return this.hasDependency('xo');
+		return this.findDependency('xo');
 	}
 
 	/**
-	 * Check if eslint is used
+	 * Find the file showing that eslint is used
 	 */
-	private async hasEslint(): Promise<boolean> {
+	private async findEslint(): Promise<string | undefined> {
 		// Check for eslint config files
 		const eslintConfigFiles = [
 			'.eslintrc',
@@ -78,40 +92,48 @@ export class LintingScanner extends BaseScanner {
 			'.eslintrc.json',
 			'.eslintrc.yml',
 			'.eslintrc.yaml',
+			'.eslintrc.cjs',
+			'eslint.config.js',
+			'eslint.config.mjs',
+			'eslint.config.cjs',
+			'eslint.config.ts',
 		];
 
 		for (const configFile of eslintConfigFiles) {
 			const configPath = path.join(this.rootPath, configFile);
 			if (existsSync(configPath)) {
-				return true;
+				return configFile;
 			}
 		}
 
 		// Check package.json for eslint dependency
-		return this.hasDependency('eslint');
+		return this.findDependency('eslint');
 	}
 
 	/**
-	 * Check if tslint is used
+	 * Find the file showing that tslint is used
 	 */
-	private async hasTslint(): Promise<boolean> {
+	private async findTslint(): Promise<string | undefined> {
 		// Check for tslint config file
 		const tslintConfigPath = path.join(this.rootPath, 'tslint.json');
 		if (existsSync(tslintConfigPath)) {
-			return true;
+			return 'tslint.json';
 		}
 
 		// Check package.json for tslint dependency
-		return this.hasDependency('tslint');
+		return this.findDependency('tslint');
 	}
 
 	/**
 	 * Check if package.json has a specific dependency
+	 * @returns package.json if it declares the dependency
 	 */
-	private async hasDependency(dependency: string): Promise<boolean> {
+	private async findDependency(
+		dependency: string,
+	): Promise<string | undefined> {
 		const packageJsonPath = path.join(this.rootPath, 'package.json');
 		if (!existsSync(packageJsonPath)) {
-			return false;
+			return undefined;
 		}
 
 		try {
@@ -135,13 +157,13 @@ export class LintingScanner extends BaseScanner {
 					packageJson[field] !== null &&
 					dependency in (packageJson[field] as Record<string, unknown>)
 				) {
-					return true;
+					return 'package.json';
 				}
 			}
 		} catch (error) {
 			this.logger.error('Error reading package.json', error);
 		}
 
-		return false;
+		return undefined;
 	}
 }
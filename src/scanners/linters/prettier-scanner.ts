@@ -1,25 +1,51 @@
 import fs from 'node:fs/promises';
 import path from 'node:path';
-import {Category, type AiRule} from '../../types.js';
+import {Category, type AiRule, type Evidence} from '../../types.js';
 import {BaseScanner} from '../base/base-scanner.js';
 
+/**
+ * Prettier options that shape how code looks, undefined when not set
+ */
+type PrettierOptions = {
+	semi?: boolean;
+	singleQuote?: boolean;
+	useTabs?: boolean;
+	tabWidth?: number;
+};
+
+/**
+ * Prettier configuration file names, in the order Prettier looks for them
+ */
+export const prettierConfigFileNames = [
+	'.prettierrc',
+	'.prettierrc.json',
+	'.prettierrc.yaml',
+	'.prettierrc.yml',
+	'.prettierrc.json5',
+	'.prettierrc.js',
+	'.prettierrc.ts',
+	'.prettierrc.mjs',
+	'.prettierrc.mts',
+	'.prettierrc.cjs',
+	'.prettierrc.cts',
+	'prettier.config.js',
+	'prettier.config.ts',
+	'prettier.config.mjs',
+	'prettier.config.mts',
+	'prettier.config.cjs',
+	'prettier.config.cts',
+	'.prettierrc.toml',
+];
+
 /**
  * Scanner to detect Prettier configuration in a project
  */
 export class PrettierScanner extends BaseScanner {
-	/**
-	 * Common Prettier configuration file names
-	 */
-	private readonly configFileNames = [
-		'.prettierrc',
-		'.prettierrc.json',
-		'.prettierrc.yml',
-		'.prettierrc.yaml',
-		'.prettierrc.json5',
-		'.prettierrc.js',
-		'.prettierrc.cjs',
-		'prettier.config.js',
-		'prettier.config.cjs',
+	public readonly name = 'prettier';
+	public readonly watchedFiles = [
+		'package.json',
+		'.prettierrc*',
+		'prettier.config.*',
 	];
 
 	/**
@@ -40,8 +66,28 @@ export class PrettierScanner extends BaseScanner {
 				return [];
 			}
 
+			// The prettier key and the dependency are both found in package.json
+			const configEvidence = configFile?.startsWith('package.json')
+				? 'package.json'
+				: configFile;
+			const files = new Set([
+				configEvidence,
+				hasDependency ? 'package.json' : undefined,
+			]);
+			const evidence: Evidence[] = [...files].flatMap((file) =>
+				file ? [{file}] : [],
+			);
+			if (!this.hasEnoughEvidence(evidence)) {
+				return [];
+			}
+
+			// Read the formatting options to describe the enforced style
+			const options = configFile
+				? await this.readPrettierOptions(configFile)
+				: undefined;
+
 			// Generate recommendations based on the findings
-			return this.generateRecommendations(configFile, hasDependency);
+			return this.generateRecommendations(configFile, hasDependency, options);
 		} catch (error) {
 			this.logger.error('Error scanning for Prettier configuration', error);
 			return [];
@@ -65,7 +111,7 @@ export class PrettierScanner extends BaseScanner {
 	 */
 	private async findPrettierConfigFile(): Promise<string | undefined> {
 		// Check for all config files one by one
-		for (const fileName of this.configFileNames) {
+		for (const fileName of prettierConfigFileNames) {
 			const filePath = path.join(this.rootPath, fileName);
 			// eslint-disable-next-line no-await-in-loop
 			const exists = await this.fileExists(filePath);
@@ -94,6 +140,131 @@ export class PrettierScanner extends BaseScanner {
 		return undefined;
 	}
 
+	/**
+	 * Read the style options of a Prettier configuration file
+	 * JavaScript configs are read with simple patterns, shared configs referenced
+	 * by name are not resolved
+	 */
+	private async readPrettierOptions(
+		configFile: string,
+	): Promise<PrettierOptions | undefined> {
+		const fileName = configFile.startsWith('package.json')
+			? 'package.json'
+			: configFile;
+
+		try {
+			const content = await fs.readFile(
+				path.join(this.rootPath, fileName),
+				'utf8',
+			);
+
+			if (fileName === 'package.json') {
+				const {prettier} = JSON.parse(content) as {prettier?: unknown};
+				return typeof prettier === 'object'
+					? this.toPrettierOptions(prettier as Record<string, unknown>)
+					: undefined;
+			}
+
+			if (/\.[cm]?[jt]s$/.test(fileName)) {
+				const config: Record<string, unknown> = {};
+				for (const [, key, value] of content.matchAll(
+					/\b(semi|singleQuote|useTabs|tabWidth):\s*(true|false|\d+)/g,
+				)) {
+					config[key] = this.parseOptionValue(value);
+				}
+
+				return this.toPrettierOptions(config);
+			}
+
+			if (fileName.endsWith('.toml')) {
+				return this.toPrettierOptions(this.parseTomlOptions(content));
+			}
+
+			// .prettierrc can hold JSON or YAML
+			try {
+				return this.toPrettierOptions(
+					JSON.parse(content) as Record<string, unknown>,
+				);
+			} catch {
+				return this.toPrettierOptions(this.parseYamlOptions(content));
+			}
+		} catch (error) {
+			this.logger.error(`Error reading ${fileName}`, error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Parse the top-level "key: value" pairs of a YAML configuration
+	 */
+	private parseYamlOptions(content: string): Record<string, unknown> {
+		const options: Record<string, unknown> = {};
+
+		for (const line of content.split('\n')) {
+			const match = /^(\w+):\s*["']?([^"'#\s]+)["']?/.exec(line);
+			if (match) {
+				options[match[1]] = this.parseOptionValue(match[2]);
+			}
+		}
+
+		return options;
+	}
+
+	/**
+	 * Parse the top-level "key = value" pairs of a TOML configuration
+	 */
+	private parseTomlOptions(content: string): Record<string, unknown> {
+		const options: Record<string, unknown> = {};
+
+		for (const line of content.split('\n')) {
+			const match = /^(\w+)\s*=\s*["']?([^"'#\s]+)["']?/.exec(line);
+			if (match) {
+				options[match[1]] = this.parseOptionValue(match[2]);
+			}
+		}
+
+		return options;
+	}
+
+	/**
+	 * Convert a configuration value to a boolean, number or string
+	 */
+	private parseOptionValue(value: string): unknown {
+		if (value === 'true' || value === 'false') {
+			return value === 'true';
+		}
+
+		return Number.isNaN(Number(value)) ? value : Number(value);
+	}
+
+	/**
+	 * Pick the style options from parsed Prettier configuration
+	 */
+	private toPrettierOptions(config: Record<string, unknown>): PrettierOptions {
+		const {semi, singleQuote, useTabs, tabWidth} = config;
+
+		return {
+			semi: typeof semi === 'boolean' ? semi : undefined,
+			singleQuote: typeof singleQuote === 'boolean' ? singleQuote : undefined,
+			useTabs: typeof useTabs === 'boolean' ? useTabs : undefined,
+			tabWidth: typeof tabWidth === 'number' ? tabWidth : undefined,
+		};
+	}
+
+	/**
+	 * Describe the code style enforced by Prettier, using its defaults for
+	 * options that are not set
+	 */
+	private describeStyle(options: PrettierOptions): string {
+		const semicolons = options.semi === false ? 'no semicolons' : 'semicolons';
+		const quotes = options.singleQuote ? 'single quotes' : 'double quotes';
+		const indentation = options.useTabs
+			? 'tabs for indentation'
+			: `${options.tabWidth ?? 2} spaces for indentation`;
+
+		return `${semicolons}, ${quotes}, ${indentation}`;
+	}
+
 	/**
 	 * Check if Prettier is a dependency in package.json
 	 */
@@ -124,6 +295,7 @@ export class PrettierScanner extends BaseScanner {
 	private generateRecommendations(
 		configFile: string | undefined,
 		hasDependency: boolean,
+		options: PrettierOptions | undefined,
 	): AiRule[] {
 		const recommendations: AiRule[] = [];
 
@@ -139,6 +311,13 @@ export class PrettierScanner extends BaseScanner {
 					rule: `Prettier configuration found in: ${configFile}`,
 				},
 			);
+
+			if (options) {
+				recommendations.push({
+					category: Category.Prettier,
+					rule: `Formatting is enforced by Prettier. Write code in its style: ${this.describeStyle(options)}.`,
+				});
+			}
 		} else if (hasDependency) {
 			recommendations.push({
 				category: Category.Prettier,
@@ -0,0 +1,105 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {BiomeScanner} from '../biome-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+const biomeConfig = `{
+	// Formatter settings
+	"formatter": {
+		"indentStyle": "space",
+		"indentWidth": 4,
+		"lineWidth": 100,
+	},
+	"javascript": {
+		"formatter": {"quoteStyle": "single", "semicolons": "asNeeded"}
+	}
+}
+`;
+
+describe('BiomeScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Config', () => {
+		it('should describe the style of a commented config', async () => {
+			rootPath = await createFixture({'biome.jsonc': biomeConfig});
+
+			const rules = await new BiomeScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Use Biome for linting and formatting. Run `biome check --write` to fix issues instead of fixing them by hand.',
+				'Formatting is enforced by Biome. Write code in its style: semicolons only where required, single quotes, 4 spaces for indentation, lines up to 100 characters.',
+			]);
+			expect(rules[0].files).toEqual(['biome.jsonc']);
+		});
+
+		it('should leave out the formatter when it is disabled', async () => {
+			rootPath = await createFixture({
+				'biome.json': JSON.stringify({formatter: {enabled: false}}),
+			});
+
+			const rules = await new BiomeScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Use Biome for linting. Run `biome check --write` to fix issues instead of fixing them by hand.',
+			]);
+		});
+	});
+
+	describe('Dependency', () => {
+		it('should use the defaults of Biome without a config', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					devDependencies: {'@biomejs/biome': '^1.9.0'},
+				}),
+			});
+
+			const rules = await new BiomeScanner(rootPath).scan();
+
+			expect(rules[1].rule).toBe(
+				'Formatting is enforced by Biome. Write code in its style: semicolons, double quotes, tabs for indentation, lines up to 80 characters.',
+			);
+			expect(rules[1].files).toEqual(['package.json']);
+		});
+
+		it('should not emit rules without Biome', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({devDependencies: {eslint: '^9.0.0'}}),
+			});
+
+			expect(await new BiomeScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+
+	describe('Prettier', () => {
+		it('should warn when Prettier is installed too', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					devDependencies: {'@biomejs/biome': '^1.9.0', prettier: '^3.0.0'},
+				}),
+			});
+
+			const rules = await new BiomeScanner(rootPath).scan();
+
+			expect(rules[2].rule).toBe(
+				'Both Biome and Prettier are configured and will likely format files differently. Check which formatter owns a file before formatting it, and do not add Prettier to files formatted by Biome.',
+			);
+			expect(rules[2].conflictGroup).toBe('formatter');
+		});
+
+		it('should warn about every Prettier config file name', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					devDependencies: {'@biomejs/biome': '^1.9.0'},
+				}),
+				'.prettierrc.toml': 'semi = false\n',
+			});
+
+			const rules = await new BiomeScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.conflictGroup)).toContain('formatter');
+		});
+	});
+});
@@ -0,0 +1,44 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {PrettierScanner} from '../prettier-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+const packageJson = JSON.stringify({devDependencies: {prettier: '^3.0.0'}});
+
+describe('PrettierScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Config', () => {
+		it('should describe the style of a TOML config', async () => {
+			rootPath = await createFixture({
+				'package.json': packageJson,
+				'.prettierrc.toml': 'semi = false\nsingleQuote = true\ntabWidth = 4\n',
+			});
+
+			const rules = await new PrettierScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toContain(
+				'Formatting is enforced by Prettier. Write code in its style: no semicolons, single quotes, 4 spaces for indentation.',
+			);
+		});
+
+		it('should read a TypeScript config', async () => {
+			rootPath = await createFixture({
+				'package.json': packageJson,
+				'prettier.config.ts': 'export default {useTabs: true};\n',
+			});
+
+			const rules = await new PrettierScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toContain(
+				'Prettier configuration found in: prettier.config.ts',
+			);
+			expect(rules.map((rule) => rule.rule)).toContain(
+				'Formatting is enforced by Prettier. Write code in its style: semicolons, double quotes, tabs for indentation.',
+			);
+		});
+	});
+});
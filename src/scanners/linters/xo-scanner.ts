@@ -1,12 +1,19 @@
 import fs from 'node:fs/promises';
 import path from 'node:path';
-import {Category, type AiRule} from '../../types.js';
+import {Category, type AiRule, type Evidence} from '../../types.js';
 import {BaseScanner} from '../base/base-scanner.js';
 
 /**
  * Scanner to detect XO linting configuration in a project
  */
 export class XoScanner extends BaseScanner {
+	public readonly name = 'xo';
+	public readonly watchedFiles = [
+		'package.json',
+		'xo.config.*',
+		'.xo-config*',
+	];
+
 	/**
 	 * Common XO configuration file names
 	 */
@@ -37,6 +44,14 @@ export class XoScanner extends BaseScanner {
 				return [];
 			}
 
+			const evidence: Evidence[] = [
+				...(configFile ? [{file: configFile}] : []),
+				...(hasDependency ? [{file: 'package.json'}] : []),
+			];
+			if (!this.hasEnoughEvidence(evidence)) {
+				return [];
+			}
+
 			// Get config either from package.json or external config file
 			let config: Record<string, unknown> | undefined;
 
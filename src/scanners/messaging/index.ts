@@ -0,0 +1 @@
+export {MessageQueueScanner} from './message-queue-scanner.js';
@@ -0,0 +1,291 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, Confidence, Severity, type AiRule} from '../../types.js';
+import {
+	getDefaultConcurrency,
+	mapWithConcurrency,
+} from '../../utils/concurrency.js';
+import {BaseScanner} from '../base/base-scanner.js';
+import {
+	escapeRegex,
+	readDependencies,
+	sourceFileGlobs,
+	sourceFilePattern,
+} from '../base/scanner-helpers.js';
+
+/**
+ * A message broker and how to recognize it
+ */
+type MessageBroker = {
+	name: string;
+	// Client packages of the broker, by precedence
+	packages: string[];
+	// Matches the docker image of the broker in compose files
+	imagePattern: RegExp;
+	// Matches the connection variables of the broker in .env.example
+	variablePattern: RegExp;
+	usage: string;
+};
+
+/**
+ * Message brokers detected from their client packages or broker config
+ */
+const messageBrokers: MessageBroker[] = [
+	{
+		name: 'Kafka',
+		packages: ['kafkajs', '@confluentinc/kafka-javascript', 'node-rdkafka'],
+		imagePattern: /^\s*image:\s*["']?[\w./-]*kafka\b/m,
+		variablePattern:
+			/^\s*(?:export\s+)?KAFKA_\w*(?:BROKERS?|BOOTSTRAP)\w*\s*=/m,
+		usage:
+			'Publish events to topics with a producer and handle them in consumer groups.',
+	},
+	{
+		name: 'RabbitMQ',
+		packages: ['amqplib', 'amqp-connection-manager', 'rascal'],
+		imagePattern: /^\s*image:\s*["']?(?:[\w.-]+\/)*rabbitmq\b/m,
+		variablePattern: /^\s*(?:export\s+)?(?:AMQP|RABBITMQ)_\w*URL\w*\s*=/m,
+		usage:
+			'Publish messages to exchanges and consume them from queues, acknowledging each message once it is handled.',
+	},
+	{
+		name: 'NATS',
+		packages: ['nats', '@nats-io/transport-node', 'nats.ws'],
+		imagePattern: /^\s*image:\s*["']?(?:[\w.-]+\/)*nats\b/m,
+		variablePattern: /^\s*(?:export\s+)?NATS_\w*(?:URL|SERVERS?)\w*\s*=/m,
+		usage:
+			'Publish messages to subjects and subscribe to them, and use JetStream where messages must not be lost.',
+	},
+	{
+		name: 'AWS SQS',
+		packages: ['@aws-sdk/client-sqs', 'sqs-consumer', 'sqs-producer'],
+		imagePattern: /^\s*image:\s*["']?[\w./-]*elasticmq\b/m,
+		variablePattern: /^\s*(?:export\s+)?\w*SQS_\w*(?:URL|QUEUE)\w*\s*=/m,
+		usage:
+			'Send messages to queues with `SendMessageCommand` and process them in consumers that delete each message once it is handled.',
+	},
+];
+
+/**
+ * Matches docker compose files, e.g. docker-compose.yml
+ */
+const composeFilePattern = /^(?:docker-)?compose(?:\.[\w-]+)?\.ya?ml$/;
+
+/**
+ * Matches committed env templates, e.g. .env.example
+ */
+const envTemplatePattern = /^\.env\.(?:example|sample|template)$/;
+
+/**
+ * Matches relative import paths, e.g. from '../events/kafka.js'
+ */
+const relativeImportPattern =
+	/(?:from\s+|require\(\s*|import\(\s*)["'](\.{1,2}\/[^"']+)["']/g;
+
+/**
+ * Get the path a file is imported by, without extension and index file
+ */
+function toModulePath(file: string): string {
+	return file.replace(sourceFilePattern, '').replace(/\/index$/, '');
+}
+
+/**
+ * Scanner to detect message brokers (Kafka, RabbitMQ, NATS, AWS SQS) and the
+ * modules using them, so agents keep event-driven code asynchronous
+ */
+export class MessageQueueScanner extends BaseScanner {
+	public readonly name = 'message-queue';
+	public readonly watchedFiles = [
+		'package.json',
+		'compose.*',
+		'docker-compose.*',
+		'.env.example',
+		'.env.sample',
+		'.env.template',
+		// Source files are read to find the modules using the broker clients
+		...sourceFileGlobs,
+	];
+
+	/**
+	 * Scan the project to determine which message brokers are used
+	 * Several brokers get one rule each, as each has its own modules
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for message brokers');
+
+		try {
+			const dependencies = await readDependencies(this.rootPath);
+			const files = (await this.fileIndex.getFiles()).map((file) =>
+				this.toRelative(file),
+			);
+			const candidateFiles = files.filter((file) => {
+				const name = path.posix.basename(file);
+				return composeFilePattern.test(name) || envTemplatePattern.test(name);
+			});
+			const configContents = await Promise.all(
+				candidateFiles.map(async (file) => this.readFile(file)),
+			);
+
+			const brokers = messageBrokers
+				.map((broker) => ({
+					broker,
+					packageName: broker.packages.find((name) =>
+						dependencies.includes(name),
+					),
+					configFiles: candidateFiles.filter((file, index) =>
+						(envTemplatePattern.test(path.posix.basename(file))
+							? broker.variablePattern
+							: broker.imagePattern
+						).test(configContents[index]),
+					),
+				}))
+				.filter(
+					({packageName, configFiles}) =>
+						packageName !== undefined || configFiles.length > 0,
+				);
+
+			if (brokers.length === 0) {
+				return [];
+			}
+
+			const importers = await this.findImporters(
+				files,
+				brokers.flatMap(({packageName}) => packageName ?? []),
+			);
+
+			// Every broker is detected on its own, so each needs enough evidence
+			return brokers
+				.map(({broker, packageName, configFiles}) =>
+					this.getBrokerRule(
+						broker,
+						packageName,
+						configFiles,
+						packageName ? (importers.get(packageName) ?? []) : [],
+					),
+				)
+				.filter((rule) => this.hasEnoughEvidence(this.getRuleEvidence([rule])));
+		} catch (error) {
+			this.logger.error('Error scanning for message brokers', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the rule of a message broker
+	 * A broker only found in config files may not be used by this code, so its
+	 * rule gets a medium confidence
+	 * @param broker Detected broker
+	 * @param packageName Client package of the broker, if a dependency
+	 * @param configFiles Compose and env files configuring the broker
+	 * @param sourceFiles Source files using the client package
+	 */
+	private getBrokerRule(
+		broker: MessageBroker,
+		packageName: string | undefined,
+		configFiles: string[],
+		sourceFiles: string[],
+	): AiRule {
+		const client = packageName ? ` (\`${packageName}\`)` : '';
+		const modules = [
+			...new Set(sourceFiles.map((file) => path.posix.dirname(file))),
+		].slice(0, 3);
+		const scope =
+			modules.length > 0
+				? `The code in ${this.formatFiles(modules)} is event-driven.`
+				: 'The architecture is event-driven.';
+
+		return {
+			category: Category.Messaging,
+			rule: `Services communicate asynchronously through ${broker.name}${client}. ${scope} ${broker.usage} Do not replace messages with synchronous HTTP calls between services, and keep handlers idempotent, as a message can be delivered more than once.`,
+			severity: Severity.High,
+			...(packageName ? {} : {confidence: Confidence.Medium}),
+			files: [
+				...(packageName ? ['package.json'] : []),
+				...configFiles,
+				...sourceFiles.slice(0, 5),
+			],
+		};
+	}
+
+	/**
+	 * Find the source files using each of the given packages
+	 * Clients are often created once in a shared module, so files importing a
+	 * file that imports the package count as using it too. Source files are
+	 * only read when a client package is a dependency.
+	 * @returns Files using each package by package name
+	 */
+	private async findImporters(
+		files: string[],
+		packageNames: string[],
+	): Promise<Map<string, string[]>> {
+		const importers = new Map<string, string[]>();
+		if (packageNames.length === 0) {
+			return importers;
+		}
+
+		const sourceFiles = files.filter(
+			(file) => sourceFilePattern.test(file) && !file.endsWith('.d.ts'),
+		);
+		const contents = await mapWithConcurrency(
+			sourceFiles,
+			getDefaultConcurrency(),
+			async (file) => this.readFile(file),
+		);
+
+		for (const packageName of packageNames) {
+			const importPattern = new RegExp(
+				`(?:from\\s+|require\\(\\s*|import\\(\\s*)["']${escapeRegex(packageName)}["']`,
+			);
+			const clientFiles = sourceFiles.filter((_file, index) =>
+				importPattern.test(contents[index]),
+			);
+			const clientModules = new Set(
+				clientFiles.map((file) => toModulePath(file)),
+			);
+			const dependentFiles = sourceFiles.filter(
+				(file, index) =>
+					!clientFiles.includes(file) &&
+					this.getRelativeImports(file, contents[index]).some((module) =>
+						clientModules.has(module),
+					),
+			);
+
+			importers.set(packageName, [...clientFiles, ...dependentFiles]);
+		}
+
+		return importers;
+	}
+
+	/**
+	 * Get the modules a file imports with relative paths, without extension
+	 */
+	private getRelativeImports(file: string, content: string): string[] {
+		return [...content.matchAll(relativeImportPattern)].map((match) =>
+			toModulePath(path.posix.join(path.posix.dirname(file), match[1])),
+		);
+	}
+
+	/**
+	 * Format files as a list of code spans, e.g. "`a` and `b`"
+	 */
+	private formatFiles(files: string[]): string {
+		const names = files.map((file) => `\`${file}\``);
+		return names.length > 1
+			? `${names.slice(0, -1).join(', ')} and ${names.at(-1)}`
+			: names[0];
+	}
+
+	/**
+	 * Read a file relative to the root, empty if it can't be read
+	 */
+	private async readFile(file: string): Promise<string> {
+		return fs.readFile(path.join(this.rootPath, file), 'utf8').catch(() => '');
+	}
+
+	/**
+	 * Get a path relative to the root with forward slashes
+	 */
+	private toRelative(file: string): string {
+		return path.relative(this.rootPath, file).split(path.sep).join('/');
+	}
+}
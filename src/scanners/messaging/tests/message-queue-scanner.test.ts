@@ -0,0 +1,54 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {MessageQueueScanner} from '../message-queue-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+import {Confidence} from '../../../types.js';
+
+describe('MessageQueueScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Client packages', () => {
+		it('should name the broker, the client and the code using it', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {kafkajs: '^2.0.0'}}),
+				'src/consumer.ts': "import {Kafka} from 'kafkajs';\n",
+			});
+
+			const rules = await new MessageQueueScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Services communicate asynchronously through Kafka (`kafkajs`). The code in `src` is event-driven. Publish events to topics with a producer and handle them in consumer groups. Do not replace messages with synchronous HTTP calls between services, and keep handlers idempotent, as a message can be delivered more than once.',
+			]);
+			expect(rules[0].files).toEqual(['package.json', 'src/consumer.ts']);
+		});
+	});
+
+	describe('Broker config', () => {
+		it('should be less certain without a client package', async () => {
+			rootPath = await createFixture({
+				'docker-compose.yml':
+					'services:\n  queue:\n    image: rabbitmq:3-management\n',
+				'.env.example': 'AMQP_URL=\n',
+			});
+
+			const rules = await new MessageQueueScanner(rootPath).scan();
+
+			expect(rules).toHaveLength(1);
+			expect(rules[0].rule).toMatch(
+				'Services communicate asynchronously through RabbitMQ. The architecture is event-driven.',
+			);
+			expect(rules[0].confidence).toBe(Confidence.Medium);
+		});
+
+		it('should not emit rules without a broker', async () => {
+			rootPath = await createFixture({
+				'docker-compose.yml': 'services:\n  db:\n    image: postgres:16\n',
+			});
+
+			expect(await new MessageQueueScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
@@ -0,0 +1 @@
+export {MobileScanner} from './mobile-scanner.js';
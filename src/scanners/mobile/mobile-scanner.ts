@@ -0,0 +1,320 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, Confidence, Severity, type AiRule} from '../../types.js';
+import {getMajorVersion, parseVersion} from '../../utils/version.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * Native platforms and the folders holding their projects
+ */
+const nativePlatforms = [
+	{name: 'iOS', directory: 'ios'},
+	{name: 'Android', directory: 'android'},
+];
+
+/**
+ * Matches the Metro bundler config, e.g. metro.config.js
+ */
+const metroConfigPattern = /^metro\.config\.[cm]?[jt]s$/;
+
+/**
+ * Matches the dynamic Expo app config, e.g. app.config.ts
+ */
+const appConfigPattern = /^app\.config\.[cm]?[jt]s$/;
+
+/**
+ * Matches native module sources, e.g. ios/CalendarModule.swift
+ */
+const nativeModulePattern =
+	/^(?:ios|android)\/.*Module\.(?:kt|java|swift|mm?)$/;
+
+/**
+ * Matches build output and installed pods inside the native folders
+ */
+const nativeBuildPattern = /\/(?:Pods|build)\//;
+
+/**
+ * Matches the Flutter SDK dependency of pubspec.yaml
+ */
+const flutterSdkPattern = /^\s+sdk:\s*["']?flutter["']?\s*$/m;
+
+/**
+ * Scanner to detect mobile apps built with React Native (with or without
+ * Expo) or Flutter, so they don't get the guidance of web projects
+ */
+export class MobileScanner extends BaseScanner {
+	public readonly name = 'mobile';
+	public readonly watchedFiles = [
+		'package.json',
+		'package-lock.json',
+		'pnpm-lock.yaml',
+		'yarn.lock',
+		'bun.lock',
+		'app.json',
+		'app.config.*',
+		'metro.config.*',
+		'pubspec.yaml',
+		'expo-module.config.json',
+		'*Module.kt',
+		'*Module.java',
+		'*Module.swift',
+		'*Module.m',
+		'*Module.mm',
+	];
+	public readonly versionedDependencies = ['expo', 'react-native'];
+
+	/**
+	 * Scan the project to determine if it is a React Native or Flutter app
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for mobile frameworks');
+
+		try {
+			const files = (await this.fileIndex.getFiles()).map((file) =>
+				path.relative(this.rootPath, file).split(path.sep).join('/'),
+			);
+			const platforms = nativePlatforms.filter((platform) =>
+				files.some((file) => file.startsWith(`${platform.directory}/`)),
+			);
+
+			const flutterRule = await this.getFlutterRule(platforms);
+			const rules = flutterRule
+				? [flutterRule]
+				: await this.getReactNativeRules(files, platforms);
+
+			return this.hasEnoughEvidence(this.getRuleEvidence(rules)) ? rules : [];
+		} catch (error) {
+			this.logger.error('Error scanning for mobile frameworks', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the rules for a React Native app, empty if it is not one
+	 */
+	private async getReactNativeRules(
+		files: string[],
+		platforms: typeof nativePlatforms,
+	): Promise<AiRule[]> {
+		const packageJson = await this.readPackageJson();
+		const metroConfig = files.find((file) => metroConfigPattern.test(file));
+		const appConfig = files.find((file) => appConfigPattern.test(file));
+		const hasExpoAppJson = await this.hasExpoAppJson();
+		const hasReactNative = this.hasDependency(packageJson, 'react-native');
+		const hasExpo = this.hasDependency(packageJson, 'expo');
+
+		// If neither package nor config is found, React Native is not used
+		if (!hasReactNative && !hasExpo && !metroConfig && !hasExpoAppJson) {
+			return [];
+		}
+
+		const usesExpo = hasExpo || hasExpoAppJson || appConfig !== undefined;
+		const configFiles = [
+			...(packageJson ? ['package.json'] : []),
+			...(metroConfig ? [metroConfig] : []),
+			...(hasExpoAppJson ? ['app.json'] : []),
+			...(appConfig ? [appConfig] : []),
+		];
+		// Without a declared dependency, e.g. in a workspace package, the
+		// project is only inferred from its config files
+		const confidence =
+			hasReactNative || hasExpo ? {} : {confidence: Confidence.Medium};
+
+		const rules: AiRule[] = [
+			{
+				category: Category.Mobile,
+				rule: await this.getPlatformRule(packageJson, platforms),
+				severity: Severity.High,
+				...confidence,
+				files: configFiles,
+			},
+			{
+				category: Category.Mobile,
+				rule: usesExpo
+					? await this.getExpoRule(hasExpoAppJson, appConfig, platforms)
+					: this.getBareRule(platforms),
+				severity: Severity.High,
+				...confidence,
+				files: configFiles,
+			},
+		];
+
+		const nativeModules = this.findNativeModules(files);
+		if (nativeModules.length > 0) {
+			rules.push({
+				category: Category.Mobile,
+				rule: 'The project has its own native modules. Keep their JavaScript interface in sync with the native code of every platform, and rebuild the app after changing them, as Metro only reloads JavaScript.',
+				severity: Severity.Normal,
+				files: nativeModules.slice(0, 5),
+			});
+		}
+
+		return rules;
+	}
+
+	/**
+	 * Get the rule telling the APIs available on the targeted platforms apart
+	 * react-native-web also runs the app in the browser, where native
+	 * modules are missing
+	 */
+	private async getPlatformRule(
+		packageJson: Record<string, unknown> | undefined,
+		platforms: typeof nativePlatforms,
+	): Promise<string> {
+		const version = await this.resolveDependencyVersion('react-native');
+		const parsed = version ? parseVersion(version) : undefined;
+		const versionLabel = parsed ? ` ${parsed.major}.${parsed.minor}` : '';
+		const components =
+			'Build UI from React Native components such as `View`, `Text` and `Pressable` and style it with `StyleSheet.create()`';
+
+		if (this.hasDependency(packageJson, 'react-native-web')) {
+			return `The app is built with React Native${versionLabel} and also runs on the web through react-native-web. ${components} instead of HTML elements, and keep native-only APIs behind \`Platform.OS\` checks or in \`.native.tsx\` and \`.web.tsx\` files.`;
+		}
+
+		const targets =
+			platforms.length > 0
+				? platforms.map((platform) => platform.name).join(' and ')
+				: 'iOS and Android';
+
+		return `The app is built with React Native${versionLabel} for ${targets}, not for the web. ${components}, and do not use HTML elements, CSS files, \`react-dom\` or browser APIs such as \`window\`, \`document\` and \`localStorage\`.`;
+	}
+
+	/**
+	 * Get the rule for an app using Expo
+	 */
+	private async getExpoRule(
+		hasExpoAppJson: boolean,
+		appConfig: string | undefined,
+		platforms: typeof nativePlatforms,
+	): Promise<string> {
+		const version = await this.resolveDependencyVersion('expo');
+		const major = version ? getMajorVersion(version) : undefined;
+		const sdkLabel = major === undefined ? '' : ` (SDK ${major})`;
+		const configFile = appConfig ?? (hasExpoAppJson ? 'app.json' : undefined);
+		const configLocation = configFile ? ` in \`${configFile}\`` : '';
+		const rule = `The project uses Expo${sdkLabel}. Add packages with \`npx expo install\` so their versions match the SDK, and configure the app${configLocation}.`;
+
+		// Without native folders they are generated by prebuild
+		if (platforms.length === 0) {
+			return `${rule} The \`ios/\` and \`android/\` folders are generated by \`npx expo prebuild\`, change native settings through the app config and config plugins instead of adding them by hand.`;
+		}
+
+		const folders = platforms
+			.map((platform) => `\`${platform.directory}/\``)
+			.join(' and ');
+
+		return `${rule} The native ${folders} folders are checked in, so native settings also have to be changed there.`;
+	}
+
+	/**
+	 * Get the rule for an app using React Native without Expo
+	 */
+	private getBareRule(platforms: typeof nativePlatforms): string {
+		const rule =
+			'The project uses React Native without Expo. Do not add Expo packages or use `expo` commands.';
+
+		return platforms.some((platform) => platform.directory === 'ios')
+			? `${rule} Run \`pod install\` in \`ios/\` after adding a package with native code, and rebuild the app.`
+			: rule;
+	}
+
+	/**
+	 * Get the rule for a Flutter app, undefined if it is not one
+	 */
+	private async getFlutterRule(
+		platforms: typeof nativePlatforms,
+	): Promise<AiRule | undefined> {
+		const pubspecPath = path.join(this.rootPath, 'pubspec.yaml');
+		if (!existsSync(pubspecPath)) {
+			return undefined;
+		}
+
+		// A pubspec.yaml without the Flutter SDK is a plain Dart package
+		const content = await fs.readFile(pubspecPath, 'utf8');
+		if (!flutterSdkPattern.test(content)) {
+			return undefined;
+		}
+
+		const targets =
+			platforms.length > 0
+				? ` for ${platforms.map((platform) => platform.name).join(' and ')}`
+				: '';
+
+		return {
+			category: Category.Mobile,
+			rule: `The app is built with Flutter${targets} and written in Dart. Build UI from composed widgets with \`const\` constructors where possible, add packages with \`flutter pub add\`, and call platform code through platform channels or plugins instead of React Native or web APIs.`,
+			severity: Severity.High,
+			files: ['pubspec.yaml'],
+		};
+	}
+
+	/**
+	 * Find the native module sources and Expo modules of the project
+	 */
+	private findNativeModules(files: string[]): string[] {
+		return files.filter(
+			(file) =>
+				path.posix.basename(file) === 'expo-module.config.json' ||
+				(nativeModulePattern.test(file) && !nativeBuildPattern.test(file)),
+		);
+	}
+
+	/**
+	 * Check if app.json holds an Expo config
+	 */
+	private async hasExpoAppJson(): Promise<boolean> {
+		const appJsonPath = path.join(this.rootPath, 'app.json');
+		if (!existsSync(appJsonPath)) {
+			return false;
+		}
+
+		try {
+			const content = await fs.readFile(appJsonPath, 'utf8');
+			const appJson = JSON.parse(content) as Record<string, unknown>;
+			return typeof appJson.expo === 'object' && appJson.expo !== null;
+		} catch (error) {
+			this.logger.error('Error reading app.json', error);
+			return false;
+		}
+	}
+
+	/**
+	 * Check if package.json declares a dependency or dev dependency
+	 */
+	private hasDependency(
+		packageJson: Record<string, unknown> | undefined,
+		dependency: string,
+	): boolean {
+		const dependencyFields = ['dependencies', 'devDependencies'] as const;
+
+		return dependencyFields.some((field) => {
+			const dependencies = packageJson?.[field];
+			return (
+				typeof dependencies === 'object' &&
+				dependencies !== null &&
+				dependency in dependencies
+			);
+		});
+	}
+
+	/**
+	 * Read and parse package.json
+	 */
+	private async readPackageJson(): Promise<
+		Record<string, unknown> | undefined
+	> {
+		const packageJsonPath = path.join(this.rootPath, 'package.json');
+		if (!existsSync(packageJsonPath)) {
+			return undefined;
+		}
+
+		try {
+			const content = await fs.readFile(packageJsonPath, 'utf8');
+			return JSON.parse(content) as Record<string, unknown>;
+		} catch (error) {
+			this.logger.error('Error reading package.json', error);
+			return undefined;
+		}
+	}
+}
@@ -0,0 +1,73 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {MobileScanner} from '../mobile-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+describe('MobileScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('React Native', () => {
+		it('should describe an Expo app without native folders', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					dependencies: {'react-native': '0.74.0', expo: '~51.0.0'},
+				}),
+				'app.json': JSON.stringify({expo: {name: 'app'}}),
+			});
+
+			const rules = await new MobileScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'The app is built with React Native 0.74 for iOS and Android, not for the web. Build UI from React Native components such as `View`, `Text` and `Pressable` and style it with `StyleSheet.create()`, and do not use HTML elements, CSS files, `react-dom` or browser APIs such as `window`, `document` and `localStorage`.',
+				'The project uses Expo (SDK 51). Add packages with `npx expo install` so their versions match the SDK, and configure the app in `app.json`. The `ios/` and `android/` folders are generated by `npx expo prebuild`, change native settings through the app config and config plugins instead of adding them by hand.',
+			]);
+		});
+
+		it('should find the native modules of a bare app', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					dependencies: {'react-native': '^0.73.0'},
+				}),
+				'metro.config.js': 'module.exports = {};\n',
+				'ios/App/CalendarModule.swift': '',
+				'ios/Pods/Firebase/FirebaseModule.swift': '',
+				'android/app/build.gradle': '',
+			});
+
+			const rules = await new MobileScanner(rootPath).scan();
+
+			expect(rules[1].rule).toBe(
+				'The project uses React Native without Expo. Do not add Expo packages or use `expo` commands. Run `pod install` in `ios/` after adding a package with native code, and rebuild the app.',
+			);
+			expect(rules[2].files).toEqual(['ios/App/CalendarModule.swift']);
+		});
+
+		it('should not emit rules for web projects', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {react: '^19.0.0'}}),
+			});
+
+			expect(await new MobileScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+
+	describe('Flutter', () => {
+		it('should only emit the Flutter rule', async () => {
+			rootPath = await createFixture({
+				'pubspec.yaml':
+					'name: app\ndependencies:\n  flutter:\n    sdk: flutter\n',
+				'ios/Runner/AppDelegate.swift': '',
+				'android/build.gradle': '',
+			});
+
+			const rules = await new MobileScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'The app is built with Flutter for iOS and Android and written in Dart. Build UI from composed widgets with `const` constructors where possible, add packages with `flutter pub add`, and call platform code through platform channels or plugins instead of React Native or web APIs.',
+			]);
+		});
+	});
+});
@@ -0,0 +1,30 @@
+import path from 'node:path';
+import type {FileIndex} from '../../services/file-index.js';
+
+/**
+ * Lock files and the package manager they belong to, by precedence when
+ * several are found
+ */
+export const lockFiles: Record<string, string> = {
+	'pnpm-lock.yaml': 'pnpm',
+	'yarn.lock': 'yarn',
+	'bun.lockb': 'bun',
+	'bun.lock': 'bun',
+	'package-lock.json': 'npm',
+};
+
+/**
+ * Find the lock files in a directory, by precedence
+ * @param fileIndex Index of the files of the directory
+ * @param directoryPath Directory holding the lock files
+ */
+export async function findLockFiles(
+	fileIndex: FileIndex,
+	directoryPath: string,
+): Promise<string[]> {
+	const files = new Set(await fileIndex.getFiles());
+
+	return Object.keys(lockFiles).filter((lockFile) =>
+		files.has(path.join(directoryPath, lockFile)),
+	);
+}
@@ -1,6 +1,6 @@
 import fs from 'node:fs/promises';
 import path from 'node:path';
-import {Category, type AiRule} from '../../types.js';
+import {Category, Confidence, Severity, type AiRule} from '../../types.js';
 import {BaseScanner} from '../base/base-scanner.js';
 
 /**
@@ -8,6 +8,9 @@ import {BaseScanner} from '../base/base-scanner.js';
  * This scanner checks both .nvmrc files and package.json engines
  */
 export class NodeVersionScanner extends BaseScanner {
+	public readonly name = 'node-version';
+	public readonly watchedFiles = ['.nvmrc', 'package.json'];
+
 	/**
 	 * Scan the project to determine which Node.js version is used
 	 */
@@ -17,13 +20,18 @@ export class NodeVersionScanner extends BaseScanner {
 		try {
 			// First check for .nvmrc file (highest priority)
 			const nvmrcPath = path.join(this.rootPath, '.nvmrc');
-			if (await this.fileExists(nvmrcPath)) {
+			if (
+				(await this.fileExists(nvmrcPath)) &&
+				this.hasEnoughEvidence([{file: '.nvmrc'}])
+			) {
 				const nvmrcContent = await fs.readFile(nvmrcPath, 'utf8');
 				const nodeVersion = nvmrcContent.trim();
 				return [
 					{
 						category: Category.NodeVersion,
 						rule: `Use the nodejs version specified in the .nvmrc file (${nodeVersion}).`,
+						severity: Severity.High,
+						values: {version: nodeVersion},
 					},
 				];
 			}
@@ -41,7 +49,8 @@ export class NodeVersionScanner extends BaseScanner {
 					packageJson.engines &&
 					typeof packageJson.engines === 'object' &&
 					packageJson.engines !== null &&
-					'node' in packageJson.engines
+					'node' in packageJson.engines &&
+					this.hasEnoughEvidence([{file: 'package.json'}])
 				) {
 					const nodeEngines = packageJson.engines as Record<string, unknown>;
 					const nodeVersion = String(nodeEngines.node);
@@ -50,6 +59,8 @@ export class NodeVersionScanner extends BaseScanner {
 						{
 							category: Category.NodeVersion,
 							rule: `Use Node.js version ${nodeVersion} as specified in package.json.`,
+							severity: Severity.High,
+							values: {version: nodeVersion},
 						},
 					];
 				}
@@ -60,6 +71,8 @@ export class NodeVersionScanner extends BaseScanner {
 				{
 					category: Category.NodeVersion,
 					rule: 'Use the latest LTS version of Node.js.',
+					severity: Severity.Info,
+					confidence: Confidence.Low,
 				},
 			];
 		} catch (error) {
@@ -7,6 +7,8 @@ import {BaseScanner} from '../base/base-scanner.js';
  * Scanner to detect if the project uses .nvmrc for Node.js version management
  */
 export class NvmrcScanner extends BaseScanner {
+	public readonly name = 'nvmrc';
+
 	/**
 	 * Scan the project to determine if .nvmrc is used
 	 */
@@ -16,7 +18,10 @@ export class NvmrcScanner extends BaseScanner {
 		try {
 			// Check for .nvmrc file
 			const nvmrcPath = path.join(this.rootPath, '.nvmrc');
-			if (await this.fileExists(nvmrcPath)) {
+			if (
+				(await this.fileExists(nvmrcPath)) &&
+				this.hasEnoughEvidence([{file: '.nvmrc'}])
+			) {
 				const nvmrcContent = await fs.readFile(nvmrcPath, 'utf8');
 				const nodeVersion = nvmrcContent.trim();
 				return [
@@ -1,13 +1,58 @@
-import {existsSync} from 'node:fs';
 import fs from 'node:fs/promises';
 import path from 'node:path';
-import {Category, type AiRule} from '../../types.js';
+import {
+	Category,
+	Confidence,
+	Severity,
+	type AiRule,
+	type Evidence,
+} from '../../types.js';
 import {BaseScanner} from '../base/base-scanner.js';
+import {findLockFiles, lockFiles} from './lock-files.js';
+
+/**
+ * Commands of a package manager used in the rules
+ */
+type PackageManagerCommands = {
+	install: string;
+	add: string;
+	run: string;
+};
 
 /**
  * Scanner to detect which package manager is used in the project
+ * The packageManager field of package.json takes precedence over lock files
  */
 export class PackageManagerScanner extends BaseScanner {
+	public readonly name = 'package-manager';
+	public readonly watchedFiles = ['package.json', ...Object.keys(lockFiles)];
+
+	/**
+	 * Install, add and run commands of each package manager
+	 */
+	private readonly commands: Record<string, PackageManagerCommands> = {
+		npm: {
+			install: 'npm install',
+			add: 'npm install <package>',
+			run: 'npm run <script>',
+		},
+		pnpm: {
+			install: 'pnpm install',
+			add: 'pnpm add <package>',
+			run: 'pnpm run <script>',
+		},
+		yarn: {
+			install: 'yarn install',
+			add: 'yarn add <package>',
+			run: 'yarn run <script>',
+		},
+		bun: {
+			install: 'bun install',
+			add: 'bun add <package>',
+			run: 'bun run <script>',
+		},
+	};
+
 	/**
 	 * Scan the project to determine which package manager is used
 	 */
@@ -15,51 +60,70 @@ export class PackageManagerScanner extends BaseScanner {
 		this.logger.debug('Scanning for package manager');
 
 		try {
-			// First check if package.json has packageManager field
-			const packageManagerFromJson =
-				await this.getPackageManagerFromPackageJson();
-			if (packageManagerFromJson) {
-				return [
-					{
-						category: Category.PackageManager,
-						rule: `Use ${packageManagerFromJson} as the package manager.`,
-					},
-				];
-			}
+			const hasPackageJson = await this.hasPackageJson();
+			const packageManagerFromJson = hasPackageJson
+				? await this.getPackageManagerFromPackageJson()
+				: undefined;
+			const foundLockFiles = await findLockFiles(
+				this.fileIndex,
+				this.rootPath,
+			);
 
-			// If no packageManager field, check for lock files
-			if (this.isPnpm()) {
-				return [
-					{
-						category: Category.PackageManager,
-						rule: 'Use pnpm as the package manager.',
-					},
-				];
+			// If no packageManager field or lock file is found, assume npm, unless
+			// the project has no package.json at all, e.g. a Go or Python project
+			if (!packageManagerFromJson && foundLockFiles.length === 0) {
+				return hasPackageJson
+					? [
+							{
+								...this.getPackageManagerRule('npm', ['package.json']),
+								severity: Severity.Normal,
+								confidence: Confidence.Low,
+							},
+						]
+					: [];
 			}
 
-			if (this.isYarn()) {
-				return [
-					{
-						category: Category.PackageManager,
-						rule: 'Use yarn as the package manager.',
-					},
-				];
+			const evidence: Evidence[] = [
+				...(packageManagerFromJson ? ['package.json'] : []),
+				...foundLockFiles,
+			].map((file) => ({file}));
+			if (!this.hasEnoughEvidence(evidence)) {
+				return [];
 			}
 
-			if (this.isNpm()) {
-				return [
-					{
-						category: Category.PackageManager,
-						rule: 'Use npm as the package manager.',
-					},
-				];
+			const packageManager =
+				packageManagerFromJson ?? lockFiles[foundLockFiles[0]];
+			const ownLockFiles = foundLockFiles.filter(
+				(lockFile) => lockFiles[lockFile] === packageManager,
+			);
+			const strayLockFiles = foundLockFiles.filter(
+				(lockFile) => lockFiles[lockFile] !== packageManager,
+			);
+
+			const packageManagerRule = this.getPackageManagerRule(packageManager, [
+				...(packageManagerFromJson ? ['package.json'] : []),
+				...ownLockFiles,
+			]);
+			if (strayLockFiles.length === 0) {
+				return [packageManagerRule];
 			}
 
-			// If no lock file found, assume npm
+			this.logger.warn(
+				`Found lock files of another package manager than ${packageManager}: ${strayLockFiles.join(', ')}`,
+			);
+
+			// The rules describe the conflict between the lock files, so the
+			// aggregator does not add another rule to resolve it
 			return [
 				{
-					category: Category.PackageManager,
-					rule: 'Use npm as the package manager.',
+					...packageManagerRule,
+					// Lock files of several package managers leave the choice unclear
+					...(!packageManagerFromJson && {confidence: Confidence.Medium}),
+					conflictGroup: 'package-manager',
+				},
+				{
+					...this.getStrayLockFilesRule(packageManager, strayLockFiles),
+					conflictGroup: 'package-manager',
 				},
 			];
 		} catch (error) {
@@ -69,27 +133,53 @@ export class PackageManagerScanner extends BaseScanner {
 	}
 
 	/**
-	 * Check if npm is used based on lock file presence
+	 * Get the rule telling which package manager to use and its commands
 	 */
-	private isNpm(): boolean {
-		const lockFilePath = path.join(this.rootPath, 'package-lock.json');
-		return existsSync(lockFilePath);
+	private getPackageManagerRule(
+		packageManager: string,
+		files: string[],
+	): AiRule {
+		const commands = this.commands[packageManager];
+		const usage = commands
+			? ` Install dependencies with \`${commands.install}\`, add packages with \`${commands.add}\` and run scripts with \`${commands.run}\`. Do not mix it with other package managers.`
+			: '';
+
+		return {
+			category: Category.PackageManager,
+			rule: `Use ${packageManager} as the package manager.${usage}`,
+			severity: Severity.High,
+			files,
+			values: {packageManager, ...commands},
+		};
 	}
 
 	/**
-	 * Check if yarn is used based on lock file presence
+	 * Get the rule about lock files of other package managers
 	 */
-	private isYarn(): boolean {
-		const lockFilePath = path.join(this.rootPath, 'yarn.lock');
-		return existsSync(lockFilePath);
+	private getStrayLockFilesRule(
+		packageManager: string,
+		strayLockFiles: string[],
+	): AiRule {
+		const install =
+			this.commands[packageManager]?.install ?? `${packageManager} install`;
+		const lockFileList = strayLockFiles.join(', ');
+		const [belongs, it] =
+			strayLockFiles.length === 1 ? ['belongs', 'it'] : ['belong', 'them'];
+
+		return {
+			category: Category.PackageManager,
+			rule: `${lockFileList} ${belongs} to another package manager than ${packageManager}. Do not update ${it}, only keep the lock file written by \`${install}\`.`,
+			files: strayLockFiles,
+			values: {packageManager, install, lockFiles: lockFileList},
+		};
 	}
 
 	/**
-	 * Check if pnpm is used based on lock file presence
+	 * Check if the scanned directory has a package.json
 	 */
-	private isPnpm(): boolean {
-		const lockFilePath = path.join(this.rootPath, 'pnpm-lock.yaml');
-		return existsSync(lockFilePath);
+	private async hasPackageJson(): Promise<boolean> {
+		const files = await this.fileIndex.getFiles();
+		return files.includes(path.join(this.rootPath, 'package.json'));
 	}
 
 	/**
@@ -99,11 +189,6 @@ export class PackageManagerScanner extends BaseScanner {
 		string | undefined
 	> {
 		const packageJsonPath = path.join(this.rootPath, 'package.json');
-		const packageJsonExists = existsSync(packageJsonPath);
-
-		if (!packageJsonExists) {
-			return undefined;
-		}
 
 		try {
 			const packageJsonContent = await fs.readFile(packageJsonPath, 'utf8');
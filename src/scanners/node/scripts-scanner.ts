@@ -0,0 +1,165 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import type {FileIndex} from '../../services/file-index.js';
+import {WorkspaceDetector} from '../../services/workspace-detector.js';
+import {Category, CommandPurpose, type AiRule} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+import {findLockFiles, lockFiles} from './lock-files.js';
+
+/**
+ * Scanner to detect the build, test and lint commands defined in package.json scripts
+ */
+export class ScriptsScanner extends BaseScanner {
+	public readonly name = 'scripts';
+	public readonly watchedFiles = [
+		'package.json',
+		...Object.keys(lockFiles),
+		'pnpm-workspace.yaml',
+		'lerna.json',
+		'nx.json',
+		'turbo.json',
+	];
+
+	/**
+	 * Well-known script names and what running them does
+	 */
+	private readonly knownScripts: Record<string, CommandPurpose> = {
+		dev: CommandPurpose.Dev,
+		start: CommandPurpose.Start,
+		build: CommandPurpose.Build,
+		test: CommandPurpose.Test,
+		lint: CommandPurpose.Lint,
+		'lint:fix': CommandPurpose.Fix,
+		format: CommandPurpose.Format,
+		typecheck: CommandPurpose.Typecheck,
+	};
+
+	/**
+	 * Scan the project to determine which commands are defined in package.json
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for package.json scripts');
+
+		try {
+			const packageJson = await this.readPackageJson();
+			if (!packageJson) {
+				return [];
+			}
+
+			const scripts = packageJson.scripts as
+				| Record<string, unknown>
+				| undefined;
+			if (
+				!scripts ||
+				typeof scripts !== 'object' ||
+				!this.hasEnoughEvidence([{file: 'package.json'}])
+			) {
+				return [];
+			}
+
+			const packageManager = await this.getPackageManager(packageJson);
+			const recommendations: AiRule[] = [];
+
+			// The text of the rules is set when the commands of all scanners are
+			// merged by purpose
+			for (const [scriptName, purpose] of Object.entries(this.knownScripts)) {
+				if (typeof scripts[scriptName] === 'string') {
+					const command = `${packageManager} run ${scriptName}`;
+					recommendations.push({
+						category: Category.Commands,
+						rule: `Run \`${command}\`.`,
+						files: ['package.json'],
+						commands: [{command, purpose}],
+					});
+				}
+			}
+
+			return recommendations;
+		} catch (error) {
+			this.logger.error('Error scanning for package.json scripts', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Read and parse package.json
+	 */
+	private async readPackageJson(): Promise<
+		Record<string, unknown> | undefined
+	> {
+		const packageJsonPath = path.join(this.rootPath, 'package.json');
+		if (!existsSync(packageJsonPath)) {
+			return undefined;
+		}
+
+		try {
+			const packageJsonContent = await fs.readFile(packageJsonPath, 'utf8');
+			return JSON.parse(packageJsonContent) as Record<string, unknown>;
+		} catch (error) {
+			this.logger.error('Error reading package.json', error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Get the package manager used to run scripts
+	 */
+	private async getPackageManager(
+		packageJson: Record<string, unknown>,
+	): Promise<string> {
+		if (typeof packageJson.packageManager === 'string') {
+			return packageJson.packageManager.split('@')[0];
+		}
+
+		return (
+			(await this.getLockFilePackageManager(this.fileIndex, this.rootPath)) ??
+			(await this.getWorkspacePackageManager()) ??
+			'npm'
+		);
+	}
+
+	/**
+	 * Get the package manager of the lock file in a directory
+	 * @param fileIndex Index of the files of the directory
+	 * @param directoryPath Directory holding the lock file
+	 */
+	private async getLockFilePackageManager(
+		fileIndex: FileIndex,
+		directoryPath: string,
+	): Promise<string | undefined> {
+		const [lockFile] = await findLockFiles(fileIndex, directoryPath);
+		return lockFile ? lockFiles[lockFile] : undefined;
+	}
+
+	/**
+	 * Get the package manager of the lock file of the workspace, when scanning a
+	 * package of the workspace, as packages share the lock file of the root
+	 * The workspace root is the scanned directory, so no file outside of it is
+	 * read.
+	 */
+	private async getWorkspacePackageManager(): Promise<string | undefined> {
+		const workspaceIndex = this.fileIndex.getParent();
+		const workspaceRoot = workspaceIndex?.getRootPath();
+		if (!workspaceIndex || !workspaceRoot || workspaceRoot === this.rootPath) {
+			return undefined;
+		}
+
+		const packagePath = path
+			.relative(workspaceRoot, this.rootPath)
+			.split(path.sep)
+			.join('/');
+		const packages = await new WorkspaceDetector(
+			workspaceRoot,
+			workspaceIndex,
+		).detectPackages();
+		const isWorkspacePackage = packages.some(
+			(workspacePackage) => workspacePackage.path === packagePath,
+		);
+		if (!isWorkspacePackage) {
+			return undefined;
+		}
+
+		return this.getLockFilePackageManager(workspaceIndex, workspaceRoot);
+	}
+}
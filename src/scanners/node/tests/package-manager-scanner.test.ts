@@ -0,0 +1,67 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {PackageManagerScanner} from '../package-manager-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+describe('PackageManagerScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	/**
+	 * Get the package managers of the rules of the scanned fixture
+	 */
+	async function getPackageManagers(): Promise<string[]> {
+		const rules = await new PackageManagerScanner(rootPath).scan();
+		return rules.map((rule) => rule.values?.packageManager ?? '');
+	}
+
+	describe('Lock files', () => {
+		it('should use the package manager of the lock file', async () => {
+			rootPath = await createFixture({
+				'package.json': '{}',
+				'yarn.lock': '',
+			});
+
+			expect(await getPackageManagers()).toEqual(['yarn']);
+		});
+
+		it('should prefer the packageManager field of package.json', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({packageManager: 'pnpm@9.0.0'}),
+				'package-lock.json': '{}',
+			});
+
+			const rules = await new PackageManagerScanner(rootPath).scan();
+
+			expect(rules[0].values?.packageManager).toBe('pnpm');
+			expect(rules[0].files).toEqual(['package.json']);
+		});
+
+		it('should skip lock files ignored by git', async () => {
+			rootPath = await createFixture({
+				'.gitignore': 'yarn.lock\n',
+				'package.json': '{}',
+				'yarn.lock': '',
+				'bun.lock': '',
+			});
+
+			expect(await getPackageManagers()).toEqual(['bun']);
+		});
+	});
+
+	describe('Without lock files', () => {
+		it('should assume npm with a package.json', async () => {
+			rootPath = await createFixture({'package.json': '{}'});
+
+			expect(await getPackageManagers()).toEqual(['npm']);
+		});
+
+		it('should not emit rules without a package.json', async () => {
+			rootPath = await createFixture({'go.mod': 'module example.com/app\n'});
+
+			expect(await getPackageManagers()).toEqual([]);
+		});
+	});
+});
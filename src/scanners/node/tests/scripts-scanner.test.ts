@@ -0,0 +1,68 @@
+import path from 'node:path';
+import {afterEach, describe, expect, it} from 'vitest';
+import {FileIndex} from '../../../services/file-index.js';
+import {ScriptsScanner} from '../scripts-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+const workspaceFiles = {
+	'package.json': JSON.stringify({name: 'workspace', private: true}),
+	'pnpm-workspace.yaml': 'packages:\n  - packages/*\n',
+	'pnpm-lock.yaml': "lockfileVersion: '9.0'\n",
+	'packages/web/package.json': JSON.stringify({
+		name: 'web',
+		scripts: {build: 'vite build', test: 'vitest'},
+	}),
+};
+
+describe('ScriptsScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	/**
+	 * Get the commands of the rules of a scanner
+	 */
+	async function getCommands(scanner: ScriptsScanner): Promise<string[]> {
+		const rules = await scanner.scan();
+		return rules.flatMap((rule) =>
+			(rule.commands ?? []).map(({command}) => command),
+		);
+	}
+
+	it('should run the known scripts with the package manager of the lock file', async () => {
+		rootPath = await createFixture({
+			'package.json': JSON.stringify({
+				scripts: {dev: 'vite', lint: 'xo', release: 'np'},
+			}),
+			'yarn.lock': '',
+		});
+
+		expect(await getCommands(new ScriptsScanner(rootPath))).toEqual([
+			'yarn run dev',
+			'yarn run lint',
+		]);
+	});
+
+	it('should use the lock file of the workspace for its packages', async () => {
+		rootPath = await createFixture(workspaceFiles);
+		const packagePath = path.join(rootPath, 'packages/web');
+		const fileIndex = new FileIndex(rootPath).createSubIndex(packagePath);
+
+		expect(
+			await getCommands(new ScriptsScanner(packagePath, fileIndex)),
+		).toEqual(['pnpm run build', 'pnpm run test']);
+	});
+
+	it('should not read the directories above the scanned directory', async () => {
+		rootPath = await createFixture(workspaceFiles);
+
+		const scanner = new ScriptsScanner(path.join(rootPath, 'packages/web'));
+
+		expect(await getCommands(scanner)).toEqual([
+			'npm run build',
+			'npm run test',
+		]);
+	});
+});
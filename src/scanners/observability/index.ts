@@ -0,0 +1 @@
+export {ObservabilityScanner} from './observability-scanner.js';
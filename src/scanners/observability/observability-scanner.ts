@@ -0,0 +1,309 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, Confidence, Severity, type AiRule} from '../../types.js';
+import {
+	getDefaultConcurrency,
+	mapWithConcurrency,
+} from '../../utils/concurrency.js';
+import {BaseScanner} from '../base/base-scanner.js';
+import {
+	escapeRegex,
+	readDependencies,
+	sourceFileGlobs,
+	sourceFilePattern,
+} from '../base/scanner-helpers.js';
+
+/**
+ * What an observability tool covers, which decides the wording of its rule
+ */
+type ObservabilityKind = 'logging' | 'tracing' | 'errors' | 'metrics';
+
+/**
+ * A logging or monitoring tool and how to recognize it
+ */
+type ObservabilityTool = {
+	name: string;
+	kind: ObservabilityKind;
+	// Matches the packages of the tool in package.json
+	packagePattern: RegExp;
+	// Matches the names of the files setting up the tool, e.g. src/logger.ts
+	setupFilePattern: RegExp;
+	// Matches config files of the tool, which are enough to detect it
+	configFilePattern?: RegExp;
+	usage: string;
+};
+
+/**
+ * Logging and monitoring tools, loggers first so the rule asking to not use
+ * console.log comes first
+ */
+const observabilityTools: ObservabilityTool[] = [
+	{
+		name: 'Pino',
+		kind: 'logging',
+		packagePattern: /^(?:pino|pino-http|nestjs-pino)$/,
+		setupFilePattern: /log/i,
+		usage:
+			'Use the log levels of the logger (`logger.info`, `logger.error`) and pass context as an object before the message, e.g. `logger.info({orderId}, "Order created")`.',
+	},
+	{
+		name: 'Winston',
+		kind: 'logging',
+		packagePattern: /^(?:winston|nest-winston)$/,
+		setupFilePattern: /log/i,
+		usage:
+			'Use the log levels of the logger (`logger.info`, `logger.error`) and pass context as metadata after the message, e.g. `logger.info("Order created", {orderId})`.',
+	},
+	{
+		name: 'OpenTelemetry',
+		kind: 'tracing',
+		packagePattern: /^(?:@opentelemetry\/|@vercel\/otel$)/,
+		setupFilePattern: /instrument|tracing|tracer|otel|telemetry/i,
+		usage:
+			'Wrap new operations that call other services or do I/O in spans with `tracer.startActiveSpan()` from `@opentelemetry/api`, and record errors on the span with `span.recordException()`.',
+	},
+	{
+		name: 'Datadog',
+		kind: 'tracing',
+		packagePattern: /^(?:dd-trace|@datadog\/browser-(?:rum|logs))$/,
+		setupFilePattern: /instrument|tracing|tracer|datadog/i,
+		usage:
+			'Add custom spans with `tracer.trace()` and tags with `span.setTag()`, and keep `dd-trace` initialized before any other import.',
+	},
+	{
+		name: 'Sentry',
+		kind: 'errors',
+		packagePattern: /^@sentry\//,
+		setupFilePattern: /sentry|instrument/i,
+		configFilePattern:
+			/^(?:sentry\.(?:client|server|edge)\.config\.[cm]?[jt]s|\.sentryclirc|sentry\.properties)$/,
+		usage:
+			'Report caught errors that are not rethrown with `Sentry.captureException()`, so they are not only logged.',
+	},
+	{
+		name: 'Prometheus',
+		kind: 'metrics',
+		packagePattern:
+			/^(?:prom-client|express-prom-bundle|@willsoto\/nestjs-prometheus)$/,
+		setupFilePattern: /metric|prometheus/i,
+		usage:
+			'Register new counters and histograms on the existing registry, and name them like the existing metrics, in snake_case with a unit suffix such as `_seconds` or `_total`.',
+	},
+];
+
+/**
+ * How the rule of each kind of tool starts, and how it names the setup file
+ */
+const kindDescriptions: Record<
+	ObservabilityKind,
+	{intro: string; location: string}
+> = {
+	logging: {
+		intro: 'Logging goes through',
+		location: 'with the shared logger in',
+	},
+	tracing: {intro: 'Traces are collected with', location: 'set up in'},
+	errors: {intro: 'Errors are reported to', location: 'set up in'},
+	metrics: {intro: 'Metrics are exposed to', location: 'defined in'},
+};
+
+/**
+ * Scanner to detect logging and monitoring (Pino, Winston, OpenTelemetry,
+ * Datadog, Sentry, Prometheus), so new code logs and is instrumented like the
+ * existing code instead of using console.log
+ */
+export class ObservabilityScanner extends BaseScanner {
+	public readonly name = 'observability';
+	public readonly watchedFiles = [
+		'package.json',
+		'sentry.*.config.*',
+		'.sentryclirc',
+		'sentry.properties',
+		// Source files are read to find the setup file of each tool
+		...sourceFileGlobs,
+	];
+
+	/**
+	 * Scan the project to determine which logging and monitoring tools are used
+	 * Every tool gets its own rule, as they cover different signals
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for observability tools');
+
+		try {
+			const dependencies = await readDependencies(this.rootPath);
+			const files = (await this.fileIndex.getFiles()).map((file) =>
+				this.toRelative(file),
+			);
+
+			const tools = observabilityTools
+				.map((tool) => ({
+					tool,
+					packageNames: dependencies.filter((name) =>
+						tool.packagePattern.test(name),
+					),
+					configFiles: files.filter(
+						(file) =>
+							tool.configFilePattern?.test(path.posix.basename(file)) ??
+							false,
+					),
+				}))
+				.filter(
+					({packageNames, configFiles}) =>
+						packageNames.length > 0 || configFiles.length > 0,
+				);
+
+			if (tools.length === 0) {
+				return [];
+			}
+
+			const importers = await this.findImporters(
+				files,
+				tools.flatMap(({packageNames}) => packageNames),
+			);
+
+			// Every tool is detected on its own, so each needs enough evidence
+			return tools
+				.map(({tool, packageNames, configFiles}) =>
+					this.getToolRule(
+						tool,
+						packageNames,
+						configFiles,
+						this.findSetupFile(
+							tool,
+							packageNames.flatMap((name) => importers.get(name) ?? []),
+						),
+					),
+				)
+				.filter((rule) => this.hasEnoughEvidence(this.getRuleEvidence([rule])));
+		} catch (error) {
+			this.logger.error('Error scanning for observability tools', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the rule of an observability tool
+	 * A tool only found by its config files may no longer be installed, so its
+	 * rule gets a medium confidence
+	 * @param tool Detected tool
+	 * @param packageNames Packages of the tool in package.json
+	 * @param configFiles Config files of the tool
+	 * @param setupFile File setting up the tool, if found
+	 */
+	private getToolRule(
+		tool: ObservabilityTool,
+		packageNames: string[],
+		configFiles: string[],
+		setupFile: string | undefined,
+	): AiRule {
+		const packageLabel =
+			packageNames.length > 0 ? ` (\`${packageNames[0]}\`)` : '';
+
+		return {
+			category: Category.Observability,
+			rule: [
+				this.describeTool(tool, packageLabel, setupFile),
+				tool.usage,
+				tool.kind === 'logging'
+					? 'Do not use `console.log` in application code, log through the existing logger instead.'
+					: 'Instrument new code the same way as the existing code.',
+			].join(' '),
+			...(tool.kind === 'logging' && {severity: Severity.High}),
+			...(packageNames.length > 0 ? {} : {confidence: Confidence.Medium}),
+			files: [
+				...(packageNames.length > 0 ? ['package.json'] : []),
+				...configFiles,
+				...(setupFile ? [setupFile] : []),
+			],
+		};
+	}
+
+	/**
+	 * Describe what a tool does and where it is set up
+	 * e.g. "Logging goes through Pino (`pino`), with the shared logger in
+	 * `src/logger.ts`."
+	 */
+	private describeTool(
+		tool: ObservabilityTool,
+		packageLabel: string,
+		setupFile: string | undefined,
+	): string {
+		const {intro, location} = kindDescriptions[tool.kind];
+		const setup = setupFile ? `, ${location} \`${setupFile}\`` : '';
+
+		return `${intro} ${tool.name}${packageLabel}${setup}.`;
+	}
+
+	/**
+	 * Find the file setting up a tool among the files importing it
+	 * A file named after the tool is preferred, e.g. src/lib/logger.ts, and
+	 * otherwise the only file importing it
+	 */
+	private findSetupFile(
+		tool: ObservabilityTool,
+		importers: string[],
+	): string | undefined {
+		const uniqueImporters = [...new Set(importers)].sort();
+		const namedFile = uniqueImporters.find((file) =>
+			tool.setupFilePattern.test(path.posix.basename(file)),
+		);
+
+		return (
+			namedFile ??
+			(uniqueImporters.length === 1 ? uniqueImporters[0] : undefined)
+		);
+	}
+
+	/**
+	 * Find the source files importing each of the given packages
+	 * @returns Files importing each package by package name
+	 */
+	private async findImporters(
+		files: string[],
+		packageNames: string[],
+	): Promise<Map<string, string[]>> {
+		const importers = new Map<string, string[]>();
+		if (packageNames.length === 0) {
+			return importers;
+		}
+
+		const sourceFiles = files.filter(
+			(file) => sourceFilePattern.test(file) && !file.endsWith('.d.ts'),
+		);
+		const contents = await mapWithConcurrency(
+			sourceFiles,
+			getDefaultConcurrency(),
+			async (file) => this.readFile(file),
+		);
+
+		for (const packageName of packageNames) {
+			// Subpath imports such as "pino/file" import the package too
+			const importPattern = new RegExp(
+				`(?:from\\s+|require\\(\\s*|import\\(\\s*|import\\s+)["']${escapeRegex(packageName)}(?:/[^"']*)?["']`,
+			);
+			importers.set(
+				packageName,
+				sourceFiles.filter((_file, index) =>
+					importPattern.test(contents[index]),
+				),
+			);
+		}
+
+		return importers;
+	}
+
+	/**
+	 * Read a file relative to the root, empty if it can't be read
+	 */
+	private async readFile(file: string): Promise<string> {
+		return fs.readFile(path.join(this.rootPath, file), 'utf8').catch(() => '');
+	}
+
+	/**
+	 * Get a path relative to the root with forward slashes
+	 */
+	private toRelative(file: string): string {
+		return path.relative(this.rootPath, file).split(path.sep).join('/');
+	}
+}
@@ -0,0 +1,59 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {ObservabilityScanner} from '../observability-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+import {Confidence, Severity} from '../../../types.js';
+
+describe('ObservabilityScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Packages', () => {
+		it('should point to the setup file of each tool', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					dependencies: {pino: '^9.0.0', '@sentry/node': '^8.0.0'},
+				}),
+				'src/logger.ts': "import pino from 'pino';\n",
+				'src/instrument.ts': "import * as Sentry from '@sentry/node';\n",
+			});
+
+			const rules = await new ObservabilityScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.files)).toEqual([
+				['package.json', 'src/logger.ts'],
+				['package.json', 'src/instrument.ts'],
+			]);
+			expect(rules[0].rule).toMatch(
+				'Do not use `console.log` in application code, log through the existing logger instead.',
+			);
+			expect(rules[0].severity).toBe(Severity.High);
+		});
+	});
+
+	describe('Config files', () => {
+		it('should be less certain with only a config file', async () => {
+			rootPath = await createFixture({
+				'sentry.client.config.ts': 'Sentry.init({});\n',
+			});
+
+			const rules = await new ObservabilityScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Errors are reported to Sentry. Report caught errors that are not rethrown with `Sentry.captureException()`, so they are not only logged. Instrument new code the same way as the existing code.',
+			]);
+			expect(rules[0].confidence).toBe(Confidence.Medium);
+		});
+
+		it('should not emit rules without an observability tool', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {express: '^4.0.0'}}),
+				'src/logger.ts': 'export const log = console.log;\n',
+			});
+
+			expect(await new ObservabilityScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
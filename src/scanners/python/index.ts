@@ -0,0 +1 @@
+export {PythonScanner} from './python-scanner.js';
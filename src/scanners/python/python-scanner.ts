@@ -0,0 +1,305 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, Severity, type AiRule} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * Key/value pairs of each TOML table, keyed by table name
+ */
+type TomlTables = Record<string, Record<string, string>>;
+
+/**
+ * Scanner to detect Python projects, their package manager and formatting conventions
+ */
+export class PythonScanner extends BaseScanner {
+	public readonly name = 'python';
+	public readonly watchedFiles = [
+		'pyproject.toml',
+		'setup.py',
+		'requirements*.txt',
+		'Pipfile',
+		'Pipfile.lock',
+		'poetry.lock',
+		'uv.lock',
+	];
+
+	/**
+	 * Files that mark a Python project
+	 */
+	private readonly projectFiles = [
+		'pyproject.toml',
+		'requirements.txt',
+		'Pipfile',
+		'poetry.lock',
+		'setup.py',
+		'uv.lock',
+	];
+
+	/**
+	 * Python package managers and how to use them
+	 */
+	private readonly packageManagerRules: Record<string, string> = {
+		uv: 'Use uv to manage Python dependencies (`uv add <package>`, `uv run <command>`). Do not install packages with pip directly.',
+		poetry:
+			'Use Poetry to manage Python dependencies (`poetry add <package>`, `poetry run <command>`). Do not install packages with pip directly.',
+		pipenv:
+			'Use Pipenv to manage Python dependencies (`pipenv install <package>`, `pipenv run <command>`). Do not install packages with pip directly.',
+		pip: 'Use pip to manage Python dependencies and keep requirements.txt up to date when adding packages.',
+	};
+
+	/**
+	 * Scan the project to determine Python tooling
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for Python configuration');
+
+		try {
+			const foundFiles = this.projectFiles.filter((file) =>
+				this.fileExists(file),
+			);
+
+			// If no Python project files found, don't return any recommendations
+			if (
+				foundFiles.length === 0 ||
+				!this.hasEnoughEvidence(foundFiles.map((file) => ({file})))
+			) {
+				return [];
+			}
+
+			const tables = await this.readPyproject();
+			const recommendations: AiRule[] = [];
+
+			const packageManager = this.getPackageManager(tables);
+			recommendations.push({
+				category: Category.Python,
+				rule: this.packageManagerRules[packageManager],
+				severity: Severity.High,
+				files: foundFiles,
+			});
+
+			const requiredVersion = await this.getRequiredPythonVersion(tables);
+			if (requiredVersion) {
+				recommendations.push({
+					category: Category.Python,
+					rule: `The project supports Python ${requiredVersion.version}. Only use syntax and standard library APIs available in the oldest supported version.`,
+					severity: Severity.High,
+					files: [requiredVersion.file],
+				});
+			}
+
+			if (tables) {
+				recommendations.push(...this.getFormattingRules(tables));
+			}
+
+			return recommendations;
+		} catch (error) {
+			this.logger.error('Error scanning for Python configuration', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Check if a file exists in the project root
+	 */
+	private fileExists(fileName: string): boolean {
+		return existsSync(path.join(this.rootPath, fileName));
+	}
+
+	/**
+	 * Determine the package manager from lock files and pyproject.toml
+	 */
+	private getPackageManager(tables: TomlTables | undefined): string {
+		if (this.fileExists('uv.lock') || tables?.['tool.uv']) {
+			return 'uv';
+		}
+
+		if (this.fileExists('poetry.lock') || tables?.['tool.poetry']) {
+			return 'poetry';
+		}
+
+		if (this.fileExists('Pipfile') || this.fileExists('Pipfile.lock')) {
+			return 'pipenv';
+		}
+
+		return 'pip';
+	}
+
+	/**
+	 * Get the supported Python versions from requires-python in pyproject.toml
+	 * or python_requires in setup.py
+	 */
+	private async getRequiredPythonVersion(
+		tables: TomlTables | undefined,
+	): Promise<{version: string; file: string} | undefined> {
+		const requiresPython = tables?.project?.['requires-python'];
+		if (requiresPython) {
+			return {version: this.unquote(requiresPython), file: 'pyproject.toml'};
+		}
+
+		if (!this.fileExists('setup.py')) {
+			return undefined;
+		}
+
+		try {
+			const content = await fs.readFile(
+				path.join(this.rootPath, 'setup.py'),
+				'utf8',
+			);
+			const version = /python_requires\s*=\s*["']([^"']+)["']/.exec(
+				content,
+			)?.[1];
+			return version ? {version, file: 'setup.py'} : undefined;
+		} catch (error) {
+			this.logger.error('Error reading setup.py', error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Read and parse pyproject.toml
+	 */
+	private async readPyproject(): Promise<TomlTables | undefined> {
+		const pyprojectPath = path.join(this.rootPath, 'pyproject.toml');
+
+		if (!existsSync(pyprojectPath)) {
+			return undefined;
+		}
+
+		try {
+			const content = await fs.readFile(pyprojectPath, 'utf8');
+			return this.parseTomlTables(content);
+		} catch (error) {
+			this.logger.error('Error reading pyproject.toml', error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Parse the tables of a TOML file into raw key/value pairs
+	 * Only top-level keys of each table are read, which is all the scanner needs
+	 */
+	private parseTomlTables(content: string): TomlTables {
+		const tables: TomlTables = {};
+		let currentTable: Record<string, string> = {};
+		tables[''] = currentTable;
+		let pendingKey: string | undefined;
+
+		for (const line of content.split('\n')) {
+			const trimmedLine = line.replace(/\s+#.*$/, '').trim();
+
+			// Continuation of a multi-line array
+			if (pendingKey) {
+				currentTable[pendingKey] += ` ${trimmedLine}`;
+				if (trimmedLine.endsWith(']')) {
+					pendingKey = undefined;
+				}
+
+				continue;
+			}
+
+			if (!trimmedLine || trimmedLine.startsWith('#')) {
+				continue;
+			}
+
+			const tableMatch = /^\[([^[\]]+)]$/.exec(trimmedLine);
+			if (tableMatch) {
+				currentTable = {};
+				tables[tableMatch[1].trim()] = currentTable;
+				continue;
+			}
+
+			const keyMatch = /^([\w.-]+)\s*=\s*(.*)$/.exec(trimmedLine);
+			if (keyMatch) {
+				const [, key, value] = keyMatch;
+				currentTable[key] = value;
+				if (value.startsWith('[') && !value.endsWith(']')) {
+					pendingKey = key;
+				}
+			}
+		}
+
+		return tables;
+	}
+
+	/**
+	 * Remove the quotes around a TOML string value
+	 */
+	private unquote(value: string): string {
+		return value.replace(/^["']|["']$/g, '');
+	}
+
+	/**
+	 * Get formatting conventions from the Ruff and Black configuration
+	 */
+	private getFormattingRules(tables: TomlTables): AiRule[] {
+		const rules: AiRule[] = [];
+		const black = tables['tool.black'];
+
+		// Ruff can be configured in [tool.ruff] or only in its sub-tables
+		const hasRuff = Object.keys(tables).some(
+			(table) => table === 'tool.ruff' || table.startsWith('tool.ruff.'),
+		);
+
+		if (hasRuff) {
+			const ruff = tables['tool.ruff'] ?? {};
+			const ruffFormat = tables['tool.ruff.format'] ?? {};
+			const ruffLint = tables['tool.ruff.lint'] ?? {};
+
+			rules.push({
+				category: Category.Python,
+				rule: 'Use Ruff for linting Python code and fix reported issues before committing.',
+				files: ['pyproject.toml'],
+			});
+
+			if (ruff['line-length']) {
+				rules.push({
+					category: Category.Python,
+					rule: `Keep Python lines at most ${ruff['line-length']} characters long (Ruff line-length).`,
+					files: ['pyproject.toml'],
+				});
+			}
+
+			if (ruff['target-version']) {
+				rules.push({
+					category: Category.Python,
+					rule: `Ruff targets ${this.unquote(ruff['target-version'])}. Do not use syntax unavailable in that Python version.`,
+					files: ['pyproject.toml'],
+				});
+			}
+
+			const quoteStyle = ruffFormat['quote-style'];
+			if (quoteStyle) {
+				rules.push({
+					category: Category.Python,
+					rule: `Use ${this.unquote(quoteStyle)} quotes for Python strings (Ruff quote-style).`,
+					files: ['pyproject.toml'],
+				});
+			}
+
+			const selectedRules = ruffLint.select ?? ruff.select;
+			if (selectedRules) {
+				const ruleCodes = [
+					...selectedRules.matchAll(/["']([^"']+)["']/g),
+				].map((match) => match[1]);
+				if (ruleCodes.length > 0) {
+					rules.push({
+						category: Category.Python,
+						rule: `Ruff enforces the rule sets: ${ruleCodes.join(', ')}.`,
+						files: ['pyproject.toml'],
+					});
+				}
+			}
+		}
+
+		if (black) {
+			rules.push({
+				category: Category.Python,
+				rule: `Format Python code with Black and keep lines at most ${black['line-length'] ?? '88'} characters long.`,
+				files: ['pyproject.toml'],
+			});
+		}
+
+		return rules;
+	}
+}
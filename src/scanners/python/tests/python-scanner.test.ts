@@ -0,0 +1,75 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {PythonScanner} from '../python-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+const pyproject = `[project]
+name = "app"
+requires-python = ">=3.10"
+
+[tool.ruff]
+line-length = 100
+target-version = "py310"
+
+[tool.ruff.lint]
+select = ["E", "F", "I"]
+
+[tool.ruff.format]
+quote-style = "single"
+`;
+
+describe('PythonScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Package manager', () => {
+		it('should detect uv from its lock file', async () => {
+			rootPath = await createFixture({
+				'pyproject.toml': pyproject,
+				'uv.lock': '',
+			});
+
+			const rules = await new PythonScanner(rootPath).scan();
+
+			expect(rules[0].rule).toBe(
+				'Use uv to manage Python dependencies (`uv add <package>`, `uv run <command>`). Do not install packages with pip directly.',
+			);
+			expect(rules[0].files).toEqual(['pyproject.toml', 'uv.lock']);
+		});
+
+		it('should fall back to pip', async () => {
+			rootPath = await createFixture({'requirements.txt': 'django\n'});
+
+			const rules = await new PythonScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Use pip to manage Python dependencies and keep requirements.txt up to date when adding packages.',
+			]);
+		});
+	});
+
+	describe('Tools', () => {
+		it('should read the Python version and the Ruff settings', async () => {
+			rootPath = await createFixture({'pyproject.toml': pyproject});
+
+			const rules = await new PythonScanner(rootPath).scan();
+
+			expect(rules.slice(1).map((rule) => rule.rule)).toEqual([
+				'The project supports Python >=3.10. Only use syntax and standard library APIs available in the oldest supported version.',
+				'Use Ruff for linting Python code and fix reported issues before committing.',
+				'Keep Python lines at most 100 characters long (Ruff line-length).',
+				'Ruff targets py310. Do not use syntax unavailable in that Python version.',
+				'Use single quotes for Python strings (Ruff quote-style).',
+				'Ruff enforces the rule sets: E, F, I.',
+			]);
+		});
+
+		it('should not emit rules without Python project files', async () => {
+			rootPath = await createFixture({'scripts/build.py': 'print("build")\n'});
+
+			expect(await new PythonScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
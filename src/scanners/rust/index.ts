@@ -0,0 +1 @@
+export {RustScanner} from './rust-scanner.js';
@@ -0,0 +1,433 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, CommandPurpose, Severity, type AiRule} from '../../types.js';
+import {globToRegex} from '../../utils/glob.js';
+import {BaseScanner} from '../base/base-scanner.js';
+
+/**
+ * Key/value pairs of each TOML table, keyed by table name
+ */
+type TomlTables = Record<string, Record<string, string>>;
+
+/**
+ * A crate of the project, read from its Cargo.toml
+ */
+type Crate = {
+	name?: string;
+	// Directory of the crate relative to the root, "." for the root crate
+	directory: string;
+	edition?: string;
+	dependencies: string[];
+};
+
+/**
+ * Edition used by Cargo when Cargo.toml does not set one
+ */
+const defaultEdition = '2015';
+
+/**
+ * Maximum number of workspace members listed in a rule
+ */
+const maxListedCrates = 10;
+
+/**
+ * Scanner to detect Rust projects and Cargo workspaces
+ * This scanner extracts the edition, workspace members and well-known crates
+ */
+export class RustScanner extends BaseScanner {
+	public readonly name = 'rust';
+	public readonly watchedFiles = ['Cargo.toml', 'Cargo.lock'];
+
+	/**
+	 * Well-known crates and the rule to emit when they are used
+	 */
+	private readonly knownCrates: Record<string, string> = {
+		tokio:
+			'Use async Rust on the Tokio runtime. Do not block async tasks with blocking I/O or `std::thread::sleep`, use the async APIs of tokio instead.',
+		'async-std':
+			'Use async Rust on the async-std runtime. Do not block async tasks with blocking I/O.',
+		serde:
+			'Use serde derives (`#[derive(Serialize, Deserialize)]`) for serialization instead of implementing it by hand.',
+		axum: 'The web server is built with axum. Add endpoints as async handler functions registered on the axum `Router`.',
+		'actix-web':
+			'The web server is built with Actix Web. Add endpoints as async handlers registered on the Actix `App`.',
+		rocket:
+			'The web server is built with Rocket. Add endpoints with the Rocket route attributes (`#[get]`, `#[post]`).',
+		warp: 'The web server is built with warp. Add endpoints as warp filters.',
+		thiserror:
+			'Use thiserror (`#[derive(Error)]`) to define error types instead of implementing `std::error::Error` by hand.',
+		anyhow:
+			'Use anyhow (`anyhow::Result`) for error handling in application code.',
+		clap: 'Use clap to parse command line arguments.',
+		tracing:
+			'Use tracing (`tracing::info!`, `#[instrument]`) for logging instead of `println!`.',
+		sqlx: 'Use sqlx for database access.',
+		diesel: 'Use Diesel for database access.',
+	};
+
+	/**
+	 * Cargo commands every Rust project can run, by purpose
+	 */
+	private readonly cargoCommands: Record<string, CommandPurpose> = {
+		'cargo build': CommandPurpose.Build,
+		'cargo test': CommandPurpose.Test,
+		'cargo clippy': CommandPurpose.Lint,
+		'cargo fmt': CommandPurpose.Format,
+	};
+
+	/**
+	 * Scan the project to determine the Rust edition, workspace and crates
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for Rust configuration');
+
+		try {
+			const tables = await this.readCargoToml('.');
+
+			// If no Cargo.toml is found, don't return any recommendations
+			if (!tables || !this.hasEnoughEvidence([{file: 'Cargo.toml'}])) {
+				return [];
+			}
+
+			const recommendations: AiRule[] = [];
+			const isWorkspace = tables.workspace !== undefined;
+			const members = isWorkspace ? await this.readMembers(tables) : [];
+			const crates = [
+				...(tables.package ? [this.toCrate('.', tables, tables)] : []),
+				...members,
+			];
+
+			const editionRule = this.getEditionRule(crates, tables);
+			if (editionRule) {
+				recommendations.push(editionRule);
+			}
+
+			if (isWorkspace) {
+				recommendations.push(...this.getWorkspaceRules(members, tables));
+			}
+
+			const dependencies = new Set([
+				...crates.flatMap((crate) => crate.dependencies),
+				...this.getDependencies(tables, ['workspace.dependencies']),
+			]);
+			for (const [crate, rule] of Object.entries(this.knownCrates)) {
+				if (dependencies.has(crate)) {
+					recommendations.push({
+						category: Category.Rust,
+						rule,
+						files: ['Cargo.toml'],
+					});
+				}
+			}
+
+			if (existsSync(path.join(this.rootPath, 'Cargo.lock'))) {
+				recommendations.push({
+					category: Category.Rust,
+					rule: 'Add dependencies with `cargo add <crate>` and update them with `cargo update`. Do not edit Cargo.lock by hand.',
+					files: ['Cargo.lock'],
+				});
+			}
+
+			for (const [command, purpose] of Object.entries(this.cargoCommands)) {
+				recommendations.push({
+					category: Category.Commands,
+					rule: `Run \`${command}\`.`,
+					files: ['Cargo.toml'],
+					commands: [{command, purpose}],
+				});
+			}
+
+			return recommendations;
+		} catch (error) {
+			this.logger.error('Error scanning for Rust configuration', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the rule describing the Rust edition of the crates
+	 * Crates may inherit the edition of [workspace.package]
+	 */
+	private getEditionRule(
+		crates: Crate[],
+		workspaceTables: TomlTables,
+	): AiRule | undefined {
+		const workspaceEdition = workspaceTables['workspace.package']?.edition;
+		const editions = new Set(
+			crates.map((crate) => crate.edition ?? defaultEdition),
+		);
+
+		if (editions.size === 0 && workspaceEdition) {
+			editions.add(this.unquote(workspaceEdition));
+		}
+
+		if (editions.size === 0) {
+			return undefined;
+		}
+
+		if (editions.size === 1) {
+			const [edition] = editions;
+
+			return {
+				category: Category.Rust,
+				rule: `The project uses the Rust ${edition} edition. Only use syntax and language features available in this edition.`,
+				severity: Severity.High,
+				files: ['Cargo.toml'],
+			};
+		}
+
+		const crateEditions = crates
+			.map(
+				(crate) =>
+					`${crate.name ?? crate.directory} (${crate.edition ?? defaultEdition})`,
+			)
+			.join(', ');
+
+		return {
+			category: Category.Rust,
+			rule: `The crates use different Rust editions: ${crateEditions}. Check the edition of a crate before using newer syntax in it.`,
+			severity: Severity.High,
+			files: ['Cargo.toml'],
+		};
+	}
+
+	/**
+	 * Get the rules describing a Cargo workspace and its member crates
+	 */
+	private getWorkspaceRules(members: Crate[], tables: TomlTables): AiRule[] {
+		const rules: AiRule[] = [];
+
+		if (members.length > 0) {
+			const listedMembers = members
+				.slice(0, maxListedCrates)
+				.map((member) =>
+					member.name && member.name !== path.posix.basename(member.directory)
+						? `${member.name} (\`${member.directory}\`)`
+						: `\`${member.directory}\``,
+				)
+				.join(', ');
+			const more =
+				members.length > maxListedCrates
+					? ` and ${members.length - maxListedCrates} more crates`
+					: '';
+
+			rules.push({
+				category: Category.Rust,
+				rule: `This is a Cargo workspace with the member crates ${listedMembers}${more}. Add dependencies to the crate that uses them, and run cargo commands for a single crate with \`-p <crate>\`.`,
+				files: [
+					'Cargo.toml',
+					...members
+						.slice(0, maxListedCrates)
+						.map((member) => `${member.directory}/Cargo.toml`),
+				],
+			});
+		}
+
+		if (tables['workspace.dependencies']) {
+			rules.push({
+				category: Category.Rust,
+				rule: 'Shared dependency versions are declared in `[workspace.dependencies]` of the root Cargo.toml. Reference them from member crates with `{ workspace = true }`.',
+				files: ['Cargo.toml'],
+			});
+		}
+
+		return rules;
+	}
+
+	/**
+	 * Read the member crates of a workspace, expanding glob patterns
+	 */
+	private async readMembers(tables: TomlTables): Promise<Crate[]> {
+		const members = this.parseArray(tables.workspace.members);
+		const excludes = this.parseArray(tables.workspace.exclude);
+		if (members.length === 0) {
+			return [];
+		}
+
+		const includePatterns = members.map((member) =>
+			globToRegex(this.normalizeDirectory(member)),
+		);
+		const excludePatterns = excludes.map((exclude) =>
+			globToRegex(this.normalizeDirectory(exclude)),
+		);
+
+		const directories = (await this.fileIndex.getFiles())
+			.filter((file) => path.basename(file) === 'Cargo.toml')
+			.map((file) =>
+				path
+					.relative(this.rootPath, path.dirname(file))
+					.split(path.sep)
+					.join('/'),
+			)
+			.filter(
+				(directory) =>
+					directory &&
+					includePatterns.some((pattern) => pattern.test(directory)) &&
+					!excludePatterns.some((pattern) => pattern.test(directory)),
+			);
+
+		const crates = await Promise.all(
+			directories.map(async (directory) => {
+				const memberTables = await this.readCargoToml(directory);
+				return memberTables
+					? this.toCrate(directory, memberTables, tables)
+					: undefined;
+			}),
+		);
+
+		return crates.filter((crate) => crate !== undefined);
+	}
+
+	/**
+	 * Build a crate from its parsed Cargo.toml
+	 * @param directory Directory of the crate relative to the root
+	 * @param tables Tables of the Cargo.toml of the crate
+	 * @param workspaceTables Tables of the root Cargo.toml
+	 */
+	private toCrate(
+		directory: string,
+		tables: TomlTables,
+		workspaceTables: TomlTables,
+	): Crate {
+		const packageTable = tables.package ?? {};
+
+		// "edition.workspace = true" and "edition = { workspace = true }"
+		// inherit the edition of the workspace
+		const inheritsEdition =
+			packageTable['edition.workspace'] === 'true' ||
+			/^{\s*workspace\s*=\s*true\s*}$/.test(packageTable.edition ?? '');
+		const edition = inheritsEdition
+			? workspaceTables['workspace.package']?.edition
+			: packageTable.edition;
+
+		return {
+			name: packageTable.name ? this.unquote(packageTable.name) : undefined,
+			directory,
+			edition: edition ? this.unquote(edition) : undefined,
+			dependencies: this.getDependencies(tables, [
+				'dependencies',
+				'dev-dependencies',
+				'build-dependencies',
+			]),
+		};
+	}
+
+	/**
+	 * Get the crate names declared in dependency tables, including the
+	 * "[dependencies.<crate>]" form
+	 */
+	private getDependencies(tables: TomlTables, tableNames: string[]): string[] {
+		const dependencies: string[] = [];
+
+		for (const [tableName, table] of Object.entries(tables)) {
+			if (tableNames.includes(tableName)) {
+				dependencies.push(
+					...Object.keys(table).map((key) => key.split('.')[0]),
+				);
+				continue;
+			}
+
+			const tableDependency = tableNames
+				.map((name) => `${name}.`)
+				.find((prefix) => tableName.startsWith(prefix));
+			if (tableDependency) {
+				dependencies.push(tableName.slice(tableDependency.length));
+			}
+		}
+
+		return dependencies;
+	}
+
+	/**
+	 * Read and parse the Cargo.toml of a directory
+	 * @param directory Directory relative to the root
+	 */
+	private async readCargoToml(
+		directory: string,
+	): Promise<TomlTables | undefined> {
+		const cargoTomlPath = path.join(this.rootPath, directory, 'Cargo.toml');
+
+		if (!existsSync(cargoTomlPath)) {
+			return undefined;
+		}
+
+		try {
+			const content = await fs.readFile(cargoTomlPath, 'utf8');
+			return this.parseTomlTables(content);
+		} catch (error) {
+			this.logger.error(`Error reading ${cargoTomlPath}`, error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Parse the tables of a TOML file into raw key/value pairs
+	 * Only top-level keys of each table are read, which is all the scanner needs
+	 */
+	private parseTomlTables(content: string): TomlTables {
+		const tables: TomlTables = {};
+		let currentTable: Record<string, string> = {};
+		tables[''] = currentTable;
+		let pendingKey: string | undefined;
+
+		for (const line of content.split('\n')) {
+			const trimmedLine = line.replace(/\s+#.*$/, '').trim();
+
+			// Continuation of a multi-line array
+			if (pendingKey) {
+				currentTable[pendingKey] += ` ${trimmedLine}`;
+				if (trimmedLine.endsWith(']')) {
+					pendingKey = undefined;
+				}
+
+				continue;
+			}
+
+			if (!trimmedLine || trimmedLine.startsWith('#')) {
+				continue;
+			}
+
+			const tableMatch = /^\[([^[\]]+)]$/.exec(trimmedLine);
+			if (tableMatch) {
+				currentTable = {};
+				tables[tableMatch[1].trim()] = currentTable;
+				continue;
+			}
+
+			const keyMatch = /^([\w.-]+)\s*=\s*(.*)$/.exec(trimmedLine);
+			if (keyMatch) {
+				const [, key, value] = keyMatch;
+				currentTable[key] = value;
+				if (value.startsWith('[') && !value.endsWith(']')) {
+					pendingKey = key;
+				}
+			}
+		}
+
+		return tables;
+	}
+
+	/**
+	 * Parse the strings of a TOML array, e.g. ["crates/*", "cli"]
+	 */
+	private parseArray(value: string | undefined): string[] {
+		return [...(value ?? '').matchAll(/["']([^"']+)["']/g)].map(
+			(match) => match[1],
+		);
+	}
+
+	/**
+	 * Normalize a member path, e.g. "./crates/" to "crates"
+	 */
+	private normalizeDirectory(directory: string): string {
+		return directory.replace(/^\.\//, '').replace(/\/+$/, '');
+	}
+
+	/**
+	 * Remove the quotes around a TOML string value
+	 */
+	private unquote(value: string): string {
+		return value.replace(/^["']|["']$/g, '');
+	}
+}
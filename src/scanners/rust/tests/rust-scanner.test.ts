@@ -0,0 +1,110 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {RustScanner} from '../rust-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+describe('RustScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Editions', () => {
+		it('should default to the 2015 edition', async () => {
+			rootPath = await createFixture({
+				'Cargo.toml': '[package]\nname = "cli"\n\n[dependencies]\nclap = "4"\n',
+			});
+
+			const rules = await new RustScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toContain(
+				'The project uses the Rust 2015 edition. Only use syntax and language features available in this edition.',
+			);
+			expect(rules.map((rule) => rule.rule)).toContain(
+				'Use clap to parse command line arguments.',
+			);
+		});
+
+		it('should list the editions of crates that differ', async () => {
+			rootPath = await createFixture({
+				'Cargo.toml': '[workspace]\nmembers = ["a", "b"]\n',
+				'a/Cargo.toml': '[package]\nname = "a"\nedition = "2021"\n',
+				'b/Cargo.toml': '[package]\nname = "b"\nedition = "2018"\n',
+			});
+
+			const rules = await new RustScanner(rootPath).scan();
+
+			expect(rules[0].rule).toBe(
+				'The crates use different Rust editions: a (2021), b (2018). Check the edition of a crate before using newer syntax in it.',
+			);
+		});
+
+		it('should inherit the edition of the workspace', async () => {
+			rootPath = await createFixture({
+				'Cargo.toml':
+					'[workspace]\nmembers = ["a", "b"]\n\n[workspace.package]\nedition = "2021"\n',
+				'a/Cargo.toml':
+					'[package]\nname = "a"\nedition = { workspace = true }\n',
+				'b/Cargo.toml': '[package]\nname = "b"\nedition.workspace = true\n',
+			});
+
+			const rules = await new RustScanner(rootPath).scan();
+
+			expect(rules[0].rule).toBe(
+				'The project uses the Rust 2021 edition. Only use syntax and language features available in this edition.',
+			);
+		});
+	});
+
+	describe('Workspaces', () => {
+		it('should describe the members and the shared dependencies', async () => {
+			rootPath = await createFixture({
+				'Cargo.toml':
+					'[workspace]\nmembers = ["crates/*"]\n\n[workspace.dependencies]\nserde = "1"\n',
+				'Cargo.lock': '',
+				'crates/api/Cargo.toml':
+					'[package]\nname = "api"\nedition = "2021"\n\n[dependencies]\ntokio = { version = "1", features = ["full"] }\n',
+				'crates/core/Cargo.toml':
+					'[package]\nname = "core"\nedition = "2021"\n',
+			});
+
+			const rules = await new RustScanner(rootPath).scan();
+			const texts = rules.map((rule) => rule.rule);
+
+			expect(texts).toContain(
+				'This is a Cargo workspace with the member crates `crates/api`, `crates/core`. Add dependencies to the crate that uses them, and run cargo commands for a single crate with `-p <crate>`.',
+			);
+			expect(texts).toContain(
+				'Shared dependency versions are declared in `[workspace.dependencies]` of the root Cargo.toml. Reference them from member crates with `{ workspace = true }`.',
+			);
+			expect(texts).toContain(
+				'Use async Rust on the Tokio runtime. Do not block async tasks with blocking I/O or `std::thread::sleep`, use the async APIs of tokio instead.',
+			);
+			expect(texts).toContain(
+				'Add dependencies with `cargo add <crate>` and update them with `cargo update`. Do not edit Cargo.lock by hand.',
+			);
+		});
+	});
+
+	describe('Commands', () => {
+		it('should list the cargo commands', async () => {
+			rootPath = await createFixture({
+				'Cargo.toml': '[package]\nname = "cli"\n',
+			});
+
+			const rules = await new RustScanner(rootPath).scan();
+
+			expect(
+				rules.flatMap((rule) =>
+					(rule.commands ?? []).map((command) => command.command),
+				),
+			).toEqual(['cargo build', 'cargo test', 'cargo clippy', 'cargo fmt']);
+		});
+
+		it('should not emit rules without Cargo.toml', async () => {
+			rootPath = await createFixture({'src/main.rs': 'fn main() {}\n'});
+
+			expect(await new RustScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
@@ -1 +1,2 @@
+export {StateManagementScanner} from './state-management-scanner.js';
 export {ZustandScanner} from './zustand-scanner.js';
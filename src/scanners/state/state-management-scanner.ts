@@ -0,0 +1,288 @@
+import path from 'node:path';
+import {Category, Confidence, Severity, type AiRule} from '../../types.js';
+import {BaseScanner} from '../base/base-scanner.js';
+import {readDependencies} from '../base/scanner-helpers.js';
+
+/**
+ * A state management library and how its state is used
+ */
+type StateLibrary = {
+	name: string;
+	// Packages of the library, any of them marks the library as used
+	packages: string[];
+	usage: string;
+};
+
+/**
+ * A state library detected in the project
+ */
+type DetectedLibrary = StateLibrary & {
+	files: string[];
+	// Detected from file names only, without a declared dependency
+	inferred?: boolean;
+};
+
+/**
+ * Libraries that hold client state, by precedence
+ * Redux Toolkit comes before Redux, as it depends on it
+ */
+const clientLibraries: StateLibrary[] = [
+	{
+		name: 'Redux Toolkit',
+		packages: ['@reduxjs/toolkit'],
+		usage:
+			'Define state and reducers with `createSlice()` and read it with `useSelector()` and `useDispatch()`, instead of hand-written action types and switch reducers.',
+	},
+	{
+		name: 'Redux',
+		packages: ['redux'],
+		usage:
+			'Change state by dispatching actions handled by reducers, and connect components with `useSelector()` and `useDispatch()` from react-redux.',
+	},
+	{
+		name: 'Zustand',
+		packages: ['zustand'],
+		usage:
+			'Create stores with `create()` and read them with the store hooks, instead of actions and reducers.',
+	},
+	{
+		name: 'Jotai',
+		packages: ['jotai'],
+		usage:
+			'Keep state in small atoms created with `atom()` and read them with `useAtom()`, instead of a central store.',
+	},
+	{
+		name: 'Recoil',
+		packages: ['recoil'],
+		usage:
+			'Keep state in atoms and selectors (`atom()`, `selector()`) and read them with `useRecoilState()` and `useRecoilValue()`.',
+	},
+	{
+		name: 'MobX',
+		packages: ['mobx'],
+		usage:
+			'Keep state in observable stores created with `makeAutoObservable()`, and wrap components that read them in `observer()`.',
+	},
+	{
+		name: 'Pinia',
+		packages: ['pinia'],
+		usage:
+			'Define stores with `defineStore()` and use them through their `use...Store()` composables, instead of Vuex modules.',
+	},
+];
+
+/**
+ * Libraries that fetch and cache server state
+ */
+const serverLibraries: StateLibrary[] = [
+	{
+		name: 'TanStack Query',
+		packages: [
+			'@tanstack/react-query',
+			'@tanstack/vue-query',
+			'@tanstack/svelte-query',
+			'react-query',
+		],
+		usage:
+			'Fetch and cache server data with `useQuery()`, change it with `useMutation()` and invalidate the affected queries afterwards.',
+	},
+	{
+		name: 'SWR',
+		packages: ['swr'],
+		usage:
+			'Fetch and cache server data with `useSWR()` and revalidate it with `mutate()` after changing it.',
+	},
+];
+
+/**
+ * Matches Redux Toolkit slice files, e.g. userSlice.ts or cart.slice.ts
+ */
+const sliceFilePattern = /^\w+(?:Slice|[.-]slice)\.[cm]?[jt]sx?$/;
+
+/**
+ * Names of the directories that conventionally hold stores
+ */
+const storeDirectoryNames = new Set(['store', 'stores']);
+
+/**
+ * Scanner to detect state management libraries (Redux, Redux Toolkit,
+ * Zustand, Jotai, Recoil, MobX, Pinia) and server state libraries (TanStack
+ * Query, SWR), so rules do not mix their paradigms
+ */
+export class StateManagementScanner extends BaseScanner {
+	public readonly name = 'state-management';
+	public readonly watchedFiles = ['package.json'];
+
+	/**
+	 * Scan the project to determine which state libraries are used
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for state management libraries');
+
+		try {
+			const dependencies = await readDependencies(this.rootPath);
+			const files = (await this.fileIndex.getFiles()).map((file) =>
+				path.relative(this.rootPath, file).split(path.sep).join('/'),
+			);
+
+			const clientState = this.detectLibraries(clientLibraries, dependencies);
+			const serverState = this.detectLibraries(serverLibraries, dependencies);
+
+			// Redux Toolkit slices without a declared dependency, e.g. in a
+			// package that gets it from the workspace root
+			const sliceFiles = files.filter((file) =>
+				sliceFilePattern.test(path.posix.basename(file)),
+			);
+			if (clientState.length === 0 && sliceFiles.length > 0) {
+				clientState.push({
+					...clientLibraries[0],
+					files: sliceFiles.slice(0, 5),
+					inferred: true,
+				});
+			}
+
+			const recommendations: AiRule[] = [];
+			const storeDirectory = this.findStoreDirectory(files);
+
+			for (const library of clientState) {
+				recommendations.push(
+					this.getClientStateRule(
+						library,
+						clientState,
+						serverState,
+						storeDirectory,
+					),
+				);
+			}
+
+			for (const library of serverState) {
+				recommendations.push(this.getServerStateRule(library, clientState));
+			}
+
+			return this.hasEnoughEvidence(this.getRuleEvidence(recommendations))
+				? recommendations
+				: [];
+		} catch (error) {
+			this.logger.error('Error scanning for state management libraries', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the rule for a client state library
+	 * @param library Library of the rule
+	 * @param clientState All client state libraries of the project
+	 * @param serverState Server state libraries of the project
+	 * @param storeDirectory Directory holding the stores, if any
+	 */
+	private getClientStateRule(
+		library: DetectedLibrary,
+		clientState: DetectedLibrary[],
+		serverState: DetectedLibrary[],
+		storeDirectory: string | undefined,
+	): AiRule {
+		const sentences = [
+			`Client state is managed with ${library.name}.`,
+			library.usage,
+		];
+
+		// Stores can only be attributed to a library when there is one
+		if (storeDirectory && clientState.length === 1) {
+			sentences.push(`Stores live in \`${storeDirectory}/\`.`);
+		}
+
+		if (serverState.length > 0) {
+			sentences.push(
+				`Only keep client-side state such as UI state in it, server data is cached by ${this.joinNames(serverState)}.`,
+			);
+		}
+
+		const otherLibraries = clientState.filter((other) => other !== library);
+		sentences.push(
+			otherLibraries.length === 0
+				? 'Do not introduce another state management library or its patterns.'
+				: `The project also uses ${this.joinNames(otherLibraries)}, follow the library already used by the code you change.`,
+		);
+
+		return {
+			category: Category.StateManagement,
+			rule: sentences.join(' '),
+			severity: Severity.High,
+			...(library.inferred ? {confidence: Confidence.Medium} : {}),
+			files: library.files,
+		};
+	}
+
+	/**
+	 * Get the rule for a server state library
+	 * @param library Library of the rule
+	 * @param clientState Client state libraries of the project
+	 */
+	private getServerStateRule(
+		library: DetectedLibrary,
+		clientState: DetectedLibrary[],
+	): AiRule {
+		const split =
+			clientState.length > 0
+				? ` Do not copy server responses into ${this.joinNames(clientState)} state.`
+				: '';
+
+		return {
+			category: Category.StateManagement,
+			rule: `Server state is managed with ${library.name}. ${library.usage}${split}`,
+			severity: Severity.High,
+			files: library.files,
+		};
+	}
+
+	/**
+	 * Detect the libraries whose packages are dependencies of the project
+	 */
+	private detectLibraries(
+		libraries: StateLibrary[],
+		dependencies: string[],
+	): DetectedLibrary[] {
+		const detected: DetectedLibrary[] = [];
+
+		for (const library of libraries) {
+			if (library.packages.some((name) => dependencies.includes(name))) {
+				detected.push({...library, files: ['package.json']});
+			}
+		}
+
+		// Redux is a dependency of Redux Toolkit and only listed alongside it
+		// in older setups
+		return detected.some((library) => library.name === 'Redux Toolkit')
+			? detected.filter((library) => library.name !== 'Redux')
+			: detected;
+	}
+
+	/**
+	 * Find the outermost store or stores directory of the project
+	 */
+	private findStoreDirectory(files: string[]): string | undefined {
+		const directories = files.flatMap((file) => {
+			const segments = file.split('/').slice(0, -1);
+			const index = segments.findIndex((segment) =>
+				storeDirectoryNames.has(segment),
+			);
+			return index === -1 ? [] : [segments.slice(0, index + 1).join('/')];
+		});
+
+		return directories.sort(
+			(first, second) =>
+				first.split('/').length - second.split('/').length ||
+				first.localeCompare(second),
+		)[0];
+	}
+
+	/**
+	 * Join library names, e.g. "TanStack Query and SWR"
+	 */
+	private joinNames(libraries: DetectedLibrary[]): string {
+		const names = libraries.map((library) => library.name);
+		return names.length > 1
+			? `${names.slice(0, -1).join(', ')} and ${names.at(-1)}`
+			: names[0];
+	}
+}
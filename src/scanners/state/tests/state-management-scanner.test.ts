@@ -0,0 +1,54 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {StateManagementScanner} from '../state-management-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+import {Confidence} from '../../../types.js';
+
+describe('StateManagementScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Client and server state', () => {
+		it('should split client state from server state', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					dependencies: {
+						'@reduxjs/toolkit': '^2.0.0',
+						redux: '^5.0.0',
+						'@tanstack/react-query': '^5.0.0',
+					},
+				}),
+				'src/store/index.ts': 'export {};\n',
+			});
+
+			const rules = await new StateManagementScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Client state is managed with Redux Toolkit. Define state and reducers with `createSlice()` and read it with `useSelector()` and `useDispatch()`, instead of hand-written action types and switch reducers. Stores live in `src/store/`. Only keep client-side state such as UI state in it, server data is cached by TanStack Query. Do not introduce another state management library or its patterns.',
+				'Server state is managed with TanStack Query. Fetch and cache server data with `useQuery()`, change it with `useMutation()` and invalidate the affected queries afterwards. Do not copy server responses into Redux Toolkit state.',
+			]);
+		});
+	});
+
+	describe('Slices', () => {
+		it('should infer Redux Toolkit from slice files', async () => {
+			rootPath = await createFixture({'src/features/userSlice.ts': ''});
+
+			const rules = await new StateManagementScanner(rootPath).scan();
+
+			expect(rules).toHaveLength(1);
+			expect(rules[0].confidence).toBe(Confidence.Medium);
+			expect(rules[0].files).toEqual(['src/features/userSlice.ts']);
+		});
+
+		it('should not emit rules without a state library', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {react: '^19.0.0'}}),
+			});
+
+			expect(await new StateManagementScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
@@ -1,13 +1,22 @@
 import {existsSync} from 'node:fs';
 import fs from 'node:fs/promises';
 import path from 'node:path';
-import {Category, type AiRule} from '../../types.js';
+import {Category, Confidence, type AiRule} from '../../types.js';
 import {BaseScanner} from '../base/base-scanner.js';
 
 /**
  * Scanner to detect Zustand store patterns and configurations in a project
  */
 export class ZustandScanner extends BaseScanner {
+	public readonly name = 'zustand';
+	public readonly watchedFiles = [
+		'package.json',
+		'*.js',
+		'*.jsx',
+		'*.ts',
+		'*.tsx',
+	];
+
 	/**
 	 * Common Zustand store file patterns
 	 */
@@ -30,7 +39,7 @@ export class ZustandScanner extends BaseScanner {
 			const hasDependency = await this.hasZustandDependency();
 
 			// If no Zustand dependency found, don't return any recommendations
-			if (!hasDependency) {
+			if (!hasDependency || !this.hasEnoughEvidence([{file: 'package.json'}])) {
 				return [];
 			}
 
@@ -111,7 +120,7 @@ export class ZustandScanner extends BaseScanner {
 			const fullPath = path.join(this.rootPath, commonPath);
 			if (existsSync(fullPath)) {
 				try {
-					const files = await this.getFilesRecursively(fullPath);
+					const files = await this.fileIndex.getFilesIn(fullPath);
 					return files.filter(
 						(file) => file.endsWith('.js') || file.endsWith('.ts'),
 					);
@@ -133,7 +142,7 @@ export class ZustandScanner extends BaseScanner {
 		const sourcePath = path.join(this.rootPath, 'src');
 		if (existsSync(sourcePath)) {
 			try {
-				const files = await this.getFilesRecursively(sourcePath);
+				const files = await this.fileIndex.getFilesIn(sourcePath);
 				const storeNamedFiles = files.filter(
 					(file) =>
 						(file.includes('store') || file.includes('Store')) &&
@@ -148,36 +157,6 @@ export class ZustandScanner extends BaseScanner {
 		return [...new Set(storeFiles)]; // Remove duplicates
 	}
 
-	/**
-	 * Get all files recursively from a directory
-	 */
-	private async getFilesRecursively(directoryPath: string): Promise<string[]> {
-		const files: string[] = [];
-
-		try {
-			const entries = await fs.readdir(directoryPath, {withFileTypes: true});
-
-			const entryPromises = entries.map(async (entry) => {
-				const fullPath = path.join(directoryPath, entry.name);
-
-				if (entry.isDirectory()) {
-					return this.getFilesRecursively(fullPath);
-				}
-
-				return [fullPath];
-			});
-
-			const entryResults = await Promise.all(entryPromises);
-			for (const entryFiles of entryResults) {
-				files.push(...entryFiles);
-			}
-		} catch (error) {
-			this.logger.error(`Error reading directory ${directoryPath}`, error);
-		}
-
-		return files;
-	}
-
 	/**
 	 * Analyze store files for patterns and best practices
 	 */
@@ -205,6 +184,8 @@ export class ZustandScanner extends BaseScanner {
 
 		const fileResults = await Promise.all(filePromises);
 
+		// Patterns are found by searching the store code for keywords, so the
+		// rules derived from them are not certain
 		for (const {file, content} of fileResults) {
 			if (!content) continue;
 
@@ -213,6 +194,7 @@ export class ZustandScanner extends BaseScanner {
 				recommendations.push({
 					category: Category.Zustand,
 					rule: 'Use descriptive names for your stores and organize them by domain or feature.',
+					confidence: Confidence.Medium,
 				});
 			}
 
@@ -254,6 +236,7 @@ export class ZustandScanner extends BaseScanner {
 				recommendations.push({
 					category: Category.Zustand,
 					rule: 'Use TypeScript interfaces to define your store state shape for better type safety.',
+					confidence: Confidence.Medium,
 				});
 			}
 
@@ -262,6 +245,7 @@ export class ZustandScanner extends BaseScanner {
 				recommendations.push({
 					category: Category.Zustand,
 					rule: 'Use Zustand subscriptions sparingly. Prefer React hooks (useStore) for component updates.',
+					confidence: Confidence.Medium,
 				});
 			}
 		}
@@ -287,6 +271,7 @@ export class ZustandScanner extends BaseScanner {
 			recommendations.push({
 				category: Category.Zustand,
 				rule: 'Using Immer middleware. Good for complex state updates, but consider performance impact for simple updates.',
+				confidence: Confidence.Medium,
 			});
 		}
 
@@ -294,6 +279,7 @@ export class ZustandScanner extends BaseScanner {
 			recommendations.push({
 				category: Category.Zustand,
 				rule: 'Using Redux DevTools integration. Excellent for debugging state changes in development.',
+				confidence: Confidence.Medium,
 			});
 		}
 
@@ -301,6 +287,7 @@ export class ZustandScanner extends BaseScanner {
 			recommendations.push({
 				category: Category.Zustand,
 				rule: 'Using persist middleware. Be mindful of what data you persist and handle migration strategies for schema changes.',
+				confidence: Confidence.Medium,
 			});
 		}
 
@@ -308,6 +295,7 @@ export class ZustandScanner extends BaseScanner {
 			recommendations.push({
 				category: Category.Zustand,
 				rule: 'Using store slicing pattern. Good for organizing large stores, but ensure slices are cohesive and well-defined.',
+				confidence: Confidence.Low,
 			});
 		}
 
@@ -315,6 +303,7 @@ export class ZustandScanner extends BaseScanner {
 			recommendations.push({
 				category: Category.Zustand,
 				rule: 'Handling async operations in stores. Consider loading states, error handling, and avoid race conditions.',
+				confidence: Confidence.Low,
 			});
 		}
 	}
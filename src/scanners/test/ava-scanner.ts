@@ -1,13 +1,16 @@
 import {existsSync} from 'node:fs';
 import fs from 'node:fs/promises';
 import path from 'node:path';
-import {Category, type AiRule} from '../../types.js';
+import {Category, type AiRule, type Evidence} from '../../types.js';
 import {BaseScanner} from '../base/base-scanner.js';
 
 /**
  * Scanner to detect AVA configuration in a project and generate helpful AI recommendations
  */
 export class AvaScanner extends BaseScanner {
+	public readonly name = 'ava';
+	public readonly watchedFiles = ['package.json', 'ava.config.*'];
+
 	/**
 	 * Common AVA configuration file names
 	 */
@@ -35,6 +38,14 @@ export class AvaScanner extends BaseScanner {
 				return [];
 			}
 
+			const evidence: Evidence[] = [
+				...(configFile ? [{file: configFile}] : []),
+				...(hasDependency ? [{file: 'package.json'}] : []),
+			];
+			if (!this.hasEnoughEvidence(evidence)) {
+				return [];
+			}
+
 			// Get config either from package.json or external config file
 			let config: Record<string, unknown> | undefined;
 
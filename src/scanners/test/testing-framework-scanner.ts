@@ -1,13 +1,36 @@
 import {existsSync} from 'node:fs';
 import fs from 'node:fs/promises';
 import path from 'node:path';
-import {Category, type AiRule} from '../../types.js';
+import {
+	Category,
+	Confidence,
+	Severity,
+	type AiRule,
+	type Evidence,
+} from '../../types.js';
 import {BaseScanner} from '../base/base-scanner.js';
 
 /**
  * Scanner to detect which testing framework is used in the project
  */
 export class TestingFrameworkScanner extends BaseScanner {
+	public readonly name = 'testing';
+	public readonly watchedFiles = [
+		'package.json',
+		'jest.*',
+		'.mocharc*',
+		'vitest.*',
+		'ava.config.*',
+		'jasmine.json',
+		'karma.conf.*',
+		'qunit.config.js',
+		'cypress.*',
+		'playwright.config.*',
+		'test-runner.js',
+		'*.test.*',
+		'*.spec.*',
+	];
+
 	// List of common testing frameworks to check for
 	private readonly testFrameworks = [
 		{
@@ -15,25 +38,42 @@ export class TestingFrameworkScanner extends BaseScanner {
 			configFiles: [
 				'jest.config.js',
 				'jest.config.ts',
+				'jest.config.mjs',
+				'jest.config.cjs',
 				'jest.config.json',
 				'jest.setup.js',
 				'jest.setup.ts',
 			],
+			packageJsonKey: 'jest',
 			scriptPattern: /jest/i,
 		},
 		{
 			name: 'mocha',
-			configFiles: ['.mocharc.js', '.mocharc.json', '.mocharc.yml'],
+			configFiles: [
+				'.mocharc.js',
+				'.mocharc.cjs',
+				'.mocharc.json',
+				'.mocharc.yml',
+				'.mocharc.yaml',
+			],
+			packageJsonKey: 'mocha',
 			scriptPattern: /mocha/i,
 		},
 		{
 			name: 'vitest',
-			configFiles: ['vitest.config.js', 'vitest.config.ts'],
+			configFiles: [
+				'vitest.config.js',
+				'vitest.config.ts',
+				'vitest.config.mjs',
+				'vitest.config.mts',
+				'vitest.workspace.ts',
+			],
 			scriptPattern: /vitest/i,
 		},
 		{
 			name: 'ava',
-			configFiles: ['ava.config.js', 'ava.config.cjs'],
+			configFiles: ['ava.config.js', 'ava.config.cjs', 'ava.config.mjs'],
+			packageJsonKey: 'ava',
 			scriptPattern: /ava/i,
 		},
 		{
@@ -55,12 +95,21 @@ export class TestingFrameworkScanner extends BaseScanner {
 		},
 		{
 			name: 'cypress',
-			configFiles: ['cypress.config.js', 'cypress.config.ts', 'cypress.json'],
+			configFiles: [
+				'cypress.config.js',
+				'cypress.config.ts',
+				'cypress.config.mjs',
+				'cypress.json',
+			],
 			scriptPattern: /cypress/i,
 		},
 		{
 			name: 'playwright',
-			configFiles: ['playwright.config.js', 'playwright.config.ts'],
+			configFiles: [
+				'playwright.config.js',
+				'playwright.config.ts',
+				'playwright.config.mjs',
+			],
 			scriptPattern: /playwright/i,
 		},
 		{name: 'puppeteer', configFiles: [], scriptPattern: /puppeteer/i},
@@ -74,6 +123,37 @@ export class TestingFrameworkScanner extends BaseScanner {
 		},
 	];
 
+	/**
+	 * Frameworks for unit and integration tests, in order of priority
+	 */
+	private readonly unitFrameworks = [
+		'jest',
+		'vitest',
+		'mocha',
+		'ava',
+		'jasmine',
+		'tape',
+		'qunit',
+		'node:test',
+	];
+
+	/**
+	 * Frameworks for end-to-end tests, in order of priority
+	 */
+	private readonly endToEndFrameworks = ['playwright', 'cypress', 'puppeteer'];
+
+	/**
+	 * Directories that usually hold end-to-end tests
+	 */
+	private readonly endToEndDirectories = [
+		'e2e',
+		'tests/e2e',
+		'test/e2e',
+		'cypress/e2e',
+		'cypress/integration',
+		'playwright',
+	];
+
 	/**
 	 * Scan the project to determine which testing framework is used
 	 */
@@ -82,19 +162,33 @@ export class TestingFrameworkScanner extends BaseScanner {
 
 		try {
 			const detectedFrameworks: string[] = [];
+			const evidence: Evidence[] = [];
 
 			// First check config files for each framework
 			const configFilesPromises = this.testFrameworks.map(async (framework) =>
-				this.hasConfigFiles(framework.name, framework.configFiles).then(
-					(hasConfig) => {
-						if (hasConfig) {
+				this.findConfigFile(framework.name, framework.configFiles).then(
+					(configFile) => {
+						if (configFile) {
 							detectedFrameworks.push(framework.name);
+							evidence.push({file: configFile});
 						}
 					},
 				),
 			);
 			await Promise.all(configFilesPromises);
 
+			// Some frameworks can be configured in package.json
+			for (const framework of this.testFrameworks) {
+				if (
+					framework.packageJsonKey &&
+					!detectedFrameworks.includes(framework.name) &&
+					// eslint-disable-next-line no-await-in-loop
+					(await this.hasPackageJsonKey(framework.packageJsonKey))
+				) {
+					detectedFrameworks.push(framework.name);
+				}
+			}
+
 			// Then check package.json for dependencies
 			const dependenciesPromises = this.testFrameworks.map(async (framework) =>
 				this.hasDependency(framework.name).then((hasDependency) => {
@@ -108,11 +202,26 @@ export class TestingFrameworkScanner extends BaseScanner {
 			// Check for test scripts in package.json
 			await this.checkTestScripts(detectedFrameworks);
 
-			// Check for framework-specific patterns in the project
-			await this.checkForTestingPatterns(detectedFrameworks);
+			// The frameworks without a config file were found in package.json
+			if (detectedFrameworks.length > evidence.length) {
+				evidence.push({file: 'package.json'});
+			}
+
+			if (!this.hasEnoughEvidence(evidence)) {
+				return [];
+			}
 
 			// Generate recommendations based on detected frameworks
-			return this.generateRecommendations(detectedFrameworks);
+			const recommendations = this.generateRecommendations(detectedFrameworks);
+
+			// Describe where tests are located based on the existing test files
+			if (detectedFrameworks.length > 0) {
+				recommendations.push(
+					...(await this.getTestLocationRules(detectedFrameworks)),
+				);
+			}
+
+			return recommendations;
 		} catch (error) {
 			this.logger.error('Error scanning for testing frameworks', error);
 			return [];
@@ -122,51 +231,204 @@ export class TestingFrameworkScanner extends BaseScanner {
 	/**
 	 * Check if any configuration files for a framework exist
 	 */
-	private async hasConfigFiles(
+	private async findConfigFile(
 		frameworkName: string,
 		configFiles: string[],
-	): Promise<boolean> {
-		if (configFiles.length === 0) {
-			return false;
-		}
-
+	): Promise<string | undefined> {
 		for (const configFile of configFiles) {
 			const configPath = path.join(this.rootPath, configFile);
 			if (existsSync(configPath)) {
 				this.logger.debug(`Found ${frameworkName} config file: ${configFile}`);
+				return configFile;
+			}
+		}
+
+		return undefined;
+	}
+
+	/**
+	 * Check if package.json contains a configuration key
+	 */
+	private async hasPackageJsonKey(key: string): Promise<boolean> {
+		const packageJsonPath = path.join(this.rootPath, 'package.json');
+		if (!existsSync(packageJsonPath)) {
+			return false;
+		}
+
+		try {
+			const packageJsonContent = await fs.readFile(packageJsonPath, 'utf8');
+			const packageJson = JSON.parse(packageJsonContent) as Record<
+				string,
+				unknown
+			>;
+
+			if (packageJson[key] && typeof packageJson[key] === 'object') {
+				this.logger.debug(`Found ${key} configuration in package.json`);
 				return true;
 			}
+		} catch (error) {
+			this.logger.error('Error reading package.json', error);
 		}
 
 		return false;
 	}
 
 	/**
-	 * Check for framework-specific patterns in the project files
+	 * Get rules describing where unit and end-to-end tests are located
 	 */
-	private async checkForTestingPatterns(
+	private async getTestLocationRules(
 		detectedFrameworks: string[],
-	): Promise<void> {
-		// Look for test directories
-		const testDirectories = ['test', 'tests', '__tests__', 'spec', 'specs'];
-		let hasTestDirectory = false;
-
-		for (const directory of testDirectories) {
-			const testDirectoryPath = path.join(this.rootPath, directory);
-			if (existsSync(testDirectoryPath)) {
-				hasTestDirectory = true;
-				break;
+	): Promise<AiRule[]> {
+		const rules: AiRule[] = [];
+		const endToEndDirectory = await this.getEndToEndDirectory();
+		const testFiles = (await this.fileIndex.getFiles())
+			.map((file) =>
+				path.relative(this.rootPath, file).split(path.sep).join('/'),
+			)
+			.filter(
+				(file) =>
+					!endToEndDirectory || !file.startsWith(`${endToEndDirectory}/`),
+			)
+			.filter((file) => this.isTestFile(file));
+
+		const unitTestLocation = this.getUnitTestingFramework(detectedFrameworks)
+			? this.getUnitTestLocation(testFiles)
+			: undefined;
+		if (unitTestLocation) {
+			// The location is inferred from where the existing tests are
+			rules.push({
+				category: Category.Testing,
+				rule: unitTestLocation,
+				confidence: Confidence.Medium,
+			});
+		}
+
+		const endToEndFramework = this.getEndToEndFramework(detectedFrameworks);
+		if (endToEndFramework && endToEndDirectory) {
+			rules.push({
+				category: Category.Testing,
+				rule: `Place ${endToEndFramework} end-to-end tests in the \`${endToEndDirectory}/\` directory.`,
+			});
+		}
+
+		return rules;
+	}
+
+	/**
+	 * Check if a file is a JavaScript or TypeScript test file
+	 */
+	private isTestFile(file: string): boolean {
+		if (!/\.[cm]?[jt]sx?$/.test(file)) {
+			return false;
+		}
+
+		return (
+			/\.(test|spec)\.[cm]?[jt]sx?$/.test(file) || file.includes('__tests__/')
+		);
+	}
+
+	/**
+	 * Describe how the observed unit test files are located and named
+	 */
+	private getUnitTestLocation(testFiles: string[]): string | undefined {
+		if (testFiles.length === 0) {
+			return undefined;
+		}
+
+		// Count how tests are organized, the most common layout wins
+		const layouts = {testsDirectories: 0, separateDirectory: 0, colocated: 0};
+		const separateDirectories = new Map<string, number>();
+		const suffixes = new Map<string, number>();
+
+		for (const file of testFiles) {
+			const topDirectory = file.split('/')[0];
+
+			if (file.includes('__tests__/')) {
+				layouts.testsDirectories++;
+			} else if (['test', 'tests', 'spec', 'specs'].includes(topDirectory)) {
+				layouts.separateDirectory++;
+				separateDirectories.set(
+					topDirectory,
+					(separateDirectories.get(topDirectory) ?? 0) + 1,
+				);
+			} else {
+				layouts.colocated++;
+			}
+
+			const suffix = /\.(?:test|spec)\.[cm]?[jt]sx?$/.exec(file)?.[0];
+			if (suffix) {
+				suffixes.set(suffix, (suffixes.get(suffix) ?? 0) + 1);
 			}
 		}
 
-		// If no testing frameworks detected but test directories exist,
-		// we might need to analyze file content to detect framework
-		if (detectedFrameworks.length === 0 && hasTestDirectory) {
-			this.logger.debug(
-				'Found test directories but no frameworks detected yet',
-			);
-			// Future enhancement: analyze test file content to detect framework
+		const suffix = this.getMostCommon(suffixes);
+		const naming = suffix ? `, named \`*${suffix}\`` : '';
+
+		if (
+			layouts.testsDirectories >= layouts.colocated &&
+			layouts.testsDirectories >= layouts.separateDirectory
+		) {
+			return `Place unit tests in \`__tests__\` directories next to the code they test${naming}.`;
+		}
+
+		if (layouts.separateDirectory >= layouts.colocated) {
+			const directory = this.getMostCommon(separateDirectories);
+			return `Place unit tests in the \`${directory}/\` directory${naming}.`;
 		}
+
+		return `Place unit tests next to the source files they test${naming}.`;
+	}
+
+	/**
+	 * Get the most common key of a counter map, the first one on ties
+	 */
+	private getMostCommon(counts: Map<string, number>): string | undefined {
+		let mostCommon: string | undefined;
+		let highestCount = 0;
+
+		for (const [key, count] of counts) {
+			if (count > highestCount) {
+				mostCommon = key;
+				highestCount = count;
+			}
+		}
+
+		return mostCommon;
+	}
+
+	/**
+	 * Get the directory holding end-to-end tests
+	 * The Playwright testDir setting takes precedence over the usual directories
+	 */
+	private async getEndToEndDirectory(): Promise<string | undefined> {
+		for (const configFile of [
+			'playwright.config.ts',
+			'playwright.config.js',
+			'playwright.config.mjs',
+		]) {
+			const configPath = path.join(this.rootPath, configFile);
+			if (!existsSync(configPath)) {
+				continue;
+			}
+
+			try {
+				// eslint-disable-next-line no-await-in-loop
+				const content = await fs.readFile(configPath, 'utf8');
+				const testDirectory = /testDir:\s*["'`]([^"'`]+)["'`]/.exec(
+					content,
+				)?.[1];
+
+				if (testDirectory) {
+					return testDirectory.replace(/^\.\//, '').replace(/\/+$/, '');
+				}
+			} catch (error) {
+				this.logger.error(`Error reading ${configFile}`, error);
+			}
+		}
+
+		return this.endToEndDirectories.find((directory) =>
+			existsSync(path.join(this.rootPath, directory)),
+		);
 	}
 
 	/**
@@ -301,22 +563,44 @@ export class TestingFrameworkScanner extends BaseScanner {
 		}
 
 		const result: AiRule[] = [];
-
-		// Main testing framework detection
-		const mainFramework = this.getMainTestingFramework(detectedFrameworks);
-		if (mainFramework) {
+		const unitFramework = this.getUnitTestingFramework(detectedFrameworks);
+		const endToEndFramework = this.getEndToEndFramework(detectedFrameworks);
+		const usedFrameworks = new Set<string>();
+
+		if (unitFramework && endToEndFramework) {
+			// Unit and end-to-end tests are written with different frameworks
+			result.push(
+				{
+					category: Category.Testing,
+					rule: `Use ${unitFramework} for unit tests.`,
+					severity: Severity.High,
+				},
+				{
+					category: Category.Testing,
+					rule: `Use ${endToEndFramework} for end-to-end tests.`,
+					severity: Severity.High,
+				},
+			);
+			usedFrameworks.add(unitFramework).add(endToEndFramework);
+		} else {
+			// Main testing framework detection
+			const mainFramework =
+				unitFramework ?? endToEndFramework ?? detectedFrameworks[0];
 			result.push({
 				category: Category.Testing,
 				rule: `Use ${mainFramework} testing framework.`,
+				severity: Severity.High,
 			});
+			usedFrameworks.add(mainFramework);
 		}
 
 		// Report all detected testing frameworks
 		for (const framework of detectedFrameworks) {
-			if (framework !== mainFramework) {
+			if (!usedFrameworks.has(framework)) {
 				result.push({
 					category: Category.Testing,
 					rule: `Detected testing tool: ${framework}`,
+					severity: Severity.Info,
 				});
 			}
 		}
@@ -325,28 +609,24 @@ export class TestingFrameworkScanner extends BaseScanner {
 	}
 
 	/**
-	 * Determine the main testing framework from the detected frameworks
+	 * Determine the unit testing framework from the detected frameworks
 	 */
-	private getMainTestingFramework(
+	private getUnitTestingFramework(
 		detectedFrameworks: string[],
 	): string | undefined {
-		// Priority order for main frameworks (unit/integration testing)
-		const mainFrameworkPriority = [
-			'jest',
-			'vitest',
-			'mocha',
-			'ava',
-			'jasmine',
-			'tape',
-			'qunit',
-		];
-
-		for (const framework of mainFrameworkPriority) {
-			if (detectedFrameworks.includes(framework)) {
-				return framework;
-			}
-		}
+		return this.unitFrameworks.find((framework) =>
+			detectedFrameworks.includes(framework),
+		);
+	}
 
-		return detectedFrameworks[0] || undefined;
+	/**
+	 * Determine the end-to-end testing framework from the detected frameworks
+	 */
+	private getEndToEndFramework(
+		detectedFrameworks: string[],
+	): string | undefined {
+		return this.endToEndFrameworks.find((framework) =>
+			detectedFrameworks.includes(framework),
+		);
 	}
 }
@@ -0,0 +1,58 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {TestingFrameworkScanner} from '../testing-framework-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+
+describe('TestingFrameworkScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Frameworks', () => {
+		it('should tell unit tests from end-to-end tests', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					devDependencies: {vitest: '^1.0.0', '@playwright/test': '^1.40.0'},
+					scripts: {test: 'vitest', e2e: 'playwright test'},
+				}),
+				'playwright.config.ts': 'export default {};\n',
+				'src/button.test.tsx': '',
+				'src/card.test.tsx': '',
+				'e2e/login.spec.ts': '',
+			});
+
+			const rules = await new TestingFrameworkScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Use vitest for unit tests.',
+				'Use playwright for end-to-end tests.',
+				'Place unit tests next to the source files they test, named `*.test.tsx`.',
+				'Place playwright end-to-end tests in the `e2e/` directory.',
+			]);
+		});
+
+		it('should find tests in __tests__ directories', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({devDependencies: {jest: '^29.0.0'}}),
+				'__tests__/cart.test.js': '',
+				'__tests__/user.test.js': '',
+			});
+
+			const rules = await new TestingFrameworkScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Use jest testing framework.',
+				'Place unit tests in `__tests__` directories next to the code they test, named `*.test.js`.',
+			]);
+		});
+
+		it('should not emit rules without a testing framework', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {react: '^19.0.0'}}),
+			});
+
+			expect(await new TestingFrameworkScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+});
@@ -0,0 +1,133 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {afterEach, describe, expect, it, vi} from 'vitest';
+import {CodebaseScanner} from '../codebase-scanner.js';
+import {DockerScanner} from '../devops/docker-scanner.js';
+import {createFixture, removeFixture} from './fixture.js';
+
+describe('CodebaseScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		vi.restoreAllMocks();
+		await removeFixture(rootPath);
+	});
+
+	describe('Cache', () => {
+		it('should reuse the rules of unchanged scanners', async () => {
+			rootPath = await createFixture({
+				Dockerfile: 'FROM node:22-alpine\nEXPOSE 3000\n',
+			});
+			await new CodebaseScanner(rootPath, {cache: true}).scan();
+
+			const scanner = new CodebaseScanner(rootPath, {cache: true});
+			await scanner.scan();
+			const report = scanner.getReport().find(({name}) => name === 'docker');
+
+			expect(report?.cached).toBe(true);
+			expect(report?.status).toBe('matched');
+		});
+
+		it('should not cache the rules of a failed scanner', async () => {
+			rootPath = await createFixture({
+				Dockerfile: 'FROM node:22-alpine\nEXPOSE 3000\n',
+			});
+			vi.spyOn(DockerScanner.prototype, 'scan').mockRejectedValueOnce(
+				new Error('Scan failed'),
+			);
+			await new CodebaseScanner(rootPath, {cache: true}).scan();
+
+			const scanner = new CodebaseScanner(rootPath, {cache: true});
+			const rules = await scanner.scan();
+			const report = scanner.getReport().find(({name}) => name === 'docker');
+
+			expect(report?.cached).toBe(false);
+			expect(report?.status).toBe('matched');
+			expect(rules.some((rule) => rule.scanner === 'docker')).toBe(true);
+		});
+
+		it('should rescan the styling after an import changes', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					dependencies: {
+						'@emotion/styled': '^11.0.0',
+						'styled-components': '^6.0.0',
+					},
+				}),
+				'src/Button.tsx': "import styled from 'styled-components';\n",
+			});
+			await new CodebaseScanner(rootPath, {cache: true}).scan();
+			await fs.writeFile(
+				path.join(rootPath, 'src/Button.tsx'),
+				"import styled from '@emotion/styled';\n",
+			);
+
+			const scanner = new CodebaseScanner(rootPath, {cache: true});
+			await scanner.scan();
+			const report = scanner.getReport().find(({name}) => name === 'styling');
+
+			expect(report?.cached).toBe(false);
+		});
+
+		it('should rescan React after another version is installed', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({dependencies: {react: '*'}}),
+				'node_modules/react/package.json': '{"version": "18.3.1"}',
+			});
+			await new CodebaseScanner(rootPath, {cache: true}).scan();
+			await fs.writeFile(
+				path.join(rootPath, 'node_modules/react/package.json'),
+				'{"version": "19.1.10"}',
+			);
+
+			const scanner = new CodebaseScanner(rootPath, {cache: true});
+			const rules = await scanner.scan();
+			const report = scanner.getReport().find(({name}) => name === 'react');
+
+			expect(report?.cached).toBe(false);
+			expect(
+				rules.find((rule) => rule.scanner === 'react')?.values?.version,
+			).toBe('19');
+		});
+	});
+
+	describe('Conflicts', () => {
+		it('should mark the lock files of different package managers', async () => {
+			rootPath = await createFixture({
+				'package.json': '{}',
+				'package-lock.json': '{}',
+				'pnpm-lock.yaml': "lockfileVersion: '9.0'\n",
+			});
+
+			const rules = await new CodebaseScanner(rootPath).scan();
+			const conflictingRules = rules.filter(
+				(rule) => rule.conflictGroup === 'package-manager',
+			);
+
+			expect(conflictingRules.map((rule) => rule.files)).toEqual([
+				['pnpm-lock.yaml'],
+				['package-lock.json'],
+			]);
+		});
+
+		it('should describe the conflict between Biome and Prettier once', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					devDependencies: {'@biomejs/biome': '^1.9.0', prettier: '^3.0.0'},
+				}),
+				'biome.json': '{}',
+				'.prettierrc': '{"semi": false}',
+			});
+
+			const rules = await new CodebaseScanner(rootPath).scan();
+			const texts = rules.map((rule) => rule.rule);
+
+			expect(texts).toContain(
+				'Both Biome and Prettier are configured and will likely format files differently. Check which formatter owns a file before formatting it, and do not add Prettier to files formatted by Biome.',
+			);
+			expect(
+				texts.some((text) => text.includes('more than one formatter')),
+			).toBe(false);
+		});
+	});
+});
@@ -0,0 +1,34 @@
+import fs from 'node:fs/promises';
+import os from 'node:os';
+import path from 'node:path';
+
+/**
+ * Files of a test project by path relative to its root
+ */
+export type FixtureFiles = Record<string, string>;
+
+/**
+ * Create a temporary project with the given files
+ * @param files Contents of the files by path relative to the root
+ * @returns Absolute path of the project root
+ */
+export async function createFixture(files: FixtureFiles): Promise<string> {
+	const rootPath = await fs.mkdtemp(path.join(os.tmpdir(), 'psst-fixture-'));
+
+	for (const [file, content] of Object.entries(files)) {
+		const filePath = path.join(rootPath, file);
+		// eslint-disable-next-line no-await-in-loop
+		await fs.mkdir(path.dirname(filePath), {recursive: true});
+		// eslint-disable-next-line no-await-in-loop
+		await fs.writeFile(filePath, content);
+	}
+
+	return rootPath;
+}
+
+/**
+ * Delete a temporary project created by createFixture
+ */
+export async function removeFixture(rootPath: string): Promise<void> {
+	await fs.rm(rootPath, {recursive: true, force: true});
+}
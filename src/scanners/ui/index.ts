@@ -1 +1,2 @@
 export {TailwindScanner} from './tailwind-scanner.js';
+export {StylingScanner} from './styling-scanner.js';
@@ -0,0 +1,231 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Category, Severity, type AiRule} from '../../types.js';
+import {
+	getDefaultConcurrency,
+	mapWithConcurrency,
+} from '../../utils/concurrency.js';
+import {BaseScanner} from '../base/base-scanner.js';
+import {
+	readDependencies,
+	sourceFileGlobs,
+	sourceFilePattern,
+} from '../base/scanner-helpers.js';
+
+/**
+ * A styling approach and how to recognize it
+ */
+type StylingApproach = {
+	name: string;
+	// Packages of the approach, any of them marks it as used
+	packages: string[];
+	// Matches the names of the files of the approach, e.g. *.module.css
+	filePattern?: RegExp;
+	// Matches imports of the approach in source files
+	importPattern?: RegExp;
+	usage: string;
+};
+
+/**
+ * A styling approach detected in the project
+ */
+type DetectedApproach = StylingApproach & {
+	files: string[];
+	hasDependency: boolean;
+};
+
+/**
+ * Styling approaches besides Tailwind CSS, which has its own scanner
+ */
+const stylingApproaches: StylingApproach[] = [
+	{
+		name: 'styled-components',
+		packages: ['styled-components'],
+		importPattern: /from\s+["']styled-components["']/,
+		usage:
+			'Keep the styles of a component next to it as styled components (`styled.div`), and read colors and spacing from the `ThemeProvider` theme instead of inline `style` props or separate CSS files.',
+	},
+	{
+		name: 'Emotion',
+		packages: ['@emotion/react', '@emotion/styled', '@emotion/css'],
+		importPattern: /from\s+["']@emotion\/(?:react|styled|css)["']/,
+		usage:
+			'Style components with the `css` prop or `styled` from `@emotion/styled`, and read colors and spacing from the theme instead of inline `style` props or separate CSS files.',
+	},
+	{
+		name: 'CSS Modules',
+		packages: [],
+		filePattern: /\.module\.(?:css|scss|sass|less)$/,
+		usage:
+			'Put the styles of a component in a `*.module.css` file next to it, import them as `styles` and apply them with `className={styles.name}` instead of global class names or inline styles.',
+	},
+	{
+		name: 'Sass',
+		packages: ['sass', 'sass-embedded', 'node-sass'],
+		filePattern: /\.s[ac]ss$/,
+		usage:
+			'Write styles in `.scss` files and reuse the existing variables and mixins instead of hard-coded colors and sizes.',
+	},
+	{
+		name: 'vanilla-extract',
+		packages: ['@vanilla-extract/css'],
+		filePattern: /\.css\.[jt]s$/,
+		usage:
+			'Define styles in `*.css.ts` files with `style()` from `@vanilla-extract/css` and apply the exported class names. Styles are compiled at build time, so pass runtime values through CSS variables.',
+	},
+];
+
+/**
+ * Scanner to detect how components are styled (styled-components, Emotion,
+ * CSS Modules, Sass, vanilla-extract), so agents don't mix approaches
+ */
+export class StylingScanner extends BaseScanner {
+	public readonly name = 'styling';
+	public readonly watchedFiles = [
+		'package.json',
+		'*.module.css',
+		'*.module.scss',
+		'*.module.sass',
+		'*.module.less',
+		'*.scss',
+		'*.sass',
+		// Source files are read to find the CSS-in-JS and vanilla-extract imports
+		...sourceFileGlobs,
+	];
+
+	/**
+	 * Scan the project to determine which styling approaches are used
+	 * When several approaches coexist, the one used by the most files is the
+	 * primary one. The rules leave out the file counts, so they don't change
+	 * with every file added.
+	 */
+	public async scan(): Promise<AiRule[]> {
+		this.logger.debug('Scanning for styling approaches');
+
+		try {
+			const dependencies = await readDependencies(this.rootPath);
+			const files = (await this.fileIndex.getFiles()).map((file) =>
+				path.relative(this.rootPath, file).split(path.sep).join('/'),
+			);
+
+			const detected = (
+				await this.detectApproaches(files, dependencies)
+			).filter((approach) =>
+				this.hasEnoughEvidence(
+					[
+						...(approach.hasDependency ? ['package.json'] : []),
+						...approach.files,
+					].map((file) => ({file})),
+				),
+			);
+			if (detected.length === 0) {
+				return [];
+			}
+
+			// Sorting is stable, so approaches used by as many files keep the
+			// order of stylingApproaches
+			const [primary, ...others] = [...detected].sort(
+				(first, second) => second.files.length - first.files.length,
+			);
+
+			return detected.map((approach) =>
+				this.getApproachRule(approach, primary, others),
+			);
+		} catch (error) {
+			this.logger.error('Error scanning for styling approaches', error);
+			return [];
+		}
+	}
+
+	/**
+	 * Get the rule of a styling approach
+	 * @param approach Approach of the rule
+	 * @param primary Approach used by the most files
+	 * @param others The other detected approaches
+	 */
+	private getApproachRule(
+		approach: DetectedApproach,
+		primary: DetectedApproach,
+		others: DetectedApproach[],
+	): AiRule {
+		const sentences = [
+			approach === primary
+				? `Components are styled with ${approach.name}.`
+				: `Some components are styled with ${approach.name}.`,
+			approach.usage,
+		];
+
+		if (others.length === 0) {
+			sentences.push('Do not mix in other styling approaches.');
+		} else if (approach === primary) {
+			sentences.push(
+				'It is the primary styling approach, used by the most files, so use it for new components.',
+			);
+		} else {
+			sentences.push(
+				`Follow it when changing files that already use it, and use ${primary.name} for new components.`,
+			);
+		}
+
+		return {
+			category: Category.Styling,
+			rule: sentences.join(' '),
+			...(approach === primary ? {severity: Severity.High} : {}),
+			files: [
+				...(approach.hasDependency ? ['package.json'] : []),
+				...approach.files.slice(0, 5),
+			],
+		};
+	}
+
+	/**
+	 * Detect the approaches used by the project and the files using them
+	 * Source files are only read when a CSS-in-JS library is a dependency
+	 */
+	private async detectApproaches(
+		files: string[],
+		dependencies: string[],
+	): Promise<DetectedApproach[]> {
+		const importApproaches = stylingApproaches.filter(
+			(approach) =>
+				approach.importPattern &&
+				approach.packages.some((name) => dependencies.includes(name)),
+		);
+		const sourceFiles =
+			importApproaches.length > 0
+				? files.filter(
+						(file) => sourceFilePattern.test(file) && !file.endsWith('.d.ts'),
+					)
+				: [];
+		const contents = await mapWithConcurrency(
+			sourceFiles,
+			getDefaultConcurrency(),
+			async (file) =>
+				fs.readFile(path.join(this.rootPath, file), 'utf8').catch(() => ''),
+		);
+
+		const detected: DetectedApproach[] = [];
+
+		for (const approach of stylingApproaches) {
+			const hasDependency = approach.packages.some((name) =>
+				dependencies.includes(name),
+			);
+			const approachFiles = [
+				...(approach.filePattern
+					? files.filter((file) => approach.filePattern?.test(file))
+					: []),
+				...(importApproaches.includes(approach)
+					? sourceFiles.filter((_file, index) =>
+							approach.importPattern?.test(contents[index]),
+						)
+					: []),
+			];
+
+			if (hasDependency || approachFiles.length > 0) {
+				detected.push({...approach, files: approachFiles, hasDependency});
+			}
+		}
+
+		return detected;
+	}
+}
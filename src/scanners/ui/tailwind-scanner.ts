@@ -1,13 +1,16 @@
 import {existsSync} from 'node:fs';
 import fs from 'node:fs/promises';
 import path from 'node:path';
-import {Category, type AiRule} from '../../types.js';
+import {Category, type AiRule, type Evidence} from '../../types.js';
 import {BaseScanner} from '../base/base-scanner.js';
 
 /**
  * Scanner to detect Tailwind CSS configuration and usage patterns in a project
  */
 export class TailwindScanner extends BaseScanner {
+	public readonly name = 'tailwind';
+	public readonly watchedFiles = ['package.json', 'tailwind.config.*'];
+
 	/**
 	 * Common Tailwind CSS configuration file names
 	 */
@@ -36,6 +39,14 @@ export class TailwindScanner extends BaseScanner {
 				return [];
 			}
 
+			const evidence: Evidence[] = [
+				...(configFile ? [{file: configFile}] : []),
+				...(hasDependency ? [{file: 'package.json'}] : []),
+			];
+			if (!this.hasEnoughEvidence(evidence)) {
+				return [];
+			}
+
 			// Initialize recommendations array
 			const recommendations: AiRule[] = [];
 
@@ -0,0 +1,59 @@
+import {afterEach, describe, expect, it} from 'vitest';
+import {StylingScanner} from '../styling-scanner.js';
+import {createFixture, removeFixture} from '../../tests/fixture.js';
+import {Severity} from '../../../types.js';
+
+describe('StylingScanner', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Single approach', () => {
+		it('should not mix in other styling approaches', async () => {
+			rootPath = await createFixture({
+				'src/Button.module.css': '.root {}\n',
+				'src/Card.module.css': '.root {}\n',
+			});
+
+			const rules = await new StylingScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Components are styled with CSS Modules. Put the styles of a component in a `*.module.css` file next to it, import them as `styles` and apply them with `className={styles.name}` instead of global class names or inline styles. Do not mix in other styling approaches.',
+			]);
+		});
+
+		it('should not emit rules for plain CSS', async () => {
+			rootPath = await createFixture({'src/index.css': 'body {}\n'});
+
+			expect(await new StylingScanner(rootPath).scan()).toEqual([]);
+		});
+	});
+
+	describe('Several approaches', () => {
+		it('should make the approach used by the most files primary', async () => {
+			rootPath = await createFixture({
+				'package.json': JSON.stringify({
+					dependencies: {'styled-components': '^6.0.0'},
+				}),
+				'src/Button.tsx': "import styled from 'styled-components';\n",
+				'src/Card.tsx': "import styled from 'styled-components';\n",
+				'src/Legacy.module.css': '.root {}\n',
+			});
+
+			const rules = await new StylingScanner(rootPath).scan();
+
+			expect(rules.map((rule) => rule.severity)).toEqual([
+				Severity.High,
+				undefined,
+			]);
+			expect(rules[0].rule).toMatch(
+				'It is the primary styling approach, used by the most files, so use it for new components.',
+			);
+			expect(rules[1].rule).toMatch(
+				'Follow it when changing files that already use it, and use styled-components for new components.',
+			);
+		});
+	});
+});
@@ -0,0 +1,81 @@
+import {existsSync} from 'node:fs';
+import path from 'node:path';
+import {pathToFileURL} from 'node:url';
+import {type PsstConfig, psstConfigSchema} from '../types/config.js';
+import {logger} from './logger.js';
+
+const serviceLogger = logger.getLogger('ConfigLoader');
+
+/**
+ * Config file names looked up in the scanned directory, in order
+ */
+export const configFileNames = [
+	'psst.config.js',
+	'psst.config.mjs',
+	'psst.config.cjs',
+	'psst.config.ts',
+	'psst.config.mts',
+];
+
+/**
+ * Find the config file of a project
+ * @param rootPath Directory to look in
+ * @returns Absolute path of the config file, undefined if there is none
+ */
+export function findConfigFile(rootPath: string): string | undefined {
+	return configFileNames
+		.map((fileName) => path.join(rootPath, fileName))
+		.find((filePath) => existsSync(filePath));
+}
+
+/**
+ * Load and validate the config file of a project
+ * TypeScript config files require a Node.js version that can import
+ * TypeScript files (22.18 or newer)
+ * @param rootPath Directory to look for a config file in
+ * @param configPath Explicit path of the config file, which must exist
+ * @returns The config, undefined if the project has no config file
+ */
+export async function loadConfig(
+	rootPath: string,
+	configPath?: string,
+): Promise<PsstConfig | undefined> {
+	const configFile = configPath
+		? path.resolve(configPath)
+		: findConfigFile(rootPath);
+
+	if (!configFile) {
+		return undefined;
+	}
+
+	if (!existsSync(configFile)) {
+		throw new Error(`Config file not found: ${configFile}`);
+	}
+
+	serviceLogger.debug(`Loading config from ${configFile}`);
+
+	// The query makes sure a changed file is imported again in watch mode
+	const url = pathToFileURL(configFile);
+	url.searchParams.set('t', String(Date.now()));
+	const module = (await import(url.href)) as {default?: unknown};
+
+	return validateConfig(module.default ?? module, `file ${configFile}`);
+}
+
+/**
+ * Validate a config against the config schema
+ * @param config Config to validate
+ * @param source Where the config comes from, used in the error message
+ * @returns The validated config
+ */
+export function validateConfig(config: unknown, source: string): PsstConfig {
+	const result = psstConfigSchema.safeParse(config);
+	if (!result.success) {
+		const issues = result.error.issues
+			.map((issue) => `${issue.path.join('.')}: ${issue.message}`)
+			.join(', ');
+		throw new Error(`Invalid config ${source}: ${issues}`);
+	}
+
+	return result.data as PsstConfig;
+}
@@ -0,0 +1,233 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {logger} from './logger.js';
+
+const serviceLogger = logger.getLogger('DependencyResolver');
+
+/**
+ * Fields of package.json that declare dependencies
+ */
+const dependencyFields = [
+	'dependencies',
+	'devDependencies',
+	'peerDependencies',
+	'optionalDependencies',
+] as const;
+
+/**
+ * Escape a string for use in a regular expression
+ */
+function escapeRegex(value: string): string {
+	return value.replaceAll(/[.*+?^${}()|[\]\\/]/g, '\\$&');
+}
+
+/**
+ * Get the path of the package.json of an installed dependency
+ * @param dependencyName Name of the npm package
+ * @returns The path relative to the project root
+ */
+export function getInstalledPackageJsonPath(dependencyName: string): string {
+	return path.join('node_modules', dependencyName, 'package.json');
+}
+
+/**
+ * Resolves the versions of npm dependencies used by a project
+ * The installed or locked version is preferred over the declared range
+ */
+export class DependencyResolver {
+	private readonly fileContents = new Map<
+		string,
+		Promise<string | undefined>
+	>();
+
+	/**
+	 * Constructor for DependencyResolver
+	 * @param rootPath Root directory of the project
+	 */
+	constructor(private readonly rootPath: string) {}
+
+	/**
+	 * Resolve the version of a dependency
+	 * @param dependencyName Name of the npm package
+	 * @returns The resolved version, the declared range if no lock information
+	 * is available, or undefined if the package is not a dependency
+	 */
+	public async resolveVersion(
+		dependencyName: string,
+	): Promise<string | undefined> {
+		// Only direct dependencies are resolved
+		const declaredVersion = await this.getDeclaredVersion(dependencyName);
+		if (!declaredVersion) {
+			return undefined;
+		}
+
+		return (
+			(await this.getInstalledVersion(dependencyName)) ??
+			(await this.getPackageLockVersion(dependencyName)) ??
+			(await this.getPnpmLockVersion(dependencyName)) ??
+			(await this.getYarnLockVersion(dependencyName)) ??
+			(await this.getBunLockVersion(dependencyName)) ??
+			declaredVersion
+		);
+	}
+
+	/**
+	 * Read a project file once, undefined if it does not exist
+	 */
+	private async readFile(fileName: string): Promise<string | undefined> {
+		let content = this.fileContents.get(fileName);
+
+		if (!content) {
+			const filePath = path.join(this.rootPath, fileName);
+			content = existsSync(filePath)
+				? fs.readFile(filePath, 'utf8').catch((error: unknown) => {
+						serviceLogger.error(`Error reading ${filePath}`, error);
+						return undefined;
+					})
+				: Promise.resolve(undefined);
+			this.fileContents.set(fileName, content);
+		}
+
+		return content;
+	}
+
+	/**
+	 * Read and parse a JSON file of the project
+	 */
+	private async readJson(
+		fileName: string,
+	): Promise<Record<string, unknown> | undefined> {
+		const content = await this.readFile(fileName);
+		if (!content) {
+			return undefined;
+		}
+
+		try {
+			return JSON.parse(content) as Record<string, unknown>;
+		} catch (error) {
+			serviceLogger.error(`Error parsing ${fileName}`, error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Get the version installed in node_modules
+	 */
+	private async getInstalledVersion(
+		dependencyName: string,
+	): Promise<string | undefined> {
+		const packageJson = await this.readJson(
+			getInstalledPackageJsonPath(dependencyName),
+		);
+		return typeof packageJson?.version === 'string'
+			? packageJson.version
+			: undefined;
+	}
+
+	/**
+	 * Get the version locked in package-lock.json (lockfile v1, v2 and v3)
+	 */
+	private async getPackageLockVersion(
+		dependencyName: string,
+	): Promise<string | undefined> {
+		const packageLock = await this.readJson('package-lock.json');
+		if (!packageLock) {
+			return undefined;
+		}
+
+		const packages = packageLock.packages as
+			| Record<string, {version?: string}>
+			| undefined;
+		const dependencies = packageLock.dependencies as
+			| Record<string, {version?: string}>
+			| undefined;
+
+		return (
+			packages?.[`node_modules/${dependencyName}`]?.version ??
+			dependencies?.[dependencyName]?.version
+		);
+	}
+
+	/**
+	 * Get the version locked in pnpm-lock.yaml
+	 */
+	private async getPnpmLockVersion(
+		dependencyName: string,
+	): Promise<string | undefined> {
+		const content = await this.readFile('pnpm-lock.yaml');
+		if (!content) {
+			return undefined;
+		}
+
+		// Package keys look like /react@19.0.0, react@19.0.0 or /react/18.2.0
+		const packageKey = new RegExp(
+			`^\\s+['"]?/?${escapeRegex(dependencyName)}[@/](\\d+\\.\\d+\\.\\d+[^:'"(\\s]*)`,
+			'm',
+		);
+		return packageKey.exec(content)?.[1];
+	}
+
+	/**
+	 * Get the version locked in yarn.lock (classic and berry)
+	 */
+	private async getYarnLockVersion(
+		dependencyName: string,
+	): Promise<string | undefined> {
+		const content = await this.readFile('yarn.lock');
+		if (!content) {
+			return undefined;
+		}
+
+		const entry = new RegExp(
+			`^"?${escapeRegex(dependencyName)}@[^\\n]*:\\n\\s+version:?\\s+"?([^"\\n]+)`,
+			'm',
+		);
+		return entry.exec(content)?.[1];
+	}
+
+	/**
+	 * Get the version locked in bun.lock
+	 * The binary bun.lockb of older Bun versions is not read, projects using it
+	 * fall back to the installed or declared version
+	 */
+	private async getBunLockVersion(
+		dependencyName: string,
+	): Promise<string | undefined> {
+		const content = await this.readFile('bun.lock');
+		if (!content) {
+			return undefined;
+		}
+
+		// Packages look like "react": ["react@19.0.0", "", {...}, "sha512-..."]
+		// bun.lock allows trailing commas, so it is not parsed as JSON
+		const name = escapeRegex(dependencyName);
+		const entry = new RegExp(`^\\s+"${name}":\\s*\\["${name}@([^"]+)"`, 'm');
+		return entry.exec(content)?.[1];
+	}
+
+	/**
+	 * Get the version range declared in package.json
+	 */
+	private async getDeclaredVersion(
+		dependencyName: string,
+	): Promise<string | undefined> {
+		const packageJson = await this.readJson('package.json');
+		if (!packageJson) {
+			return undefined;
+		}
+
+		for (const field of dependencyFields) {
+			const dependencies = packageJson[field] as
+				| Record<string, unknown>
+				| undefined;
+			const version = dependencies?.[dependencyName];
+
+			if (typeof version === 'string') {
+				return version;
+			}
+		}
+
+		return undefined;
+	}
+}
@@ -0,0 +1,231 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {Gitignore} from '../utils/gitignore.js';
+import {PathFilter} from '../utils/path-filter.js';
+import {logger} from './logger.js';
+
+const serviceLogger = logger.getLogger('FileIndex');
+
+/**
+ * Directories that are never traversed
+ */
+export const ignoredDirectories = new Set(['vendor', 'node_modules', '.git']);
+
+/**
+ * A loaded .gitignore file and the directory its patterns are relative to
+ */
+type GitignoreScope = {
+	directoryPath: string;
+	gitignore: Gitignore;
+};
+
+/**
+ * Options for building the file index
+ */
+export type FileIndexOptions = {
+	/**
+	 * Honor .gitignore files found in the project (defaults to true)
+	 */
+	gitignore?: boolean;
+	/**
+	 * Globs of the files to index, relative to the root, all files if empty
+	 */
+	include?: string[];
+	/**
+	 * Globs of the files and directories to leave out, relative to the root
+	 */
+	exclude?: string[];
+};
+
+/**
+ * Index of all files in a project, traversed once and shared by all scanners
+ */
+export class FileIndex {
+	private files: Promise<string[]> | undefined;
+	private parent: FileIndex | undefined;
+	private readonly pathFilter: PathFilter;
+
+	/**
+	 * Constructor for FileIndex
+	 * @param rootPath Root directory to index
+	 * @param options Index options
+	 */
+	constructor(
+		private readonly rootPath: string,
+		private readonly options: FileIndexOptions = {},
+	) {
+		this.pathFilter = new PathFilter(options.include, options.exclude);
+	}
+
+	/**
+	 * Get all files in the project as sorted absolute paths
+	 * The directory tree is walked on the first call only
+	 */
+	public async getFiles(): Promise<string[]> {
+		this.files ??= this.walk(this.rootPath, []).then((files) => {
+			if (files.length === 0 && this.options.include?.length) {
+				serviceLogger.warn(
+					`No files match the include globs: ${this.options.include.join(', ')}`,
+				);
+			}
+
+			return files.sort();
+		});
+		return this.files;
+	}
+
+	/**
+	 * Get the root directory of the index
+	 */
+	public getRootPath(): string {
+		return this.rootPath;
+	}
+
+	/**
+	 * Get the index this index was created from with createSubIndex, e.g. the
+	 * index of the workspace root for the index of a workspace package
+	 */
+	public getParent(): FileIndex | undefined {
+		return this.parent;
+	}
+
+	/**
+	 * Get all files located under a directory of the project
+	 * @param directoryPath Absolute path of the directory
+	 */
+	public async getFilesIn(directoryPath: string): Promise<string[]> {
+		const prefix = directoryPath.endsWith(path.sep)
+			? directoryPath
+			: directoryPath + path.sep;
+		const files = await this.getFiles();
+		return files.filter((file) => file.startsWith(prefix));
+	}
+
+	/**
+	 * Create an index of a subdirectory that reuses the files of this index
+	 * @param directoryPath Absolute path of the subdirectory
+	 * @param excludedDirectories Absolute paths of directories to leave out
+	 */
+	public createSubIndex(
+		directoryPath: string,
+		excludedDirectories: string[] = [],
+	): FileIndex {
+		const subIndex = new FileIndex(directoryPath, this.options);
+		subIndex.parent = this;
+		const excludedPrefixes = excludedDirectories.map(
+			(excludedDirectory) => excludedDirectory + path.sep,
+		);
+
+		subIndex.files = this.getFilesIn(directoryPath).then((files) =>
+			files.filter(
+				(file) => !excludedPrefixes.some((prefix) => file.startsWith(prefix)),
+			),
+		);
+		return subIndex;
+	}
+
+	/**
+	 * Get all files recursively from a directory
+	 * @param directoryPath Directory to walk
+	 * @param scopes .gitignore files of the parent directories, outermost first
+	 */
+	private async walk(
+		directoryPath: string,
+		scopes: GitignoreScope[],
+	): Promise<string[]> {
+		const files: string[] = [];
+
+		try {
+			const entries = await fs.readdir(directoryPath, {withFileTypes: true});
+
+			// A .gitignore applies to its own directory and everything below it
+			const directoryScopes = [...scopes];
+			if (
+				this.options.gitignore !== false &&
+				entries.some((entry) => entry.isFile() && entry.name === '.gitignore')
+			) {
+				const gitignore = await this.loadGitignore(directoryPath);
+				if (gitignore) {
+					directoryScopes.push({directoryPath, gitignore});
+				}
+			}
+
+			const entryPromises = entries.map(async (entry) => {
+				const fullPath = path.join(directoryPath, entry.name);
+
+				// Skip vendor, node_modules, and .git directories
+				if (entry.isDirectory() && ignoredDirectories.has(entry.name)) {
+					return [];
+				}
+
+				if (this.isIgnored(fullPath, entry.isDirectory(), directoryScopes)) {
+					return [];
+				}
+
+				// Skip paths outside the include and exclude globs
+				const relativePath = path
+					.relative(this.rootPath, fullPath)
+					.split(path.sep)
+					.join('/');
+
+				if (entry.isDirectory()) {
+					return this.pathFilter.shouldTraverse(relativePath)
+						? this.walk(fullPath, directoryScopes)
+						: [];
+				}
+
+				return this.pathFilter.isIncluded(relativePath) ? [fullPath] : [];
+			});
+
+			const entryResults = await Promise.all(entryPromises);
+			for (const entryFiles of entryResults) {
+				files.push(...entryFiles);
+			}
+		} catch (error) {
+			serviceLogger.error(`Error reading directory ${directoryPath}`, error);
+		}
+
+		return files;
+	}
+
+	/**
+	 * Read and parse the .gitignore file of a directory
+	 */
+	private async loadGitignore(
+		directoryPath: string,
+	): Promise<Gitignore | undefined> {
+		const gitignorePath = path.join(directoryPath, '.gitignore');
+
+		try {
+			const content = await fs.readFile(gitignorePath, 'utf8');
+			return new Gitignore(content);
+		} catch (error) {
+			serviceLogger.error(`Error reading ${gitignorePath}`, error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Check if a path is ignored by the .gitignore files that apply to it
+	 * Patterns of deeper .gitignore files take precedence over parent ones
+	 */
+	private isIgnored(
+		fullPath: string,
+		isDirectory: boolean,
+		scopes: GitignoreScope[],
+	): boolean {
+		for (const scope of [...scopes].reverse()) {
+			const relativePath = path
+				.relative(scope.directoryPath, fullPath)
+				.split(path.sep)
+				.join('/');
+			const ignored = scope.gitignore.isIgnored(relativePath, isDirectory);
+
+			if (ignored !== undefined) {
+				return ignored;
+			}
+		}
+
+		return false;
+	}
+}
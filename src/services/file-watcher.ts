@@ -0,0 +1,143 @@
+import {type FSWatcher, watch} from 'node:fs';
+import path from 'node:path';
+import {globToRegex} from '../utils/glob.js';
+import {ignoredDirectories} from './file-index.js';
+import {logger} from './logger.js';
+
+const serviceLogger = logger.getLogger('FileWatcher');
+
+/**
+ * Options for watching a project
+ */
+export type FileWatcherOptions = {
+	/**
+	 * Names of the files to watch, "*" matches any characters
+	 */
+	patterns: string[];
+	/**
+	 * Absolute paths of files whose changes are ignored, e.g. the output file
+	 */
+	ignoredFiles?: string[];
+	/**
+	 * Time to wait for more changes before notifying, in milliseconds
+	 */
+	debounceMilliseconds?: number;
+};
+
+/**
+ * Watches a project for changes of relevant files
+ * Changes are debounced and never handled concurrently, changes made while
+ * the handler runs trigger one more run once it is done
+ */
+export class FileWatcher {
+	private watcher: FSWatcher | undefined;
+	private timer: NodeJS.Timeout | undefined;
+	private running = false;
+	private readonly changedFiles = new Set<string>();
+	private patterns: RegExp[];
+	private readonly ignoredFiles: Set<string>;
+
+	/**
+	 * Constructor for FileWatcher
+	 * @param rootPath Root directory to watch
+	 * @param options Watch options
+	 * @param onChange Called with the changed files, relative to the root
+	 */
+	constructor(
+		private readonly rootPath: string,
+		private readonly options: FileWatcherOptions,
+		private readonly onChange: (changedFiles: string[]) => Promise<void>,
+	) {
+		this.patterns = options.patterns.map((pattern) => globToRegex(pattern));
+		this.ignoredFiles = new Set(options.ignoredFiles);
+	}
+
+	/**
+	 * Replace the names of the watched files, e.g. after a scan found other
+	 * scanners to run
+	 * @param patterns Names of the files to watch, "*" matches any characters
+	 */
+	public setPatterns(patterns: string[]): void {
+		this.patterns = patterns.map((pattern) => globToRegex(pattern));
+	}
+
+	/**
+	 * Start watching the root directory and all its subdirectories
+	 */
+	public start(): void {
+		this.watcher = watch(
+			this.rootPath,
+			{recursive: true},
+			(_eventType, fileName) => {
+				if (fileName && this.isRelevant(fileName)) {
+					this.changedFiles.add(fileName.split(path.sep).join('/'));
+					this.schedule();
+				}
+			},
+		);
+		serviceLogger.debug(`Watching ${this.rootPath} for changes`);
+	}
+
+	/**
+	 * Stop watching
+	 */
+	public close(): void {
+		clearTimeout(this.timer);
+		this.watcher?.close();
+	}
+
+	/**
+	 * Check if a changed file should trigger a new run
+	 * @param fileName Path of the file relative to the root
+	 */
+	private isRelevant(fileName: string): boolean {
+		const parts = fileName.split(path.sep);
+
+		if (parts.some((part) => ignoredDirectories.has(part))) {
+			return false;
+		}
+
+		if (this.ignoredFiles.has(path.join(this.rootPath, fileName))) {
+			return false;
+		}
+
+		const baseName = parts.at(-1) ?? fileName;
+		return this.patterns.some((pattern) => pattern.test(baseName));
+	}
+
+	/**
+	 * Notify about the collected changes once no more changes come in
+	 */
+	private schedule(): void {
+		clearTimeout(this.timer);
+		this.timer = setTimeout(() => {
+			void this.flush();
+		}, this.options.debounceMilliseconds ?? 300);
+	}
+
+	/**
+	 * Run the change handler with all changes collected so far
+	 */
+	private async flush(): Promise<void> {
+		// Changes made while running are handled in the next run
+		if (this.running) {
+			return;
+		}
+
+		this.running = true;
+		const changedFiles = [...this.changedFiles].sort();
+		this.changedFiles.clear();
+
+		try {
+			await this.onChange(changedFiles);
+		} catch (error) {
+			serviceLogger.error('Error handling file changes', error);
+		} finally {
+			this.running = false;
+		}
+
+		if (this.changedFiles.size > 0) {
+			this.schedule();
+		}
+	}
+}
@@ -28,7 +28,12 @@ const loggerFactory = {
 					return `${String(timestamp)} ${String(level)}: ${String(message)}`;
 				}),
 			),
-			transports: [new winston.transports.Console()],
+			// Log to stderr so stdout only contains the generated output
+			transports: [
+				new winston.transports.Console({
+					stderrLevels: Object.keys(winston.config.npm.levels),
+				}),
+			],
 		});
 
 		return this.instance;
@@ -0,0 +1,397 @@
+import {createHash} from 'node:crypto';
+import {type AiRule, Category, categoryOrder} from '../types.js';
+import {CommandPurpose, type ProjectCommand} from '../types/command.js';
+import type {Severity} from '../types/severity.js';
+import {isBuiltInCategory} from '../utils/category-formatter.js';
+import {getConfidence, meetsMinConfidence} from '../utils/confidence.js';
+import {
+	compareSeverity,
+	getSeverity,
+	meetsMinSeverity,
+	sortBySeverity,
+} from '../utils/severity.js';
+import {detectConflicts} from './rule-conflicts.js';
+
+/**
+ * Normalize rule text for comparison, ignoring case, punctuation and spacing
+ */
+function normalizeRuleText(rule: string): string {
+	return rule
+		.toLowerCase()
+		.replaceAll('`', '')
+		.replaceAll(/\s+/g, ' ')
+		.replace(/[\s.!]+$/, '')
+		.trim();
+}
+
+/**
+ * Create a stable identifier for a rule from its category and text
+ * The id only changes when the rule text itself changes. Rules of workspace
+ * packages include the package path so the same rule gets a distinct id in
+ * every package.
+ * @param rule Rule to identify
+ * @returns Identifier such as "package_manager-1a2b3c4d"
+ */
+export function createRuleId(rule: AiRule): string {
+	const text = normalizeRuleText(rule.rule);
+	const hash = createHash('sha256')
+		.update(rule.package ? `${rule.package}:${text}` : text)
+		.digest('hex')
+		.slice(0, 8);
+	return `${rule.category ?? Category.General}-${hash}`;
+}
+
+/**
+ * Get the names of the scanners that emitted a rule
+ */
+export function getSources(rule: AiRule): string[] {
+	return rule.sources ?? (rule.scanner ? [rule.scanner] : []);
+}
+
+/**
+ * Combine a duplicate rule into the rule that is kept
+ */
+function mergeInto(kept: AiRule, duplicate: AiRule): AiRule {
+	const sources = [...new Set([...getSources(kept), ...getSources(duplicate)])];
+	const files = [
+		...new Set([...(kept.files ?? []), ...(duplicate.files ?? [])]),
+	];
+
+	// The merged rule is as important as the most important duplicate, and as
+	// certain as the most certain one
+	const [mostImportant] = sortBySeverity([kept, duplicate]);
+	const confidence = Math.max(getConfidence(kept), getConfidence(duplicate));
+
+	return {
+		...kept,
+		...(getSeverity(mostImportant) !== getSeverity(kept) && {
+			severity: mostImportant.severity,
+		}),
+		...(confidence !== getConfidence(kept) && {confidence}),
+		...(sources.length > 1 && {sources}),
+		...(files.length > 0 && {files}),
+	};
+}
+
+/**
+ * What running the commands of each purpose does, in the order of the
+ * Commands rules
+ */
+const commandPurposeDescriptions: Record<CommandPurpose, string> = {
+	[CommandPurpose.Dev]: 'start the development server',
+	[CommandPurpose.Start]: 'start the application',
+	[CommandPurpose.Build]: 'build the project',
+	[CommandPurpose.Test]: 'run the tests',
+	[CommandPurpose.Lint]: 'lint the code',
+	[CommandPurpose.Fix]: 'fix lint errors',
+	[CommandPurpose.Format]: 'format the code',
+	[CommandPurpose.Typecheck]: 'type-check the code',
+};
+
+/**
+ * Normalize a command for comparison, so `npm run test` and `npm test` are
+ * the same command
+ */
+function normalizeCommand(command: string): string {
+	return command
+		.trim()
+		.replaceAll(/\s+/g, ' ')
+		.replace(/^(npm|pnpm|yarn|bun) run /, '$1 ');
+}
+
+/**
+ * Collect the commands named by all rules into one Commands rule per purpose
+ * Commands rules naming commands are replaced by the rule of their purpose,
+ * other rules naming commands, e.g. the commands run by CI, are kept. A
+ * command found by several scanners is listed once, with the purpose and
+ * text of the first scanner that found it.
+ * @param rules Rules in scanner order
+ * @returns Rules followed by the collected Commands rules
+ */
+export function mergeCommands(rules: AiRule[]): AiRule[] {
+	const commandRules = new Map<CommandPurpose, AiRule[]>();
+	const commandsByPurpose = new Map<CommandPurpose, ProjectCommand[]>();
+	const seenCommands = new Set<string>();
+
+	for (const rule of rules) {
+		for (const command of rule.commands ?? []) {
+			const purposeRules = commandRules.get(command.purpose) ?? [];
+			if (!purposeRules.includes(rule)) {
+				commandRules.set(command.purpose, [...purposeRules, rule]);
+			}
+
+			const key = normalizeCommand(command.command);
+			if (!seenCommands.has(key)) {
+				seenCommands.add(key);
+				commandsByPurpose.set(command.purpose, [
+					...(commandsByPurpose.get(command.purpose) ?? []),
+					command,
+				]);
+			}
+		}
+	}
+
+	if (commandsByPurpose.size === 0) {
+		return rules;
+	}
+
+	const mergedRules = Object.values(CommandPurpose).flatMap((purpose) => {
+		const commands = commandsByPurpose.get(purpose);
+		if (!commands) {
+			return [];
+		}
+
+		const [firstRule, ...otherRules] = commandRules.get(purpose) ?? [];
+		const names = commands.map((command) => `\`${command.command}\``);
+		const commandList =
+			names.length > 1
+				? `${names.slice(0, -1).join(', ')} or ${names.at(-1)}`
+				: names[0];
+		const mergedRule = otherRules.reduce(mergeInto, firstRule);
+
+		return [
+			{
+				...mergedRule,
+				category: Category.Commands,
+				rule: `Run ${commandList} to ${commandPurposeDescriptions[purpose]}.`,
+				commands,
+				values: {
+					commands: commands.map((command) => command.command).join(', '),
+				},
+			},
+		];
+	});
+
+	return [
+		...rules.filter(
+			(rule) =>
+				rule.category !== Category.Commands || rule.commands === undefined,
+		),
+		...mergedRules,
+	];
+}
+
+/**
+ * Check if a rule is subsumed by a longer rule, e.g. "Use TypeScript" by
+ * "Use TypeScript for type-safe Vue.js development"
+ */
+function isSubsumedBy(rule: string, longerRule: string): boolean {
+	return longerRule.length > rule.length && longerRule.startsWith(`${rule} `);
+}
+
+/**
+ * Remove duplicate rules emitted by different scanners
+ * Rules with the same text collapse into the first occurrence, and rules that
+ * are a shorter form of another rule in the same category are merged into it.
+ * Merged rules list all scanners that emitted them in `sources`.
+ * The order of the remaining rules is preserved.
+ * @param rules Rules in scanner order
+ * @returns Deduplicated rules
+ */
+export function deduplicateRules(rules: AiRule[]): AiRule[] {
+	const keptRules: AiRule[] = [];
+	const indexByText = new Map<string, number>();
+
+	// Collapse rules with identical text
+	for (const rule of rules) {
+		const text = normalizeRuleText(rule.rule);
+		const existingIndex = indexByText.get(text);
+
+		if (existingIndex === undefined) {
+			indexByText.set(text, keptRules.length);
+			keptRules.push(rule);
+		} else {
+			keptRules[existingIndex] = mergeInto(keptRules[existingIndex], rule);
+		}
+	}
+
+	// Merge rules that are subsumed by a longer rule of the same category
+	const texts = keptRules.map((rule) => normalizeRuleText(rule.rule));
+	const subsumed = new Set<number>();
+
+	for (const [index, rule] of keptRules.entries()) {
+		const longerIndex = keptRules.findIndex(
+			(other, otherIndex) =>
+				!subsumed.has(otherIndex) &&
+				(other.category ?? Category.General) ===
+					(rule.category ?? Category.General) &&
+				isSubsumedBy(texts[index], texts[otherIndex]),
+		);
+
+		if (longerIndex !== -1) {
+			subsumed.add(index);
+			keptRules[longerIndex] = mergeInto(keptRules[longerIndex], rule);
+		}
+	}
+
+	return keptRules.filter((_rule, index) => !subsumed.has(index));
+}
+
+/**
+ * Options for aggregating rules
+ */
+export type AggregateOptions = {
+	/**
+	 * Drop rules that are less important than this severity
+	 */
+	minSeverity?: Severity;
+	/**
+	 * Drop rules that are less certain than this confidence, from 0 to 1
+	 */
+	minConfidence?: number;
+	/**
+	 * Only keep rules of these categories
+	 */
+	categories?: Category[];
+	/**
+	 * Drop rules of these categories
+	 */
+	excludeCategories?: Category[];
+};
+
+/**
+ * Check if the category of a rule passes the category filters
+ * Rules without a category belong to the general category
+ */
+function matchesCategories(rule: AiRule, options: AggregateOptions): boolean {
+	const category = rule.category ?? Category.General;
+	// Custom categories cannot be selected, only built-in ones
+	if (!isBuiltInCategory(category)) {
+		return !options.categories;
+	}
+
+	return (
+		(!options.categories || options.categories.includes(category)) &&
+		!options.excludeCategories?.includes(category)
+	);
+}
+
+/**
+ * Compare strings by code points, which unlike localeCompare gives the same
+ * order on every machine
+ */
+function compareText(a: string, b: string): number {
+	if (a === b) {
+		return 0;
+	}
+
+	return a < b ? -1 : 1;
+}
+
+/**
+ * Rank of the purpose of a Commands rule in the order of CommandPurpose, other
+ * rules come after all purposes
+ */
+function getPurposeRank(rule: AiRule): number {
+	const purposes: string[] = Object.values(CommandPurpose);
+	const purpose = rule.commands?.[0]?.purpose;
+	if (rule.category !== Category.Commands || !purpose) {
+		return purposes.length;
+	}
+
+	return purposes.indexOf(purpose);
+}
+
+/**
+ * Rank of the category of a rule in the output order
+ * Custom categories are not part of `categoryOrder` and rank after all
+ * built-in ones.
+ */
+function getCategoryRank(rule: AiRule): number {
+	const category = rule.category ?? Category.General;
+	return isBuiltInCategory(category)
+		? categoryOrder.indexOf(category)
+		: categoryOrder.length;
+}
+
+/**
+ * Compare two rules for the output order: by category in the order of
+ * `categoryOrder` with custom categories last by name, then by the purpose of
+ * their commands, then from most to least important, then by text and id
+ * Rules are compared by their content only, so the order does not depend on
+ * the order in which scanners or the file system returned them.
+ * @param a First rule
+ * @param b Second rule
+ * @returns Negative if the first rule comes first
+ */
+export function compareRules(a: AiRule, b: AiRule): number {
+	const categoryDifference =
+		getCategoryRank(a) - getCategoryRank(b) ||
+		compareText(
+			a.category ?? Category.General,
+			b.category ?? Category.General,
+		);
+	if (categoryDifference !== 0) {
+		return categoryDifference;
+	}
+
+	const purposeDifference = getPurposeRank(a) - getPurposeRank(b);
+	if (purposeDifference !== 0) {
+		return purposeDifference;
+	}
+
+	const severityDifference = compareSeverity(a, b);
+	if (severityDifference !== 0) {
+		return severityDifference;
+	}
+
+	return (
+		compareText(a.rule, b.rule) ||
+		compareText(a.id ?? createRuleId(a), b.id ?? createRuleId(b))
+	);
+}
+
+/**
+ * Post-process the rules collected from all scanners
+ * The commands named by the rules are collected into Commands rules first,
+ * and rules contradicting each other get a rule asking to resolve them
+ * @param rules Rules in scanner order
+ * @param options Aggregation options
+ * @returns Deduplicated and filtered rules with stable ids, in a stable order
+ */
+export function aggregateRules(
+	rules: AiRule[],
+	options: AggregateOptions = {},
+): AiRule[] {
+	const {minSeverity, minConfidence} = options;
+
+	const filteredRules = deduplicateRules(mergeCommands(rules))
+		.filter((rule) => !minSeverity || meetsMinSeverity(rule, minSeverity))
+		.filter(
+			(rule) =>
+				minConfidence === undefined ||
+				meetsMinConfidence(rule, minConfidence),
+		)
+		.filter((rule) => matchesCategories(rule, options));
+
+	// Conflicts are detected between the rules that are kept, so a rule that
+	// was filtered out does not need to be resolved
+	return detectConflicts(filteredRules)
+		.map((rule) => ({
+			...rule,
+			id: createRuleId(rule),
+		}))
+		.sort(compareRules);
+}
+
+/**
+ * Remove rules of a workspace package that are already shared by the root
+ * Commands are always kept, they run in the directory of their package so the
+ * same command means something else in every package.
+ * @param rules Aggregated rules of a package
+ * @param sharedRules Aggregated rules of the workspace root
+ * @returns Rules that only apply to the package
+ */
+export function excludeSharedRules(
+	rules: AiRule[],
+	sharedRules: AiRule[],
+): AiRule[] {
+	const sharedTexts = new Set(
+		sharedRules.map((rule) => normalizeRuleText(rule.rule)),
+	);
+	return rules.filter(
+		(rule) =>
+			rule.category === Category.Commands ||
+			!sharedTexts.has(normalizeRuleText(rule.rule)),
+	);
+}
@@ -0,0 +1,143 @@
+import {type AiRule, Category} from '../types.js';
+import {Severity} from '../types/severity.js';
+import {getConfidence} from '../utils/confidence.js';
+import {compareSeverity} from '../utils/severity.js';
+import {compareRules, createRuleId} from './rule-aggregator.js';
+
+/**
+ * Limits on the size of the output
+ */
+export type RuleBudget = {
+	/**
+	 * Maximum number of rules, including the note on the omitted rules
+	 */
+	maxRules?: number;
+	/**
+	 * Maximum number of tokens of the rule texts, including the note on the
+	 * omitted rules
+	 */
+	tokenBudget?: number;
+};
+
+/**
+ * Characters per token of English text, close enough for common tokenizers
+ * to estimate the size of the output without depending on one
+ */
+const charactersPerToken = 4;
+
+/**
+ * Estimate the number of tokens of a rule from the length of its text
+ */
+export function estimateTokens(rule: AiRule): number {
+	return Math.ceil(rule.rule.length / charactersPerToken);
+}
+
+/**
+ * Compare two rules by priority: from most to least important, then from most
+ * to least certain, then in output order so rules with the same priority are
+ * always dropped in the same order
+ * @returns Negative if the first rule has a higher priority
+ */
+function comparePriority(a: AiRule, b: AiRule): number {
+	return (
+		compareSeverity(a, b) ||
+		getConfidence(b) - getConfidence(a) ||
+		compareRules(a, b)
+	);
+}
+
+/**
+ * Get the rule noting how many rules were left out
+ */
+function getOmittedRule(omittedCount: number): AiRule {
+	const rule: AiRule = {
+		category: Category.General,
+		rule: `${omittedCount} lower-priority ${omittedCount === 1 ? 'rule was' : 'rules were'} left out to fit the size limit of the instructions. Follow the conventions of the existing code where no rule applies.`,
+		severity: Severity.Info,
+		values: {omitted: String(omittedCount)},
+	};
+
+	return {...rule, id: createRuleId(rule)};
+}
+
+/**
+ * Check if a number of rules of the given tokens fits in the budget
+ */
+function fitsBudget(
+	count: number,
+	tokens: number,
+	budget: RuleBudget,
+): boolean {
+	return (
+		(budget.maxRules === undefined || count <= budget.maxRules) &&
+		(budget.tokenBudget === undefined || tokens <= budget.tokenBudget)
+	);
+}
+
+/**
+ * Keep the highest-priority rules that fit the budget next to the reserved
+ * room
+ * @param sortedRules Rules by priority
+ * @param budget Maximum number of rules and tokens
+ * @param reservedCount Number of rules the budget must leave room for
+ * @param reservedTokens Number of tokens the budget must leave room for
+ */
+function fillBudget(
+	sortedRules: AiRule[],
+	budget: RuleBudget,
+	reservedCount: number,
+	reservedTokens: number,
+): Set<AiRule> {
+	const kept = new Set<AiRule>();
+	let tokens = reservedTokens;
+
+	for (const rule of sortedRules) {
+		const ruleTokens = estimateTokens(rule);
+		if (
+			!fitsBudget(kept.size + reservedCount + 1, tokens + ruleTokens, budget)
+		) {
+			break;
+		}
+
+		kept.add(rule);
+		tokens += ruleTokens;
+	}
+
+	return kept;
+}
+
+/**
+ * Drop the lowest-priority rules until the rules fit the budget
+ * Rules are dropped by severity, then by confidence, and a rule noting how
+ * many were left out is added. The note is left out when it does not leave
+ * room for any rule. The kept rules keep their order, so the same rules
+ * always give the same output.
+ * @param rules Aggregated rules
+ * @param budget Maximum number of rules and tokens
+ * @returns The rules that fit, followed by the note if any rule was left out
+ * and the note fits
+ */
+export function limitRules(rules: AiRule[], budget: RuleBudget): AiRule[] {
+	const totalTokens = rules.reduce(
+		(sum, rule) => sum + estimateTokens(rule),
+		0,
+	);
+	if (fitsBudget(rules.length, totalTokens, budget)) {
+		return rules;
+	}
+
+	const sortedRules = [...rules].sort(comparePriority);
+
+	// The note takes room too, at most as much as with every rule left out
+	const noteTokens = estimateTokens(getOmittedRule(rules.length));
+	const kept = fillBudget(sortedRules, budget, 1, noteTokens);
+	if (kept.size === 0) {
+		const keptWithoutNote = fillBudget(sortedRules, budget, 0, 0);
+		return rules.filter((rule) => keptWithoutNote.has(rule));
+	}
+
+	return [
+		...rules.filter((rule) => kept.has(rule)),
+		getOmittedRule(rules.length - kept.size),
+	];
+}
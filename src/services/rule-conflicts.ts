@@ -0,0 +1,139 @@
+import {type AiRule, Category} from '../types.js';
+import {Severity} from '../types/severity.js';
+
+/**
+ * A choice that rules can make differently, e.g. the package manager
+ */
+type ConflictRule = {
+	// Shared by the conflicting rules, e.g. "package-manager"
+	group: string;
+	// What the rules disagree on, e.g. "package manager"
+	subject: string;
+	category: Category;
+	// Names of the options, as they are written in the rules
+	options: string[];
+	// Matches a rule choosing an option, the first group that matched is the
+	// name of the option
+	pattern: RegExp;
+	resolution: string;
+};
+
+/**
+ * Known choices that rules of different scanners or of the config file can
+ * make differently
+ */
+const conflictRules: ConflictRule[] = [
+	{
+		group: 'package-manager',
+		subject: 'package manager',
+		category: Category.PackageManager,
+		options: ['npm', 'yarn', 'pnpm', 'bun'],
+		pattern:
+			/\buse (npm|yarn|pnpm|bun) (?:as the package manager|for package management)\b/i,
+		resolution:
+			'Ask which package manager the project uses before installing or adding dependencies, and remove the lock files of the others.',
+	},
+	{
+		group: 'formatter',
+		subject: 'formatter',
+		category: Category.Linting,
+		options: ['Prettier', 'Biome'],
+		pattern:
+			/\b(?:use (prettier|biome) for (?:[\w ]+ and )?(?:code )?formatting|formatting is enforced by (prettier|biome))\b/i,
+		resolution:
+			'Ask which formatter owns a file before formatting it, and do not format the same files with both.',
+	},
+];
+
+/**
+ * Get the option a rule chooses for a conflict rule, undefined if none
+ */
+function getOption(
+	rule: AiRule,
+	conflictRule: ConflictRule,
+): string | undefined {
+	const match = conflictRule.pattern.exec(rule.rule);
+	const name = match?.slice(1).find(Boolean)?.toLowerCase();
+
+	return conflictRule.options.find((option) => option.toLowerCase() === name);
+}
+
+/**
+ * Check if a rule of a scanner describes a conflict between some options,
+ * e.g. the Biome rule warning about Prettier
+ * Such a rule has the group of the conflict and names all its options.
+ */
+function describesConflict(
+	rule: AiRule,
+	conflictRule: ConflictRule,
+	options: string[],
+): boolean {
+	return (
+		rule.conflictGroup === conflictRule.group &&
+		!getOption(rule, conflictRule) &&
+		options.every((option) =>
+			new RegExp(`\\b${option}\\b`, 'i').test(rule.rule),
+		)
+	);
+}
+
+/**
+ * Find the rules that choose different options for the same choice
+ * The conflicting rules are kept and get the group of the conflict in
+ * `conflictGroup`, and a critical rule of the same group asks to resolve the
+ * conflict, unless a scanner already describes the conflict.
+ * @param rules Deduplicated rules
+ * @returns The rules with the conflicting ones marked, followed by a rule per
+ * conflict
+ */
+export function detectConflicts(rules: AiRule[]): AiRule[] {
+	const conflictGroups = new Map<AiRule, string>();
+	const resolutionRules: AiRule[] = [];
+
+	for (const conflictRule of conflictRules) {
+		const choices = rules.flatMap((rule) => {
+			const option = getOption(rule, conflictRule);
+			return option ? [{rule, option}] : [];
+		});
+		const options = conflictRule.options.filter((option) =>
+			choices.some((choice) => choice.option === option),
+		);
+		if (options.length < 2) {
+			continue;
+		}
+
+		for (const {rule} of choices) {
+			conflictGroups.set(rule, conflictRule.group);
+		}
+
+		if (rules.some((rule) => describesConflict(rule, conflictRule, options))) {
+			continue;
+		}
+
+		const optionList =
+			`${options.slice(0, -1).join(', ')} and ${options.at(-1)}`;
+		const files = [...new Set(choices.flatMap(({rule}) => rule.files ?? []))];
+		resolutionRules.push({
+			category: conflictRule.category,
+			rule: `The project is set up for more than one ${conflictRule.subject}: ${optionList}. ${conflictRule.resolution}`,
+			severity: Severity.Critical,
+			...(files.length > 0 && {files}),
+			values: {options: options.join(', ')},
+			conflictGroup: conflictRule.group,
+			// Conflicts are only detected between rules of the same package
+			...(choices[0].rule.package && {package: choices[0].rule.package}),
+		});
+	}
+
+	if (conflictGroups.size === 0) {
+		return rules;
+	}
+
+	return [
+		...rules.map((rule) => {
+			const conflictGroup = conflictGroups.get(rule);
+			return conflictGroup ? {...rule, conflictGroup} : rule;
+		}),
+		...resolutionRules,
+	];
+}
@@ -0,0 +1,64 @@
+import type {AiRule} from '../types.js';
+import {createRuleId} from './rule-aggregator.js';
+
+/**
+ * Differences between two sets of rules
+ */
+export type RuleChanges = {
+	added: AiRule[];
+	removed: AiRule[];
+	// Rules with the same text but different details, e.g. severity or files
+	updated: AiRule[];
+};
+
+/**
+ * Get the id of a rule, rules that were not aggregated get one computed
+ */
+function getRuleId(rule: AiRule): string {
+	return rule.id ?? createRuleId(rule);
+}
+
+/**
+ * Compare two sets of rules by their ids
+ * @param previousRules Rules of the previous scan
+ * @param nextRules Rules of the latest scan
+ * @returns Added, removed and updated rules in the order of the scans
+ */
+export function diffRules(
+	previousRules: AiRule[],
+	nextRules: AiRule[],
+): RuleChanges {
+	const previousById = new Map(
+		previousRules.map((rule) => [getRuleId(rule), rule] as const),
+	);
+	const nextIds = new Set(nextRules.map((rule) => getRuleId(rule)));
+	const changes: RuleChanges = {added: [], removed: [], updated: []};
+
+	for (const rule of nextRules) {
+		const previousRule = previousById.get(getRuleId(rule));
+
+		if (!previousRule) {
+			changes.added.push(rule);
+		} else if (JSON.stringify(previousRule) !== JSON.stringify(rule)) {
+			changes.updated.push(rule);
+		}
+	}
+
+	for (const [id, rule] of previousById) {
+		if (!nextIds.has(id)) {
+			changes.removed.push(rule);
+		}
+	}
+
+	return changes;
+}
+
+/**
+ * Check if two sets of rules differ, including the order of the rules
+ */
+export function rulesDiffer(
+	previousRules: AiRule[],
+	nextRules: AiRule[],
+): boolean {
+	return JSON.stringify(previousRules) !== JSON.stringify(nextRules);
+}
@@ -0,0 +1,129 @@
+import {type AiRule, Category} from '../types.js';
+import type {RuleTemplates} from '../types/config.js';
+import {formatCategoryTitle} from '../utils/category-formatter.js';
+import {logger} from './logger.js';
+import {
+	compareRules,
+	createRuleId,
+	deduplicateRules,
+	getSources,
+} from './rule-aggregator.js';
+
+const serviceLogger = logger.getLogger('RuleTemplates');
+
+/**
+ * Matches a placeholder of a template, e.g. "{{version}}"
+ */
+const placeholderPattern = /{{\s*(\w+)\s*}}/g;
+
+/**
+ * Key of the template applying to every rule of a scanner
+ */
+const anyRuleKey = '*';
+
+/**
+ * Replace the placeholders of a template with their values
+ * Placeholders without a value are kept as they are
+ * @param template Template such as "Use {{packageManager}}."
+ * @param values Values of the placeholders
+ * @returns The rendered text
+ */
+export function renderTemplate(
+	template: string,
+	values: Record<string, string>,
+): string {
+	return template.replaceAll(
+		placeholderPattern,
+		(placeholder, name: string) => {
+			if (name in values) {
+				return values[name];
+			}
+
+			serviceLogger.warn(`Unknown placeholder ${placeholder} in rule template`);
+			return placeholder;
+		},
+	);
+}
+
+/**
+ * Get the keys of the templates that apply to a rule, most specific first
+ * Rules of workspace packages also match the id the rule has at the root, so
+ * a template applies to the rule in every package. Templates keyed by a
+ * category, e.g. "package_manager", apply to the rules of that category.
+ */
+function getTemplateKeys(rule: AiRule): string[] {
+	const keys = [
+		rule.id,
+		rule.package ? createRuleId({...rule, package: undefined}) : undefined,
+		rule.category ?? Category.General,
+		anyRuleKey,
+	].filter((key) => key !== undefined);
+
+	return [...new Set(keys)];
+}
+
+/**
+ * Find the template of a rule, by rule id, by category or for any rule of its
+ * scanners
+ */
+function findTemplate(
+	rule: AiRule,
+	templates: RuleTemplates,
+	classNames: Map<string, string>,
+): string | undefined {
+	const templateKeys = getTemplateKeys(rule);
+
+	for (const source of getSources(rule)) {
+		// Templates are keyed by the scanner name, e.g. "package-manager", or
+		// by the class name of the scanner
+		for (const key of [source, classNames.get(source)]) {
+			const scannerTemplates = key === undefined ? undefined : templates[key];
+			const template = templateKeys
+				.map((templateKey) => scannerTemplates?.[templateKey])
+				.find((scannerTemplate) => scannerTemplate !== undefined);
+
+			if (template !== undefined) {
+				return template;
+			}
+		}
+	}
+
+	return undefined;
+}
+
+/**
+ * Replace the text of rules with the templates of the config file
+ * Rules keep the id of their default text, so a template keeps applying to
+ * its rule. The placeholders are the values detected by the scanner, e.g.
+ * {{version}}, and {{rule}}, {{category}} and {{files}} for every rule. An
+ * empty template removes the rule. Rules that get the same text are merged
+ * and the rules are sorted again by their new text.
+ * @param rules Aggregated rules
+ * @param templates Templates by scanner name and rule id, category or "*"
+ * @param classNames Class names of the scanners by the scanner names rules
+ * are tagged with
+ * @returns Rules with the text of their templates
+ */
+export function applyRuleTemplates(
+	rules: AiRule[],
+	templates: RuleTemplates,
+	classNames: Map<string, string> = new Map(),
+): AiRule[] {
+	const templatedRules = rules.flatMap((rule) => {
+		const template = findTemplate(rule, templates, classNames);
+		if (template === undefined) {
+			return [rule];
+		}
+
+		const text = renderTemplate(template, {
+			...rule.values,
+			rule: rule.rule,
+			category: formatCategoryTitle(rule.category ?? Category.General),
+			files: (rule.files ?? []).join(', '),
+		}).trim();
+
+		return text ? [{...rule, rule: text}] : [];
+	});
+
+	return deduplicateRules(templatedRules).sort(compareRules);
+}
@@ -0,0 +1,201 @@
+import {createHash} from 'node:crypto';
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import type {AiRule} from '../types.js';
+import {globToRegex} from '../utils/glob.js';
+import {logger} from './logger.js';
+import {packageInfo} from './package-info.js';
+
+const serviceLogger = logger.getLogger('ScanCache');
+
+/**
+ * Name of the cache file, written to the scanned directory
+ */
+export const cacheFileName = '.psst-cache.json';
+
+/**
+ * Cached rules of one scanner and the fingerprint of the files it read
+ */
+type CacheEntry = {
+	fingerprint: string;
+	rules: AiRule[];
+};
+
+/**
+ * Contents of the cache file
+ */
+type CacheFile = {
+	// Version of psst-ai that wrote the cache, other versions discard it
+	version: string;
+	entries: Record<string, CacheEntry>;
+};
+
+/**
+ * Persisted scanner results of previous runs
+ * A scanner result is reused while the fingerprint of its watched files and
+ * of the file list of its directory is unchanged
+ */
+export class ScanCache {
+	private readonly cachePath: string;
+	private entries: Record<string, CacheEntry> = {};
+	// Entries read or written by this run, the others are dropped on save
+	private usedEntries: Record<string, CacheEntry> = {};
+	private readonly fileStats = new Map<string, Promise<string>>();
+	private hits = 0;
+
+	/**
+	 * Constructor for ScanCache
+	 * @param rootPath Scanned directory, where the cache file is stored
+	 */
+	constructor(rootPath: string) {
+		this.cachePath = path.join(rootPath, cacheFileName);
+	}
+
+	/**
+	 * Read the cache file, a missing or outdated cache starts empty
+	 * Called before every scan, as files may have changed since the last one
+	 */
+	public async load(): Promise<void> {
+		this.entries = {};
+		this.usedEntries = {};
+		this.fileStats.clear();
+		this.hits = 0;
+
+		if (!existsSync(this.cachePath)) {
+			return;
+		}
+
+		try {
+			const content = await fs.readFile(this.cachePath, 'utf8');
+			const cacheFile = JSON.parse(content) as Partial<CacheFile>;
+
+			if (cacheFile.version !== packageInfo.getVersion()) {
+				serviceLogger.debug('Discarding cache of another psst-ai version');
+				return;
+			}
+
+			this.entries = cacheFile.entries ?? {};
+		} catch (error) {
+			serviceLogger.warn(`Ignoring unreadable cache file ${this.cachePath}`);
+			serviceLogger.debug('Error reading cache file', error);
+		}
+	}
+
+	/**
+	 * Write the entries used by this run to the cache file
+	 */
+	public async save(): Promise<void> {
+		const cacheFile: CacheFile = {
+			version: packageInfo.getVersion(),
+			entries: this.usedEntries,
+		};
+
+		try {
+			await fs.writeFile(this.cachePath, JSON.stringify(cacheFile), 'utf8');
+			serviceLogger.debug(
+				`Saved cache, reused ${this.hits} of ${Object.keys(this.usedEntries).length} scanner results`,
+			);
+		} catch (error) {
+			serviceLogger.error('Error writing cache file', error);
+		}
+	}
+
+	/**
+	 * Delete the cache file
+	 * @returns True if a cache file existed
+	 */
+	public async clear(): Promise<boolean> {
+		if (!existsSync(this.cachePath)) {
+			return false;
+		}
+
+		await fs.rm(this.cachePath);
+		return true;
+	}
+
+	/**
+	 * Get the cached rules of a scanner if its files are unchanged
+	 * @param key Scanner and directory the rules belong to
+	 * @param fingerprint Current fingerprint of the files of the scanner
+	 */
+	public get(key: string, fingerprint: string): AiRule[] | undefined {
+		const entry = this.entries[key];
+		if (entry?.fingerprint !== fingerprint) {
+			return undefined;
+		}
+
+		this.hits++;
+		this.usedEntries[key] = entry;
+		return entry.rules;
+	}
+
+	/**
+	 * Store the rules of a scanner
+	 * @param key Scanner and directory the rules belong to
+	 * @param fingerprint Fingerprint of the files the rules were found in
+	 * @param rules Rules found by the scanner
+	 */
+	public set(key: string, fingerprint: string, rules: AiRule[]): void {
+		this.usedEntries[key] = {fingerprint, rules};
+	}
+
+	/**
+	 * Compute the fingerprint of the files a scanner reads
+	 * Watched files contribute their modification time and size, all other
+	 * files only their path, so adding or deleting any file of the directory
+	 * invalidates the entry
+	 * @param files Absolute paths of the files of the directory
+	 * @param watchedFiles File name patterns of the scanner
+	 * @param unindexedFiles Absolute paths of other files the scanner reads,
+	 * e.g. in node_modules, which contribute their modification time and size
+	 */
+	public async getFingerprint(
+		files: string[],
+		watchedFiles: string[],
+		unindexedFiles: string[] = [],
+	): Promise<string> {
+		const patterns = watchedFiles.map((pattern) => globToRegex(pattern));
+		const hash = createHash('sha256');
+
+		const lines = await Promise.all(
+			files
+				.filter((file) => file !== this.cachePath)
+				.map(async (file) => {
+					const baseName = path.basename(file);
+					return patterns.some((pattern) => pattern.test(baseName))
+						? `${file}:${await this.getFileStat(file)}`
+						: file;
+				}),
+		);
+
+		const unindexedLines = await Promise.all(
+			unindexedFiles.map(
+				async (file) => `${file}:${await this.getFileStat(file)}`,
+			),
+		);
+
+		for (const line of [...lines, ...unindexedLines]) {
+			hash.update(`${line}\n`);
+		}
+
+		return hash.digest('hex');
+	}
+
+	/**
+	 * Get the modification time and size of a file, shared by all scanners
+	 */
+	private async getFileStat(file: string): Promise<string> {
+		let stat = this.fileStats.get(file);
+
+		if (!stat) {
+			stat = fs
+				.stat(file)
+				.then((stats) => `${stats.mtimeMs}:${stats.size}`)
+				.catch(() => 'missing');
+			this.fileStats.set(file, stat);
+		}
+
+		return stat;
+	}
+}
@@ -0,0 +1,72 @@
+import path from 'node:path';
+import {afterEach, describe, expect, it} from 'vitest';
+import {findConfigFile, loadConfig, validateConfig} from '../config-loader.js';
+import {
+	createFixture,
+	removeFixture,
+} from '../../scanners/tests/fixture.js';
+
+describe('Config loader', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('findConfigFile', () => {
+		it('should find the config file of the project', async () => {
+			rootPath = await createFixture({'psst.config.mjs': 'export default {};'});
+
+			expect(findConfigFile(rootPath)).toBe(
+				path.join(rootPath, 'psst.config.mjs'),
+			);
+		});
+
+		it('should find nothing without a config file', async () => {
+			rootPath = await createFixture({'package.json': '{}'});
+
+			expect(findConfigFile(rootPath)).toBeUndefined();
+		});
+	});
+
+	describe('loadConfig', () => {
+		it('should load the default export of the config file', async () => {
+			rootPath = await createFixture({
+				'psst.config.mjs':
+					'export default {disable: ["docker"], exclude: ["examples"]};',
+			});
+
+			expect(await loadConfig(rootPath)).toEqual({
+				disable: ['docker'],
+				exclude: ['examples'],
+			});
+		});
+
+		it('should fail when the explicit config file does not exist', async () => {
+			rootPath = await createFixture({});
+			let message = '';
+
+			try {
+				await loadConfig(rootPath, path.join(rootPath, 'missing.mjs'));
+			} catch (error) {
+				message = (error as Error).message;
+			}
+
+			expect(message).toMatch('Config file not found');
+		});
+	});
+
+	describe('validateConfig', () => {
+		it('should name the invalid options', () => {
+			let message = '';
+
+			try {
+				validateConfig({only: 'docker'}, 'object');
+			} catch (error) {
+				message = (error as Error).message;
+			}
+
+			expect(message).toMatch('Invalid config object: only');
+		});
+	});
+});
@@ -0,0 +1,114 @@
+import path from 'node:path';
+import {afterEach, describe, expect, it} from 'vitest';
+import {FileIndex, type FileIndexOptions} from '../file-index.js';
+import {
+	createFixture,
+	removeFixture,
+} from '../../scanners/tests/fixture.js';
+
+describe('FileIndex', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	/**
+	 * Get the indexed files as sorted paths relative to the root
+	 */
+	async function getFiles(options?: FileIndexOptions): Promise<string[]> {
+		const files = await new FileIndex(rootPath, options).getFiles();
+		return files.map((file) =>
+			path.relative(rootPath, file).split(path.sep).join('/'),
+		);
+	}
+
+	describe('Gitignore', () => {
+		it('should skip ignored files and directories', async () => {
+			rootPath = await createFixture({
+				'.gitignore': 'dist/\n*.log\n!keep.log\n',
+				'dist/index.js': '',
+				'debug.log': '',
+				'keep.log': '',
+				'src/index.ts': '',
+			});
+
+			expect(await getFiles()).toEqual([
+				'.gitignore',
+				'keep.log',
+				'src/index.ts',
+			]);
+		});
+
+		it('should apply nested .gitignore files to their directory', async () => {
+			rootPath = await createFixture({
+				'packages/api/.gitignore': 'generated/\n',
+				'packages/api/generated/client.ts': '',
+				'packages/api/src/index.ts': '',
+				'generated/schema.ts': '',
+			});
+
+			expect(await getFiles()).toEqual([
+				'generated/schema.ts',
+				'packages/api/.gitignore',
+				'packages/api/src/index.ts',
+			]);
+		});
+
+		it('should index ignored files when gitignore is disabled', async () => {
+			rootPath = await createFixture({
+				'.gitignore': '*.log\n',
+				'debug.log': '',
+			});
+
+			expect(await getFiles({gitignore: false})).toEqual([
+				'.gitignore',
+				'debug.log',
+			]);
+		});
+	});
+
+	describe('Include and exclude', () => {
+		it('should only index the files matching the include globs', async () => {
+			rootPath = await createFixture({
+				'package.json': '{}',
+				'src/index.ts': '',
+				'src/styles.css': '',
+			});
+
+			expect(await getFiles({include: ['**/*.ts', 'package.json']})).toEqual(
+				['package.json', 'src/index.ts'],
+			);
+		});
+
+		it('should leave out the excluded files and directories', async () => {
+			rootPath = await createFixture({
+				'package.json': '{}',
+				'examples/demo/package.json': '{}',
+				'src/index.ts': '',
+				'src/index.test.ts': '',
+			});
+
+			expect(await getFiles({exclude: ['examples', '**/*.test.ts']})).toEqual(
+				['package.json', 'src/index.ts'],
+			);
+		});
+
+		it('should keep the options and the files in a sub index', async () => {
+			rootPath = await createFixture({
+				'packages/api/package.json': '{}',
+				'packages/api/debug.log': '',
+				'packages/web/package.json': '{}',
+			});
+			const index = new FileIndex(rootPath, {exclude: ['**/*.log']});
+			const subIndex = index.createSubIndex(
+				path.join(rootPath, 'packages/api'),
+			);
+
+			expect(await subIndex.getFiles()).toEqual([
+				path.join(rootPath, 'packages/api/package.json'),
+			]);
+			expect(subIndex.getParent()).toBe(index);
+		});
+	});
+});
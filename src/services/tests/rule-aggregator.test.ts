@@ -0,0 +1,214 @@
+import {fileURLToPath} from 'node:url';
+import {describe, expect, it} from 'vitest';
+import {aggregateRules} from '../rule-aggregator.js';
+import {CodebaseScanner} from '../../scanners/codebase-scanner.js';
+import {
+	Category,
+	CommandPurpose,
+	Severity,
+	type AiRule,
+} from '../../types.js';
+
+describe('aggregateRules', () => {
+	describe('Rule order', () => {
+		const testRules: AiRule[] = [
+			{rule: 'Use Jest for unit tests.', category: Category.Jest},
+			{
+				rule: 'Use pnpm as the package manager.',
+				category: Category.PackageManager,
+				severity: Severity.High,
+			},
+			{rule: 'Run `pnpm test` to run the tests.', category: Category.Commands},
+			{rule: 'Follow the existing code style.'},
+			{
+				rule: 'Do not commit the lock files of other package managers.',
+				category: Category.PackageManager,
+			},
+			{rule: 'Run `pnpm build` to build.', category: Category.Commands},
+		];
+
+		it('should sort rules by category, severity and text', () => {
+			const rules = aggregateRules(testRules).map((rule) => rule.rule);
+
+			expect(rules).toEqual([
+				'Follow the existing code style.',
+				'Use pnpm as the package manager.',
+				'Do not commit the lock files of other package managers.',
+				'Use Jest for unit tests.',
+				'Run `pnpm build` to build.',
+				'Run `pnpm test` to run the tests.',
+			]);
+		});
+
+		it('should not depend on the order of the scanned rules', () => {
+			const rules = aggregateRules(testRules);
+			const reversedRules = aggregateRules([...testRules].reverse());
+
+			expect(reversedRules).toEqual(rules);
+		});
+
+		it('should sort custom categories after the built-in ones by name', () => {
+			const rules = aggregateRules([
+				{rule: 'Use feature flags.', category: 'Feature Flags'},
+				{
+					rule: 'Write reversible migrations.',
+					category: 'Database Migrations',
+				},
+				{rule: 'Use Jest for unit tests.', category: Category.Jest},
+			]).map((rule) => rule.rule);
+
+			expect(rules).toEqual([
+				'Use Jest for unit tests.',
+				'Write reversible migrations.',
+				'Use feature flags.',
+			]);
+		});
+	});
+
+	describe('Repeated scans', () => {
+		it('should produce byte-identical output when scanning twice', async () => {
+			const examplePath = fileURLToPath(
+				new URL('../../../examples/monorepo-1', import.meta.url),
+			);
+
+			const scanOutput = async () => {
+				const scanner = new CodebaseScanner(examplePath, {perPackage: true});
+				const rules = await scanner.scan();
+				return {
+					markdown: await scanner.getOutput(),
+					json: JSON.stringify(rules),
+				};
+			};
+
+			const first = await scanOutput();
+			const second = await scanOutput();
+
+			expect(first.json.length).toBeGreaterThan(2);
+			expect(second.markdown).toBe(first.markdown);
+			expect(second.json).toBe(first.json);
+		});
+	});
+
+	describe('Commands', () => {
+		const testRules: AiRule[] = [
+			{
+				rule: 'Run `pnpm run test`.',
+				category: Category.Commands,
+				scanner: 'scripts',
+				files: ['package.json'],
+				commands: [{command: 'pnpm run test', purpose: CommandPurpose.Test}],
+			},
+			{
+				rule: 'Run `make test`.',
+				category: Category.Commands,
+				scanner: 'makefile',
+				files: ['Makefile'],
+				commands: [{command: 'make test', purpose: CommandPurpose.Test}],
+			},
+			{
+				rule: 'Run `make build`.',
+				category: Category.Commands,
+				scanner: 'makefile',
+				files: ['Makefile'],
+				commands: [{command: 'make build', purpose: CommandPurpose.Build}],
+			},
+			{
+				rule: 'CI checks changes with `pnpm test`. Run the same commands locally before pushing.',
+				category: Category.CICD,
+				scanner: 'ci',
+				files: ['.github/workflows/ci.yml'],
+				commands: [{command: 'pnpm test', purpose: CommandPurpose.Test}],
+			},
+		];
+
+		it('should merge the commands of all scanners by purpose', () => {
+			const rules = aggregateRules(testRules);
+			const commandRules = rules.filter(
+				(rule) => rule.category === Category.Commands,
+			);
+
+			expect(commandRules.map((rule) => rule.rule)).toEqual([
+				'Run `make build` to build the project.',
+				'Run `pnpm run test` or `make test` to run the tests.',
+			]);
+			expect(commandRules[1].sources).toEqual([
+				'scripts',
+				'makefile',
+				'ci',
+			]);
+			expect(commandRules[1].files).toEqual([
+				'package.json',
+				'Makefile',
+				'.github/workflows/ci.yml',
+			]);
+		});
+
+		it('should keep the rules of other categories naming commands', () => {
+			const rules = aggregateRules(testRules);
+
+			expect(rules.map((rule) => rule.category)).toContain(Category.CICD);
+		});
+	});
+
+	describe('Conflicts', () => {
+		it('should add a rule to resolve rules choosing different options', () => {
+			const rules = aggregateRules([
+				{
+					rule: 'Use npm as the package manager.',
+					category: Category.PackageManager,
+					files: ['package-lock.json'],
+				},
+				{rule: 'Use pnpm for package management.', files: ['package.json']},
+				{rule: 'Use Vitest for unit tests.', category: Category.Testing},
+			]);
+
+			expect(rules.map((rule) => [rule.rule, rule.conflictGroup])).toEqual([
+				['Use pnpm for package management.', 'package-manager'],
+				[
+					'The project is set up for more than one package manager: npm and pnpm. Ask which package manager the project uses before installing or adding dependencies, and remove the lock files of the others.',
+					'package-manager',
+				],
+				['Use npm as the package manager.', 'package-manager'],
+				['Use Vitest for unit tests.', undefined],
+			]);
+			expect(rules[1].severity).toBe(Severity.Critical);
+		});
+
+		it('should not mark rules choosing the same option', () => {
+			const rules = aggregateRules([
+				{
+					rule: 'Use Prettier for code formatting.',
+					category: Category.Prettier,
+				},
+				{
+					rule: 'Formatting is enforced by Prettier.',
+					category: Category.Prettier,
+				},
+			]);
+
+			expect(rules).toHaveLength(2);
+			expect(rules.every((rule) => !rule.conflictGroup)).toBe(true);
+		});
+
+		it('should not add a rule for a conflict described by a scanner', () => {
+			const rules = aggregateRules([
+				{
+					rule: 'Use Prettier for code formatting.',
+					category: Category.Prettier,
+				},
+				{rule: 'Use Biome for formatting.', category: Category.Biome},
+				{
+					rule: 'Both Biome and Prettier are configured.',
+					category: Category.Biome,
+					conflictGroup: 'formatter',
+				},
+			]);
+
+			expect(rules.map((rule) => [rule.rule, rule.conflictGroup])).toEqual([
+				['Use Prettier for code formatting.', 'formatter'],
+				['Both Biome and Prettier are configured.', 'formatter'],
+				['Use Biome for formatting.', 'formatter'],
+			]);
+		});
+	});
+});
@@ -0,0 +1,80 @@
+import {describe, expect, it} from 'vitest';
+import {aggregateRules} from '../rule-aggregator.js';
+import {estimateTokens, limitRules} from '../rule-budget.js';
+import {Category, Confidence, Severity, type AiRule} from '../../types.js';
+
+describe('limitRules', () => {
+	const testRules = aggregateRules([
+		{
+			rule: 'Use pnpm as the package manager.',
+			category: Category.PackageManager,
+			severity: Severity.Critical,
+		},
+		{rule: 'Use Jest for unit tests.', category: Category.Jest},
+		{
+			rule: 'Tests are colocated with the source files.',
+			category: Category.Testing,
+			confidence: Confidence.Medium,
+		},
+		{
+			rule: 'Use the latest LTS version of Node.js, as no version is pinned in .nvmrc or the package.json engines.',
+			category: Category.NodeVersion,
+			severity: Severity.Info,
+		},
+		{rule: 'Use TypeScript strict mode.', category: Category.General},
+	]);
+
+	it('should keep the rules when they fit the budget', () => {
+		expect(limitRules(testRules, {maxRules: 5})).toBe(testRules);
+	});
+
+	it('should drop the least important and least certain rules first', () => {
+		const rules = limitRules(testRules, {maxRules: 4});
+
+		expect(rules.map((rule) => rule.rule)).toEqual([
+			'Use TypeScript strict mode.',
+			'Use pnpm as the package manager.',
+			'Use Jest for unit tests.',
+			'2 lower-priority rules were left out to fit the size limit of the instructions. Follow the conventions of the existing code where no rule applies.',
+		]);
+		expect(rules.at(-1)?.values).toEqual({omitted: '2'});
+	});
+
+	it('should fit the rules and the note in the token budget', () => {
+		const tokenBudget = 45;
+		const rules = limitRules(testRules, {tokenBudget});
+		const tokens = rules.reduce(
+			(sum, rule) => sum + estimateTokens(rule),
+			0,
+		);
+
+		expect(rules.map((rule) => rule.rule)).toContain(
+			'Use pnpm as the package manager.',
+		);
+		expect(rules.length).toBeLessThan(testRules.length);
+		expect(tokens).toBeLessThanOrEqual(tokenBudget);
+	});
+
+	it('should leave out the note when it leaves no room for a rule', () => {
+		expect(
+			limitRules(testRules, {maxRules: 1}).map((rule) => rule.rule),
+		).toEqual(['Use pnpm as the package manager.']);
+		expect(
+			limitRules(testRules, {tokenBudget: 10}).map((rule) => rule.rule),
+		).toEqual(['Use pnpm as the package manager.']);
+	});
+
+	it('should not keep any rule when none fits the budget', () => {
+		expect(limitRules(testRules, {tokenBudget: 1})).toEqual([]);
+	});
+
+	it('should not depend on the order of the rules', () => {
+		const reversedRules: AiRule[] = [...testRules].reverse();
+		const getTexts = (rules: AiRule[]) =>
+			limitRules(rules, {maxRules: 3})
+				.map((rule) => rule.rule)
+				.sort();
+
+		expect(getTexts(reversedRules)).toEqual(getTexts(testRules));
+	});
+});
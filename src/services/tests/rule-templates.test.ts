@@ -0,0 +1,148 @@
+import {describe, expect, it} from 'vitest';
+import {aggregateRules} from '../rule-aggregator.js';
+import {applyRuleTemplates, renderTemplate} from '../rule-templates.js';
+import {Category} from '../../types.js';
+
+describe('Rule templates', () => {
+	const testRules = aggregateRules([
+		{
+			rule: 'Use pnpm as the package manager.',
+			category: Category.PackageManager,
+			scanner: 'package-manager',
+			values: {packageManager: 'pnpm'},
+			files: ['pnpm-lock.yaml'],
+		},
+		{
+			rule: 'Use Jest for unit tests.',
+			category: Category.Jest,
+			scanner: 'jest',
+		},
+	]);
+	const packageManagerId =
+		testRules.find((rule) => rule.scanner === 'package-manager')?.id ?? '';
+
+	describe('renderTemplate', () => {
+		it('should replace the placeholders with their values', () => {
+			expect(
+				renderTemplate('Always run {{ packageManager }} install.', {
+					packageManager: 'pnpm',
+				}),
+			).toBe('Always run pnpm install.');
+		});
+
+		it('should keep unknown placeholders', () => {
+			expect(renderTemplate('Use {{version}}.', {})).toBe('Use {{version}}.');
+		});
+	});
+
+	describe('applyRuleTemplates', () => {
+		it('should replace the text of a rule by its id', () => {
+			const rules = applyRuleTemplates(testRules, {
+				'package-manager': {
+					[packageManagerId]: 'Run {{packageManager}} only, see {{files}}.',
+				},
+			});
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Run pnpm only, see pnpm-lock.yaml.',
+				'Use Jest for unit tests.',
+			]);
+			expect(rules[0].id).toBe(packageManagerId);
+		});
+
+		it('should apply the template of the root rule to package rules', () => {
+			const packageRules = aggregateRules([
+				{
+					rule: 'Use pnpm as the package manager.',
+					category: Category.PackageManager,
+					scanner: 'package-manager',
+					values: {packageManager: 'pnpm'},
+					package: 'packages/web',
+				},
+			]);
+
+			const rules = applyRuleTemplates(packageRules, {
+				'package-manager': {[packageManagerId]: 'Run {{packageManager}} only.'},
+			});
+
+			expect(rules[0].id).not.toBe(packageManagerId);
+			expect(rules.map((rule) => rule.rule)).toEqual(['Run pnpm only.']);
+		});
+
+		it('should replace the text of the rules of a category', () => {
+			const rules = applyRuleTemplates(testRules, {
+				jest: {
+					[Category.Jest]: 'Write unit tests with Jest.',
+					'*': 'Never used.',
+				},
+			});
+
+			expect(rules.map((rule) => rule.rule)).toContain(
+				'Write unit tests with Jest.',
+			);
+		});
+
+		it('should apply the "*" template to every rule of a scanner', () => {
+			const rules = applyRuleTemplates(testRules, {
+				jest: {'*': '{{category}}: {{rule}}'},
+			});
+
+			expect(rules.map((rule) => rule.rule)).toContain(
+				'Jest: Use Jest for unit tests.',
+			);
+		});
+
+		it('should find templates by the class name of the scanner', () => {
+			const rules = applyRuleTemplates(
+				testRules,
+				{JestScanner: {'*': 'Write unit tests with Jest.'}},
+				new Map([['jest', 'JestScanner']]),
+			);
+
+			expect(rules.map((rule) => rule.rule)).toContain(
+				'Write unit tests with Jest.',
+			);
+		});
+
+		it('should remove the rules with an empty template', () => {
+			const rules = applyRuleTemplates(testRules, {jest: {'*': ''}});
+
+			expect(rules.map((rule) => rule.scanner)).toEqual(['package-manager']);
+		});
+
+		it('should merge the rules that get the same text', () => {
+			const lintingRules = aggregateRules([
+				{rule: 'Use ESLint.', category: Category.Linting, scanner: 'linting'},
+				{rule: 'Use XO.', category: Category.Linting, scanner: 'xo'},
+			]);
+			const eslintId =
+				lintingRules.find((rule) => rule.scanner === 'linting')?.id ?? '';
+
+			const rules = applyRuleTemplates(lintingRules, {
+				linting: {'*': 'Run `npm run lint`.'},
+				xo: {'*': 'Run `npm run lint`.'},
+			});
+
+			expect(rules).toHaveLength(1);
+			expect(rules[0].id).toBe(eslintId);
+			expect(rules[0].sources).toEqual(['linting', 'xo']);
+		});
+
+		it('should sort the rules by their new text', () => {
+			const lintingRules = aggregateRules([
+				{rule: 'Use ESLint.', category: Category.Linting, scanner: 'linting'},
+				{rule: 'Use XO.', category: Category.Linting, scanner: 'xo'},
+			]);
+
+			const rules = applyRuleTemplates(lintingRules, {
+				linting: {'*': 'Lint with ESLint.'},
+				xo: {'*': 'Check the code with XO.'},
+			});
+
+			expect(rules.map((rule) => rule.rule)).toEqual([
+				'Check the code with XO.',
+				'Lint with ESLint.',
+			]);
+		});
+	});
+});
@@ -0,0 +1,167 @@
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {afterEach, describe, expect, it} from 'vitest';
+import {cacheFileName, ScanCache} from '../scan-cache.js';
+import {Category, type AiRule} from '../../types.js';
+import {
+	createFixture,
+	removeFixture,
+} from '../../scanners/tests/fixture.js';
+
+const testRules: AiRule[] = [
+	{rule: 'Use pnpm as the package manager.', category: Category.PackageManager},
+];
+
+describe('ScanCache', () => {
+	let rootPath: string;
+
+	afterEach(async () => {
+		await removeFixture(rootPath);
+	});
+
+	describe('Entries', () => {
+		it('should reuse the rules saved by a previous run', async () => {
+			rootPath = await createFixture({});
+			const cache = new ScanCache(rootPath);
+			await cache.load();
+			cache.set('package-manager:.', 'fingerprint', testRules);
+			await cache.save();
+
+			const nextCache = new ScanCache(rootPath);
+			await nextCache.load();
+
+			expect(nextCache.get('package-manager:.', 'fingerprint')).toEqual(
+				testRules,
+			);
+			expect(nextCache.get('package-manager:.', 'changed')).toBeUndefined();
+		});
+
+		it('should drop the entries that were not used by the last run', async () => {
+			rootPath = await createFixture({});
+			const cache = new ScanCache(rootPath);
+			await cache.load();
+			cache.set('docker:.', 'fingerprint', testRules);
+			await cache.save();
+
+			await cache.load();
+			await cache.save();
+			await cache.load();
+
+			expect(cache.get('docker:.', 'fingerprint')).toBeUndefined();
+		});
+
+		it('should discard the cache of another version', async () => {
+			rootPath = await createFixture({
+				[cacheFileName]: JSON.stringify({
+					version: '0.0.0-other',
+					entries: {
+						'docker:.': {fingerprint: 'fingerprint', rules: testRules},
+					},
+				}),
+			});
+			const cache = new ScanCache(rootPath);
+			await cache.load();
+
+			expect(cache.get('docker:.', 'fingerprint')).toBeUndefined();
+		});
+
+		it('should start empty when the cache file is unreadable', async () => {
+			rootPath = await createFixture({[cacheFileName]: '{'});
+			const cache = new ScanCache(rootPath);
+			await cache.load();
+
+			expect(cache.get('docker:.', 'fingerprint')).toBeUndefined();
+		});
+
+		it('should delete the cache file', async () => {
+			rootPath = await createFixture({});
+			const cache = new ScanCache(rootPath);
+			await cache.save();
+
+			expect(await cache.clear()).toBe(true);
+			expect(await cache.clear()).toBe(false);
+		});
+	});
+
+	describe('Fingerprint', () => {
+		it('should change when a watched file changes', async () => {
+			rootPath = await createFixture({'package.json': '{}', 'README.md': ''});
+			const files = [
+				path.join(rootPath, 'README.md'),
+				path.join(rootPath, 'package.json'),
+			];
+			const fingerprint = await new ScanCache(rootPath).getFingerprint(
+				files,
+				['package.json'],
+			);
+
+			await fs.writeFile(
+				path.join(rootPath, 'package.json'),
+				'{"name": "app"}',
+			);
+
+			expect(
+				await new ScanCache(rootPath).getFingerprint(files, ['package.json']),
+			).not.toBe(fingerprint);
+		});
+
+		it('should change when a file is added', async () => {
+			rootPath = await createFixture({'package.json': '{}'});
+			const cache = new ScanCache(rootPath);
+			const files = [path.join(rootPath, 'package.json')];
+			const fingerprint = await cache.getFingerprint(files, ['package.json']);
+
+			expect(
+				await cache.getFingerprint(
+					[...files, path.join(rootPath, 'pnpm-lock.yaml')],
+					['package.json'],
+				),
+			).not.toBe(fingerprint);
+		});
+
+		it('should change when a file outside the index changes', async () => {
+			rootPath = await createFixture({
+				'package.json': '{}',
+				'node_modules/react/package.json': '{"version": "18.2.0"}',
+			});
+			const files = [path.join(rootPath, 'package.json')];
+			const installedFile = path.join(
+				rootPath,
+				'node_modules/react/package.json',
+			);
+			const fingerprint = await new ScanCache(rootPath).getFingerprint(
+				files,
+				['package.json'],
+				[installedFile],
+			);
+
+			await fs.writeFile(installedFile, '{"version": "19.0.10"}');
+
+			expect(
+				await new ScanCache(rootPath).getFingerprint(
+					files,
+					['package.json'],
+					[installedFile],
+				),
+			).not.toBe(fingerprint);
+		});
+
+		it('should not change when only an unwatched file changes', async () => {
+			rootPath = await createFixture({'package.json': '{}', 'README.md': ''});
+			const files = [
+				path.join(rootPath, 'README.md'),
+				path.join(rootPath, 'package.json'),
+			];
+			const fingerprint = await new ScanCache(rootPath).getFingerprint(
+				files,
+				['package.json'],
+			);
+
+			await fs.writeFile(path.join(rootPath, 'README.md'), '# App\n');
+
+			expect(
+				await new ScanCache(rootPath).getFingerprint(files, ['package.json']),
+			).toBe(fingerprint);
+		});
+	});
+});
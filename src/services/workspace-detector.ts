@@ -0,0 +1,247 @@
+import {existsSync} from 'node:fs';
+import fs from 'node:fs/promises';
+import path from 'node:path';
+import {globToRegex} from '../utils/glob.js';
+import type {FileIndex} from './file-index.js';
+import {logger} from './logger.js';
+
+const serviceLogger = logger.getLogger('WorkspaceDetector');
+
+/**
+ * Files that mark a directory as a workspace package
+ */
+const packageManifests = new Set(['package.json', 'project.json']);
+
+/**
+ * A package of a monorepo workspace
+ */
+export type WorkspacePackage = {
+	// Path of the package relative to the workspace root, using forward slashes
+	path: string;
+	// Name from the package.json of the package
+	name?: string;
+};
+
+/**
+ * Normalize a workspace pattern, e.g. "./packages/" to "packages"
+ */
+function normalizePattern(pattern: string): string {
+	return pattern
+		.trim()
+		.replace(/^\.\//, '')
+		.replace(/\/+$/, '');
+}
+
+/**
+ * Detects the packages of a monorepo from its workspace configuration
+ * Supports pnpm, npm and yarn workspaces, Lerna, Nx and Turborepo
+ */
+export class WorkspaceDetector {
+	/**
+	 * Constructor for WorkspaceDetector
+	 * @param rootPath Root directory of the workspace
+	 * @param fileIndex Index of the files in the workspace
+	 */
+	constructor(
+		private readonly rootPath: string,
+		private readonly fileIndex: FileIndex,
+	) {}
+
+	/**
+	 * Detect the packages of the workspace
+	 * @returns Packages sorted by path, empty if this is not a monorepo
+	 */
+	public async detectPackages(): Promise<WorkspacePackage[]> {
+		const patterns = await this.getWorkspacePatterns();
+		if (patterns.length === 0) {
+			return [];
+		}
+
+		serviceLogger.debug(`Workspace patterns: ${patterns.join(', ')}`);
+
+		const includes = patterns
+			.filter((pattern) => !pattern.startsWith('!'))
+			.map((pattern) => globToRegex(normalizePattern(pattern)));
+		const excludes = patterns
+			.filter((pattern) => pattern.startsWith('!'))
+			.map((pattern) => globToRegex(normalizePattern(pattern.slice(1))));
+
+		// Every directory with a package manifest is a candidate package
+		const packageDirectories = new Set<string>();
+		for (const file of await this.fileIndex.getFiles()) {
+			if (!packageManifests.has(path.basename(file))) {
+				continue;
+			}
+
+			const directory = path
+				.relative(this.rootPath, path.dirname(file))
+				.split(path.sep)
+				.join('/');
+
+			if (
+				directory &&
+				includes.some((regex) => regex.test(directory)) &&
+				!excludes.some((regex) => regex.test(directory))
+			) {
+				packageDirectories.add(directory);
+			}
+		}
+
+		return Promise.all(
+			[...packageDirectories].sort().map(async (directory) => {
+				const name = await this.readPackageName(directory);
+				return name ? {path: directory, name} : {path: directory};
+			}),
+		);
+	}
+
+	/**
+	 * Collect the package patterns of all workspace configuration files
+	 */
+	private async getWorkspacePatterns(): Promise<string[]> {
+		const patterns = [
+			...(await this.getPnpmPatterns()),
+			...(await this.getPackageJsonPatterns()),
+			...(await this.getLernaPatterns()),
+			...(await this.getNxPatterns()),
+		];
+
+		// Turborepo relies on the package manager workspaces, fall back to its
+		// conventional layout when none are configured
+		if (
+			patterns.length === 0 &&
+			existsSync(path.join(this.rootPath, 'turbo.json'))
+		) {
+			patterns.push('apps/*', 'packages/*');
+		}
+
+		return [...new Set(patterns)];
+	}
+
+	/**
+	 * Read the packages list of pnpm-workspace.yaml
+	 */
+	private async getPnpmPatterns(): Promise<string[]> {
+		const content = await this.readFile('pnpm-workspace.yaml');
+		if (!content) {
+			return [];
+		}
+
+		const patterns: string[] = [];
+		let inPackages = false;
+
+		for (const line of content.split('\n')) {
+			const trimmedLine = line.replace(/#.*$/, '').trim();
+
+			// A new top-level key ends the packages list
+			if (/^\S/.test(line) && trimmedLine) {
+				inPackages = trimmedLine === 'packages:';
+				continue;
+			}
+
+			const item = /^-\s*["']?([^"']+)["']?$/.exec(trimmedLine)?.[1];
+			if (inPackages && item) {
+				patterns.push(item);
+			}
+		}
+
+		return patterns;
+	}
+
+	/**
+	 * Read the workspaces field of package.json, used by npm, yarn and bun
+	 */
+	private async getPackageJsonPatterns(): Promise<string[]> {
+		const packageJson = await this.readJson('package.json');
+		const workspaces = packageJson?.workspaces as
+			| string[]
+			| {packages?: string[]}
+			| undefined;
+
+		if (Array.isArray(workspaces)) {
+			return workspaces;
+		}
+
+		return workspaces?.packages ?? [];
+	}
+
+	/**
+	 * Read the packages field of lerna.json
+	 */
+	private async getLernaPatterns(): Promise<string[]> {
+		const lernaJson = await this.readJson('lerna.json');
+		if (!lernaJson) {
+			return [];
+		}
+
+		return (lernaJson.packages as string[] | undefined) ?? ['packages/*'];
+	}
+
+	/**
+	 * Read the apps and libs directories of nx.json
+	 */
+	private async getNxPatterns(): Promise<string[]> {
+		const nxJson = await this.readJson('nx.json');
+		if (!nxJson) {
+			return [];
+		}
+
+		const layout = nxJson.workspaceLayout as
+			| {appsDir?: string; libsDir?: string}
+			| undefined;
+
+		return [
+			`${layout?.appsDir ?? 'apps'}/**`,
+			`${layout?.libsDir ?? 'libs'}/**`,
+		];
+	}
+
+	/**
+	 * Read the name of a package from its package.json
+	 */
+	private async readPackageName(
+		directory: string,
+	): Promise<string | undefined> {
+		const packageJson = await this.readJson(
+			path.join(directory, 'package.json'),
+		);
+		return typeof packageJson?.name === 'string' ? packageJson.name : undefined;
+	}
+
+	/**
+	 * Read and parse a JSON file of the workspace
+	 */
+	private async readJson(
+		relativePath: string,
+	): Promise<Record<string, unknown> | undefined> {
+		const content = await this.readFile(relativePath);
+		if (!content) {
+			return undefined;
+		}
+
+		try {
+			return JSON.parse(content) as Record<string, unknown>;
+		} catch (error) {
+			serviceLogger.error(`Error parsing ${relativePath}`, error);
+			return undefined;
+		}
+	}
+
+	/**
+	 * Read a file of the workspace, undefined if it does not exist
+	 */
+	private async readFile(relativePath: string): Promise<string | undefined> {
+		const filePath = path.join(this.rootPath, relativePath);
+
+		if (!existsSync(filePath)) {
+			return undefined;
+		}
+
+		try {
+			return await fs.readFile(filePath, 'utf8');
+		} catch (error) {
+			serviceLogger.error(`Error reading ${filePath}`, error);
+			return undefined;
+		}
+	}
+}
@@ -1,3 +1,6 @@
+import type {ProjectCommand} from './types/command.js';
+import type {Severity} from './types/severity.js';
+
 export enum Category {
 	General = 'general',
 	PackageManager = 'package_manager',
@@ -14,12 +17,90 @@ export enum Category {
 	Tailwind = 'tailwind',
 	Zustand = 'zustand',
 	Go = 'go',
+	Containerization = 'containerization',
+	Kubernetes = 'kubernetes',
+	Commands = 'commands',
+	Python = 'python',
+	Infrastructure = 'infrastructure',
+	React = 'react',
+	Biome = 'biome',
+	API = 'api',
+	Database = 'database',
+	Rust = 'rust',
+	CICD = 'ci_cd',
+	Configuration = 'configuration',
+	Authentication = 'authentication',
+	StateManagement = 'state_management',
+	Mobile = 'mobile',
+	Styling = 'styling',
+	Messaging = 'messaging',
+	Internationalization = 'internationalization',
+	Observability = 'observability',
+	Accessibility = 'accessibility',
 }
 
+/**
+ * Fixed order of the categories when sorting rules, the declaration order of
+ * Category
+ */
+export const categoryOrder: readonly Category[] = Object.values(Category);
+
+/**
+ * Category of a rule, a built-in category or the custom title of a declarative
+ * rule of the config file, e.g. "Database Migrations"
+ */
+export type RuleCategory = Category | string;
+
 export type AiRule = {
+	// Stable identifier derived from the rule text, set when rules are aggregated
+	id?: string;
 	rule: string;
-	category?: Category;
+	category?: RuleCategory;
+	// Importance of the rule, defaults to normal
+	severity?: Severity;
+	// How certain the detection is, from 0 to 1, defaults to certain
+	confidence?: number;
+	// Name of the scanner that emitted the rule, e.g. "package-manager"
+	scanner?: string;
+	// Names of all scanners that emitted the rule, when merged from duplicates
+	sources?: string[];
+	// Files the rule was derived from, relative to the scanned directory
+	files?: string[];
+	// Values detected by the scanner, e.g. {version: '20'}, which rule
+	// templates of the config file can use as {{version}}
+	values?: Record<string, string>;
+	// Commands of the project the rule names, which are collected from all
+	// scanners into one Commands rule per purpose
+	commands?: ProjectCommand[];
+	// Shared by rules that contradict each other and the rule asking to
+	// resolve the contradiction, e.g. "package-manager"
+	conflictGroup?: string;
+	// Workspace package the rule applies to, relative to the scanned directory
+	// Rules shared by the whole workspace have no package
+	package?: string;
 };
 
+/**
+ * A rule found by a scan, the public name of AiRule
+ */
+export type Rule = AiRule;
+
+export type {Scanner, ScannerConstructor} from './scanners/base/scanner.js';
+export type {
+	DeclarativeRule,
+	PsstConfig,
+	RuleTemplates,
+} from './types/config.js';
+export type {
+	ScanOptions,
+	ScanResult,
+	ScannerReport,
+	ScannerStatus,
+} from './types/scan.js';
+export {CommandPurpose, type ProjectCommand} from './types/command.js';
+export {Confidence} from './types/confidence.js';
+export type {Evidence} from './types/evidence.js';
+export {Severity} from './types/severity.js';
+
 // Re-export CLI options types
 export {validateCliOptions, type CliOptions} from './types/cli-options.js';
@@ -1,4 +1,7 @@
 import {z} from 'zod';
+import type {Category} from '../types.js';
+import {OutputFormat} from './output-format.js';
+import {Severity} from './severity.js';
 
 /**
  * CLI options type definition
@@ -9,6 +12,32 @@ export type CliOptions = {
 	verbose?: boolean;
 	header?: boolean;
 	file?: string;
+	format?: OutputFormat;
+	mdc?: boolean;
+	concurrency?: number;
+	gitignore?: boolean;
+	minSeverity?: Severity;
+	minConfidence?: number;
+	minEvidence?: number;
+	maxRules?: number;
+	tokenBudget?: number;
+	category?: Category[];
+	excludeCategory?: Category[];
+	listCategories?: boolean;
+	perPackage?: boolean;
+	watch?: boolean;
+	config?: string;
+	only?: string[];
+	disable?: string[];
+	include?: string[];
+	exclude?: string[];
+	cache?: boolean;
+	clearCache?: boolean;
+	dryRun?: boolean;
+	check?: boolean;
+	repo?: string;
+	ref?: string;
+	summary?: boolean;
 };
 
 /**
@@ -20,6 +49,33 @@ export const cliOptionsSchema = z.object({
 	verbose: z.boolean().optional(),
 	header: z.boolean().optional(),
 	file: z.string().optional(),
+	format: z.nativeEnum(OutputFormat).optional(),
+	mdc: z.boolean().optional(),
+	concurrency: z.coerce.number().int().positive().optional(),
+	gitignore: z.boolean().optional(),
+	minSeverity: z.nativeEnum(Severity).optional(),
+	minConfidence: z.number().min(0).max(1).optional(),
+	minEvidence: z.coerce.number().int().positive().optional(),
+	maxRules: z.coerce.number().int().positive().optional(),
+	tokenBudget: z.coerce.number().int().positive().optional(),
+	// Category names are parsed and checked by the command line parser
+	category: z.array(z.custom<Category>()).optional(),
+	excludeCategory: z.array(z.custom<Category>()).optional(),
+	listCategories: z.boolean().optional(),
+	perPackage: z.boolean().optional(),
+	watch: z.boolean().optional(),
+	config: z.string().optional(),
+	only: z.array(z.string()).optional(),
+	disable: z.array(z.string()).optional(),
+	include: z.array(z.string()).optional(),
+	exclude: z.array(z.string()).optional(),
+	cache: z.boolean().optional(),
+	clearCache: z.boolean().optional(),
+	dryRun: z.boolean().optional(),
+	check: z.boolean().optional(),
+	repo: z.string().optional(),
+	ref: z.string().optional(),
+	summary: z.boolean().optional(),
 });
 
 /**
@@ -0,0 +1,22 @@
+/**
+ * What running a command of the project does
+ */
+export enum CommandPurpose {
+	Dev = 'dev',
+	Start = 'start',
+	Build = 'build',
+	Test = 'test',
+	Lint = 'lint',
+	Fix = 'fix',
+	Format = 'format',
+	Typecheck = 'typecheck',
+}
+
+/**
+ * A command detected by a scanner, e.g. a package.json script or a Makefile
+ * target
+ */
+export type ProjectCommand = {
+	command: string;
+	purpose: CommandPurpose;
+};
@@ -0,0 +1,15 @@
+/**
+ * How certain a scanner is about a detection, from 0 to 1
+ * - Certain: read from an explicit source such as a config file, a lock file
+ *   or a declared dependency
+ * - High: recognized from file contents, e.g. YAML files that look like
+ *   Kubernetes manifests
+ * - Medium: inferred from the project layout or code patterns
+ * - Low: a default assumed because nothing was detected
+ */
+export enum Confidence {
+	Certain = 1,
+	High = 0.8,
+	Medium = 0.5,
+	Low = 0.2,
+}
@@ -0,0 +1,108 @@
+import {z} from 'zod';
+import type {
+	Scanner,
+	ScannerConstructor,
+} from '../scanners/base/scanner.js';
+import {Severity} from './severity.js';
+
+/**
+ * A rule emitted when files matching a glob exist in the project
+ */
+export type DeclarativeRule = {
+	/**
+	 * Globs of the files that trigger the rule, relative to the scanned
+	 * directory. Globs without a slash match file names at any depth
+	 */
+	files: string | string[];
+	rule: string;
+	/**
+	 * Category of the rule, a built-in category or a custom title
+	 */
+	category?: string;
+	severity?: Severity;
+	/**
+	 * How certain the rule is, from 0 to 1, defaults to certain
+	 */
+	confidence?: number;
+};
+
+/**
+ * Templates replacing the text of rules, by scanner name and then by rule id
+ * or category. The "*" key applies to every rule of the scanner. Placeholders
+ * such as {{version}} are replaced with the values detected by the scanner
+ */
+export type RuleTemplates = Record<string, Record<string, string>>;
+
+/**
+ * Contents of a psst.config.js or psst.config.ts file
+ */
+export type PsstConfig = {
+	/**
+	 * Custom scanners, run after the built-in scanners
+	 * Classes are constructed for each scanned directory, objects are only
+	 * run for the scanned root directory
+	 */
+	scanners?: Array<Scanner | ScannerConstructor>;
+	rules?: DeclarativeRule[];
+	/**
+	 * Names of the only scanners to run
+	 */
+	only?: string[];
+	/**
+	 * Names of scanners to skip
+	 */
+	disable?: string[];
+	/**
+	 * Globs of the files to scan, relative to the scanned directory
+	 */
+	include?: string[];
+	/**
+	 * Globs of the files and directories to leave out of the scan
+	 */
+	exclude?: string[];
+	/**
+	 * Custom text of the rules of built-in and custom scanners
+	 */
+	templates?: RuleTemplates;
+};
+
+/**
+ * Check if a value is a scanner class or an object with a scan method
+ */
+function isScannerEntry(value: unknown): boolean {
+	return (
+		typeof value === 'function' ||
+		(typeof value === 'object' &&
+			value !== null &&
+			typeof (value as Scanner).scan === 'function')
+	);
+}
+
+/**
+ * Zod schema for validating a config file
+ */
+export const psstConfigSchema = z.object({
+	scanners: z
+		.array(
+			z.custom<Scanner | ScannerConstructor>(isScannerEntry, {
+				message: 'Expected a scanner class or an object with a scan() method',
+			}),
+		)
+		.optional(),
+	rules: z
+		.array(
+			z.object({
+				files: z.union([z.string(), z.array(z.string()).nonempty()]),
+				rule: z.string().min(1),
+				category: z.string().optional(),
+				severity: z.nativeEnum(Severity).optional(),
+				confidence: z.number().min(0).max(1).optional(),
+			}),
+		)
+		.optional(),
+	only: z.array(z.string()).optional(),
+	disable: z.array(z.string()).optional(),
+	include: z.array(z.string()).optional(),
+	exclude: z.array(z.string()).optional(),
+	templates: z.record(z.string(), z.record(z.string(), z.string())).optional(),
+});
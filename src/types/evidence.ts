@@ -0,0 +1,10 @@
+/**
+ * A file a scanner found that points to the technology it detects, e.g. a
+ * Dockerfile
+ */
+export type Evidence = {
+	// File the evidence was found in, relative to the scanned directory
+	file: string;
+	// How much the file counts towards the threshold, defaults to 1
+	weight?: number;
+};
@@ -0,0 +1,34 @@
+/**
+ * Version of the JSON output schema
+ * Incremented on breaking changes to the structure
+ */
+export const jsonSchemaVersion = 1;
+
+/**
+ * A single rule in the JSON output
+ */
+export type JsonRule = {
+	id?: string;
+	rule: string;
+	category: string;
+	severity: string;
+	confidence: number;
+	scanner?: string;
+	sources?: string[];
+	files?: string[];
+	commands?: Array<{command: string; purpose: string}>;
+	conflictGroup?: string;
+	package?: string;
+};
+
+/**
+ * Top-level structure of the JSON output
+ */
+export type JsonOutput = {
+	schemaVersion: number;
+	generator: {
+		name: string;
+		version: string;
+	};
+	rules: JsonRule[];
+};
@@ -0,0 +1,10 @@
+/**
+ * Output formats supported by the CLI
+ */
+export enum OutputFormat {
+	Markdown = 'markdown',
+	Cursor = 'cursor',
+	Copilot = 'copilot',
+	Claude = 'claude',
+	Json = 'json',
+}
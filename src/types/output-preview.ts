@@ -0,0 +1,16 @@
+/**
+ * A file an output builder would write, computed without writing it
+ */
+export type OutputPreview = {
+	filePath: string;
+	// Current content of the file, undefined if it does not exist
+	previousContent?: string;
+	content: string;
+	// Content between the psst-ai tags before and after the change, for files
+	// where psst-ai only manages a region. Previous is undefined if the file
+	// has no tags yet
+	managedRegion?: {
+		previous?: string;
+		next: string;
+	};
+};
@@ -0,0 +1,78 @@
+import type {CodebaseScannerOptions} from '../scanners/codebase-scanner.js';
+import type {AiRule} from '../types.js';
+import type {PsstConfig} from './config.js';
+
+/**
+ * Options of the programmatic scan API
+ */
+export type ScanOptions = Omit<CodebaseScannerOptions, 'config'> & {
+	/**
+	 * Directory to scan, defaults to the current directory
+	 */
+	directory?: string;
+	/**
+	 * Path of the config file, or the config itself
+	 * Defaults to the psst.config file of the scanned directory, if any
+	 */
+	config?: string | PsstConfig;
+};
+
+/**
+ * Result of a programmatic scan
+ */
+export type ScanResult = {
+	/**
+	 * Absolute path of the scanned directory
+	 */
+	directory: string;
+	/**
+	 * Version of psst-ai that produced the rules
+	 */
+	version: string;
+	/**
+	 * Aggregated rules, sorted by category in the order of `categoryOrder`,
+	 * then from most to least important, so the order does not depend on the
+	 * order of the scanners
+	 */
+	rules: AiRule[];
+	/**
+	 * What each scanner found, including the scanners that were disabled
+	 */
+	scanners: ScannerReport[];
+};
+
+/**
+ * Outcome of a scanner in a scan
+ * - matched: the scanner emitted rules
+ * - no-match: the scanner ran and found nothing
+ * - failed: the scanner threw an error
+ * - disabled: the scanner was skipped by the only or disable options
+ */
+export type ScannerStatus = 'matched' | 'no-match' | 'failed' | 'disabled';
+
+/**
+ * What a scanner found in a scan, for the summary of a scan
+ */
+export type ScannerReport = {
+	/**
+	 * Name of the scanner, e.g. "package-manager"
+	 */
+	name: string;
+	/**
+	 * Workspace package path, undefined for the scanned directory itself
+	 */
+	package?: string;
+	status: ScannerStatus;
+	/**
+	 * Whether the rules were reused from the scan cache
+	 */
+	cached: boolean;
+	/**
+	 * Number of rules emitted, before filtering and merging duplicates
+	 */
+	rules: number;
+	/**
+	 * Files the rules were derived from, relative to the scanned directory
+	 */
+	files: string[];
+};
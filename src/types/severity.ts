@@ -0,0 +1,9 @@
+/**
+ * Importance of a rule, from most to least important
+ */
+export enum Severity {
+	Critical = 'critical',
+	High = 'high',
+	Normal = 'normal',
+	Info = 'info',
+}
@@ -1,4 +1,4 @@
-import {Category} from '../types.js';
+import {Category, type RuleCategory} from '../types.js';
 
 /**
  * Maps each Category enum value to its display title
@@ -19,15 +19,49 @@ const categoryDisplayTitles: Record<Category, string> = {
 	[Category.Tailwind]: 'Tailwind CSS',
 	[Category.Zustand]: 'Zustand',
 	[Category.Go]: 'Go',
+	[Category.Containerization]: 'Containerization',
+	[Category.Kubernetes]: 'Kubernetes',
+	[Category.Commands]: 'Commands',
+	[Category.Python]: 'Python',
+	[Category.Infrastructure]: 'Infrastructure',
+	[Category.React]: 'React',
+	[Category.Biome]: 'Biome',
+	[Category.API]: 'API',
+	[Category.Database]: 'Database',
+	[Category.Rust]: 'Rust',
+	[Category.CICD]: 'CI/CD',
+	[Category.Configuration]: 'Configuration',
+	[Category.Authentication]: 'Authentication',
+	[Category.StateManagement]: 'State Management',
+	[Category.Mobile]: 'Mobile',
+	[Category.Styling]: 'Styling',
+	[Category.Messaging]: 'Messaging',
+	[Category.Internationalization]: 'Internationalization',
+	[Category.Observability]: 'Observability',
+	[Category.Accessibility]: 'Accessibility',
 };
 
 /**
- * Formats a Category enum value to its display title
- * @param category The category enum value
+ * Check if the category of a rule is built in rather than a custom title
+ * @param category The category of a rule
+ * @returns True for the values of the Category enum
+ */
+export function isBuiltInCategory(
+	category: RuleCategory,
+): category is Category {
+	return Object.hasOwn(categoryDisplayTitles, category);
+}
+
+/**
+ * Formats the category of a rule to its display title
+ * Custom categories are already titles and are shown as written
+ * @param category The category enum value or custom title
  * @returns The formatted display title for the category
  */
-export function formatCategoryTitle(category: Category): string {
-	return categoryDisplayTitles[category] || category;
+export function formatCategoryTitle(category: RuleCategory): string {
+	return isBuiltInCategory(category)
+		? categoryDisplayTitles[category]
+		: category;
 }
 
 /**
@@ -48,3 +82,21 @@ export function formatToDisplayTitle(value: string): string {
 		.map((word) => word.charAt(0).toUpperCase() + word.slice(1).toLowerCase())
 		.join(' ');
 }
+
+/**
+ * Parse a category given as its value or display title, e.g. "testing",
+ * "package-manager" or "CI/CD"
+ * @param value The value to parse, matched case insensitively
+ * @returns The category, undefined if no category matches
+ */
+export function parseCategory(value: string): Category | undefined {
+	const normalize = (text: string) =>
+		text.trim().toLowerCase().replaceAll(/[\s-]/g, '_');
+	const normalizedValue = normalize(value);
+
+	return Object.values(Category).find(
+		(category) =>
+			normalize(category) === normalizedValue ||
+			normalize(formatCategoryTitle(category)) === normalizedValue,
+	);
+}
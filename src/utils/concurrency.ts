@@ -0,0 +1,39 @@
+import os from 'node:os';
+
+/**
+ * Get the default number of tasks to run concurrently (the CPU count)
+ */
+export function getDefaultConcurrency(): number {
+	return os.availableParallelism();
+}
+
+/**
+ * Map items through an async function with at most `limit` calls in flight
+ * Results are returned in the order of the input items, regardless of
+ * the order in which the calls complete
+ * @param items Items to process
+ * @param limit Maximum number of concurrent calls
+ * @param mapper Async function to apply to each item
+ * @returns Results in input order
+ */
+export async function mapWithConcurrency<T, R>(
+	items: readonly T[],
+	limit: number,
+	mapper: (item: T, index: number) => Promise<R>,
+): Promise<R[]> {
+	const results: R[] = Array.from({length: items.length});
+	let nextIndex = 0;
+
+	const worker = async () => {
+		while (nextIndex < items.length) {
+			const index = nextIndex++;
+			// eslint-disable-next-line no-await-in-loop
+			results[index] = await mapper(items[index], index);
+		}
+	};
+
+	const workerCount = Math.max(1, Math.min(limit, items.length));
+	await Promise.all(Array.from({length: workerCount}, async () => worker()));
+
+	return results;
+}
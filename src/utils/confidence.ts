@@ -0,0 +1,44 @@
+import type {AiRule} from '../types.js';
+import {Confidence} from '../types/confidence.js';
+
+/**
+ * Confidence levels by name, as accepted by --min-confidence
+ */
+const confidenceLevels: Record<string, Confidence> = {
+	certain: Confidence.Certain,
+	high: Confidence.High,
+	medium: Confidence.Medium,
+	low: Confidence.Low,
+};
+
+/**
+ * Get the confidence of a rule, rules without a confidence are certain
+ * @param rule The rule
+ * @returns The rule confidence, from 0 to 1
+ */
+export function getConfidence(rule: AiRule): number {
+	return rule.confidence ?? Confidence.Certain;
+}
+
+/**
+ * Check if a rule is at least as confident as the given confidence
+ * @param rule The rule
+ * @param minConfidence Minimum confidence to keep, from 0 to 1
+ * @returns True if the rule should be kept
+ */
+export function meetsMinConfidence(
+	rule: AiRule,
+	minConfidence: number,
+): boolean {
+	return getConfidence(rule) >= minConfidence;
+}
+
+/**
+ * Parse a confidence given as a number or a level name, e.g. "0.5" or "medium"
+ * @param value The value to parse
+ * @returns The confidence, NaN if the value is not a confidence
+ */
+export function parseConfidence(value: string): number {
+	const level = confidenceLevels[value.trim().toLowerCase()];
+	return level ?? Number(value);
+}
@@ -0,0 +1,137 @@
+/**
+ * A line of a diff between two texts
+ */
+export type DiffLine = {
+	type: 'unchanged' | 'added' | 'removed';
+	text: string;
+};
+
+/**
+ * Prefixes of the lines of a unified diff
+ */
+const linePrefixes: Record<DiffLine['type'], string> = {
+	unchanged: ' ',
+	added: '+',
+	removed: '-',
+};
+
+/**
+ * Compare two texts line by line
+ * Uses the longest common subsequence, which is fast enough for the few
+ * hundred lines of an instructions file
+ * @param previous Text before the change
+ * @param next Text after the change
+ * @returns Lines of both texts in order, marked as unchanged, added or removed
+ */
+export function diffLines(previous: string, next: string): DiffLine[] {
+	const previousLines = previous.split('\n');
+	const nextLines = next.split('\n');
+
+	// Length of the longest common subsequence of the lines from each position,
+	// rows are lines of the previous text and columns lines of the next text
+	const lengths = Array.from({length: previousLines.length + 1}, () =>
+		Array.from({length: nextLines.length + 1}, () => 0),
+	);
+	for (let row = previousLines.length - 1; row >= 0; row--) {
+		for (let column = nextLines.length - 1; column >= 0; column--) {
+			lengths[row][column] =
+				previousLines[row] === nextLines[column]
+					? lengths[row + 1][column + 1] + 1
+					: Math.max(lengths[row + 1][column], lengths[row][column + 1]);
+		}
+	}
+
+	const lines: DiffLine[] = [];
+	let previousIndex = 0;
+	let nextIndex = 0;
+
+	while (previousIndex < previousLines.length && nextIndex < nextLines.length) {
+		if (previousLines[previousIndex] === nextLines[nextIndex]) {
+			lines.push({type: 'unchanged', text: previousLines[previousIndex]});
+			previousIndex++;
+			nextIndex++;
+		} else if (
+			lengths[previousIndex + 1][nextIndex] >=
+			lengths[previousIndex][nextIndex + 1]
+		) {
+			lines.push({type: 'removed', text: previousLines[previousIndex]});
+			previousIndex++;
+		} else {
+			lines.push({type: 'added', text: nextLines[nextIndex]});
+			nextIndex++;
+		}
+	}
+
+	for (const text of previousLines.slice(previousIndex)) {
+		lines.push({type: 'removed', text});
+	}
+
+	for (const text of nextLines.slice(nextIndex)) {
+		lines.push({type: 'added', text});
+	}
+
+	return lines;
+}
+
+/**
+ * Format the changes between two texts as a unified diff without file headers
+ * @param previous Text before the change
+ * @param next Text after the change
+ * @param contextLines Number of unchanged lines shown around each change
+ * @returns Hunks of changed lines, empty if the texts are equal
+ */
+export function formatDiff(
+	previous: string,
+	next: string,
+	contextLines = 3,
+): string {
+	const lines = diffLines(previous, next);
+	const changedIndexes = lines.flatMap((line, index) =>
+		line.type === 'unchanged' ? [] : [index],
+	);
+	const isShown = (index: number) =>
+		changedIndexes.some(
+			(changedIndex) => Math.abs(changedIndex - index) <= contextLines,
+		);
+
+	const hunks: string[] = [];
+	let hunk: string[] = [];
+	let previousStart = 0;
+	let nextStart = 0;
+	let previousCount = 0;
+	let nextCount = 0;
+	let previousLine = 1;
+	let nextLine = 1;
+
+	const closeHunk = () => {
+		if (hunk.length > 0) {
+			hunks.push(
+				`@@ -${previousStart},${previousCount} +${nextStart},${nextCount} @@\n${hunk.join('\n')}`,
+			);
+			hunk = [];
+		}
+	};
+
+	for (const [index, line] of lines.entries()) {
+		if (isShown(index)) {
+			if (hunk.length === 0) {
+				previousStart = previousLine;
+				nextStart = nextLine;
+				previousCount = 0;
+				nextCount = 0;
+			}
+
+			hunk.push(`${linePrefixes[line.type]}${line.text}`);
+			previousCount += line.type === 'added' ? 0 : 1;
+			nextCount += line.type === 'removed' ? 0 : 1;
+		} else {
+			closeHunk();
+		}
+
+		previousLine += line.type === 'added' ? 0 : 1;
+		nextLine += line.type === 'removed' ? 0 : 1;
+	}
+
+	closeHunk();
+	return hunks.join('\n');
+}
@@ -0,0 +1,149 @@
+/**
+ * A single parsed pattern of a .gitignore file
+ */
+type GitignorePattern = {
+	regex: RegExp;
+	negated: boolean;
+	directoryOnly: boolean;
+};
+
+/**
+ * Escape a character for use in a regular expression
+ */
+function escapeRegex(character: string): string {
+	return character.replaceAll(/[.*+?^${}()|[\]\\/]/g, '\\$&');
+}
+
+/**
+ * Convert a gitignore glob to a regular expression source
+ * Supports *, ?, [...] character classes and ** directory wildcards
+ */
+function globToRegexSource(glob: string): string {
+	let source = '';
+	let index = 0;
+
+	while (index < glob.length) {
+		const character = glob[index];
+
+		if (glob.startsWith('**/', index)) {
+			// Leading or middle **/ matches zero or more directories
+			source += '(?:.*/)?';
+			index += 3;
+		} else if (glob.slice(index) === '/**') {
+			// Trailing /** matches everything inside
+			source += '/.*';
+			index += 3;
+		} else if (glob.startsWith('**', index)) {
+			source += '.*';
+			index += 2;
+		} else if (character === '*') {
+			source += '[^/]*';
+			index++;
+		} else if (character === '?') {
+			source += '[^/]';
+			index++;
+		} else if (character === '[' && glob.includes(']', index + 1)) {
+			const closingIndex = glob.indexOf(']', index + 1);
+			const characterClass = glob
+				.slice(index + 1, closingIndex)
+				.replace(/^!/, '^')
+				.replaceAll('\\', '\\\\');
+			source += `[${characterClass}]`;
+			index = closingIndex + 1;
+		} else if (character === '\\' && index + 1 < glob.length) {
+			source += escapeRegex(glob[index + 1]);
+			index += 2;
+		} else {
+			source += escapeRegex(character);
+			index++;
+		}
+	}
+
+	return source;
+}
+
+/**
+ * Parse a single .gitignore line
+ * @returns The pattern, or undefined for blank lines and comments
+ */
+function parseLine(line: string): GitignorePattern | undefined {
+	// Trailing spaces are ignored unless escaped
+	let pattern = line.replace(/(?<!\\)\s+$/, '');
+
+	if (!pattern || pattern.startsWith('#')) {
+		return undefined;
+	}
+
+	const negated = pattern.startsWith('!');
+	if (negated) {
+		pattern = pattern.slice(1);
+	} else if (pattern.startsWith('\\#') || pattern.startsWith('\\!')) {
+		pattern = pattern.slice(1);
+	}
+
+	const directoryOnly = pattern.endsWith('/');
+	if (directoryOnly) {
+		pattern = pattern.slice(0, -1);
+	}
+
+	if (!pattern) {
+		return undefined;
+	}
+
+	// Patterns containing a slash are relative to the .gitignore directory,
+	// other patterns match at any depth
+	const anchored = pattern.includes('/');
+	if (pattern.startsWith('/')) {
+		pattern = pattern.slice(1);
+	}
+
+	const prefix = anchored ? '^' : '^(?:.*/)?';
+	return {
+		regex: new RegExp(`${prefix}${globToRegexSource(pattern)}$`),
+		negated,
+		directoryOnly,
+	};
+}
+
+/**
+ * Patterns of a single .gitignore file
+ */
+export class Gitignore {
+	private readonly patterns: GitignorePattern[];
+
+	/**
+	 * Constructor for Gitignore
+	 * @param content Content of the .gitignore file
+	 */
+	constructor(content: string) {
+		this.patterns = content
+			.split(/\r?\n/)
+			.map((line) => parseLine(line))
+			.filter((pattern) => pattern !== undefined);
+	}
+
+	/**
+	 * Check a path against the patterns, the last matching pattern wins
+	 * @param relativePath Path relative to the .gitignore directory (/ separated)
+	 * @param isDirectory Whether the path is a directory
+	 * @returns True if ignored, false if re-included, undefined if nothing matches
+	 */
+	public isIgnored(
+		relativePath: string,
+		isDirectory: boolean,
+	): boolean | undefined {
+		let ignored: boolean | undefined;
+
+		for (const pattern of this.patterns) {
+			if (pattern.directoryOnly && !isDirectory) {
+				continue;
+			}
+
+			if (pattern.regex.test(relativePath)) {
+				ignored = !pattern.negated;
+			}
+		}
+
+		return ignored;
+	}
+}
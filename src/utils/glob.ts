@@ -0,0 +1,32 @@
+/**
+ * Convert a glob such as "packages/*" or "*.tf" to a regular expression
+ * Supports "**" for any number of directories, "*" and "?"
+ * A "**" followed by a slash also matches zero directories
+ */
+export function globToRegex(pattern: string): RegExp {
+	let source = '';
+
+	for (let index = 0; index < pattern.length; index++) {
+		const character = pattern[index];
+
+		if (
+			character === '*' &&
+			pattern[index + 1] === '*' &&
+			pattern[index + 2] === '/'
+		) {
+			source += '(?:.*/)?';
+			index += 2;
+		} else if (character === '*' && pattern[index + 1] === '*') {
+			source += '.*';
+			index++;
+		} else if (character === '*') {
+			source += '[^/]*';
+		} else if (character === '?') {
+			source += '[^/]';
+		} else {
+			source += character.replaceAll(/[.+^${}()|[\]\\]/g, '\\$&');
+		}
+	}
+
+	return new RegExp(`^${source}$`);
+}
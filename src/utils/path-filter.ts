@@ -0,0 +1,107 @@
+import {globToRegex} from './glob.js';
+
+/**
+ * Normalize a glob, e.g. "./legacy/" to "legacy"
+ */
+function normalizeGlob(glob: string): string {
+	return glob
+		.trim()
+		.replace(/^\.?\/+/, '')
+		.replace(/\/+$/, '');
+}
+
+/**
+ * Include and exclude globs that scope which files of a project are scanned
+ * Globs are anchored at the scanned directory and support "**", "*" and "?".
+ * A glob matching a directory applies to every file below it, so "legacy"
+ * and "legacy/**" both exclude the legacy directory.
+ */
+export class PathFilter {
+	private readonly includes: RegExp[];
+	private readonly excludes: RegExp[];
+	// Leading segments of each include glob up to the first wildcard, e.g.
+	// ["packages", "web"] for "packages/web/**/*.ts"
+	private readonly includePrefixes: string[][];
+
+	/**
+	 * Constructor for PathFilter
+	 * @param include Globs of the files to scan, all files if empty
+	 * @param exclude Globs of the files to leave out
+	 */
+	constructor(include: string[] = [], exclude: string[] = []) {
+		const includeGlobs = include.map((glob) => normalizeGlob(glob));
+		this.includes = includeGlobs.map((glob) => globToRegex(glob));
+		this.excludes = exclude.map((glob) => globToRegex(normalizeGlob(glob)));
+		this.includePrefixes = includeGlobs.map((glob) => {
+			const segments = glob.split('/');
+			const wildcardIndex = segments.findIndex((segment) =>
+				/[*?]/.test(segment),
+			);
+			return wildcardIndex === -1
+				? segments
+				: segments.slice(0, wildcardIndex);
+		});
+	}
+
+	/**
+	 * Check if a directory has to be traversed to find the files to scan
+	 * @param relativePath Path relative to the scanned directory (/ separated)
+	 */
+	public shouldTraverse(relativePath: string): boolean {
+		if (this.matches(this.excludes, relativePath, true)) {
+			return false;
+		}
+
+		if (this.includes.length === 0) {
+			return true;
+		}
+
+		// The directory is on the way to an included path, or below one
+		const segments = relativePath.split('/');
+		return this.includePrefixes.some((prefix) =>
+			segments.every(
+				(segment, index) => index >= prefix.length || prefix[index] === segment,
+			),
+		);
+	}
+
+	/**
+	 * Check if a file is scanned, the file or one of its directories has to
+	 * match an include glob and none may match an exclude glob
+	 * @param relativePath Path relative to the scanned directory (/ separated)
+	 */
+	public isIncluded(relativePath: string): boolean {
+		if (this.matches(this.excludes, relativePath, false)) {
+			return false;
+		}
+
+		if (this.includes.length === 0) {
+			return true;
+		}
+
+		const segments = relativePath.split('/');
+		return segments.some((_segment, index) =>
+			this.matches(
+				this.includes,
+				segments.slice(0, index + 1).join('/'),
+				index < segments.length - 1,
+			),
+		);
+	}
+
+	/**
+	 * Check a path against globs, a directory also matches the globs of the
+	 * files below it such as "legacy/**"
+	 */
+	private matches(
+		patterns: RegExp[],
+		relativePath: string,
+		isDirectory: boolean,
+	): boolean {
+		return patterns.some(
+			(pattern) =>
+				pattern.test(relativePath) ||
+				(isDirectory && pattern.test(`${relativePath}/`)),
+		);
+	}
+}
@@ -0,0 +1,51 @@
+import type {AiRule} from '../types.js';
+import {Severity} from '../types/severity.js';
+
+/**
+ * Rank of each severity, lower is more important
+ */
+const severityRanks: Record<Severity, number> = {
+	[Severity.Critical]: 0,
+	[Severity.High]: 1,
+	[Severity.Normal]: 2,
+	[Severity.Info]: 3,
+};
+
+/**
+ * Get the severity of a rule, rules without a severity are normal
+ * @param rule The rule
+ * @returns The rule severity
+ */
+export function getSeverity(rule: AiRule): Severity {
+	return rule.severity ?? Severity.Normal;
+}
+
+/**
+ * Check if a rule is at least as important as the given severity
+ * @param rule The rule
+ * @param minSeverity Minimum severity to keep
+ * @returns True if the rule should be kept
+ */
+export function meetsMinSeverity(rule: AiRule, minSeverity: Severity): boolean {
+	return severityRanks[getSeverity(rule)] <= severityRanks[minSeverity];
+}
+
+/**
+ * Compare two rules by severity, for sorting from most to least important
+ * @param a First rule
+ * @param b Second rule
+ * @returns Negative if the first rule is more important, 0 if equally important
+ */
+export function compareSeverity(a: AiRule, b: AiRule): number {
+	return severityRanks[getSeverity(a)] - severityRanks[getSeverity(b)];
+}
+
+/**
+ * Sort rules from most to least important, keeping the order of rules with
+ * the same severity
+ * @param rules Rules to sort
+ * @returns A new sorted array of rules
+ */
+export function sortBySeverity<T extends AiRule>(rules: readonly T[]): T[] {
+	return [...rules].sort(compareSeverity);
+}
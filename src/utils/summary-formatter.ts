@@ -0,0 +1,143 @@
+import type {ScannerReport, ScannerStatus} from '../types/scan.js';
+
+/**
+ * Titles of the sections of the summary, in display order
+ */
+const statusTitles: Record<ScannerStatus, string> = {
+	matched: 'Matched',
+	'no-match': 'No match',
+	failed: 'Failed',
+	disabled: 'Disabled',
+};
+
+/**
+ * Maximum number of files listed for a scanner
+ */
+const maxListedFiles = 3;
+
+/**
+ * Width the lists of scanner names are wrapped at
+ */
+const lineWidth = 80;
+
+/**
+ * Format a count with the singular or plural of a noun, e.g. "1 rule"
+ */
+function formatCount(count: number, noun: string): string {
+	return `${count} ${noun}${count === 1 ? '' : 's'}`;
+}
+
+/**
+ * Get the label of a scanner, prefixed by its workspace package if any
+ */
+function getLabel(report: ScannerReport): string {
+	return report.package ? `${report.package}: ${report.name}` : report.name;
+}
+
+/**
+ * Wrap a list of names in lines of at most lineWidth characters
+ * @param names Names to list, separated by commas
+ * @param indent Indentation of every line
+ */
+function wrapNames(names: string[], indent: string): string[] {
+	const lines: string[] = [];
+	let line = '';
+
+	for (const [index, name] of names.entries()) {
+		const item = index < names.length - 1 ? `${name},` : name;
+		if (line && indent.length + line.length + item.length + 1 > lineWidth) {
+			lines.push(`${indent}${line}`);
+			line = item;
+		} else {
+			line = line ? `${line} ${item}` : item;
+		}
+	}
+
+	return line ? [...lines, `${indent}${line}`] : lines;
+}
+
+/**
+ * List the names of scanners, grouped by workspace package
+ */
+function formatNames(reports: ScannerReport[]): string[] {
+	const packages = [...new Set(reports.map((report) => report.package))];
+
+	return packages.flatMap((packagePath) => {
+		const names = reports
+			.filter((report) => report.package === packagePath)
+			.map((report) => report.name);
+		return packagePath
+			? [`  ${packagePath}:`, ...wrapNames(names, '    ')]
+			: wrapNames(names, '  ');
+	});
+}
+
+/**
+ * Describe the rules and files of a scanner that matched
+ * e.g. "2 rules from 3 files (package.json, a.ts, b.ts and 1 more)"
+ */
+function formatMatch(report: ScannerReport): string {
+	const {files} = report;
+	const listedFiles = files.slice(0, maxListedFiles).join(', ');
+	const moreFiles =
+		files.length > maxListedFiles
+			? ` and ${files.length - maxListedFiles} more`
+			: '';
+	const source =
+		files.length > 0
+			? ` from ${formatCount(files.length, 'file')} (${listedFiles}${moreFiles})`
+			: '';
+
+	return `${formatCount(report.rules, 'rule')}${source}${report.cached ? ', cached' : ''}`;
+}
+
+/**
+ * Format the report of a scan as a summary of what each scanner found
+ * Matched scanners get a line each with their rule and file counts, the
+ * other scanners are listed by name
+ * @param reports Reports of the scanners, from CodebaseScanner.getReport
+ * @returns The summary, one section per status
+ */
+export function formatScanSummary(reports: ScannerReport[]): string {
+	const ran = reports.filter((report) => report.status !== 'disabled');
+	const matched = reports.filter((report) => report.status === 'matched');
+	const width = Math.max(
+		0,
+		...matched.map((report) => getLabel(report).length),
+	);
+	const packageCount =
+		new Set(reports.map((report) => report.package)).size - 1;
+	// In per-package mode every scanner runs once for the root and each package
+	const scope =
+		packageCount > 0
+			? ` in the root and ${formatCount(packageCount, 'package')}`
+			: '';
+
+	const lines = [
+		`Scan summary: ${matched.length} of ${formatCount(ran.length, 'scanner')} matched${scope}`,
+	];
+
+	for (const [status, title] of Object.entries(statusTitles)) {
+		const sectionReports = reports.filter(
+			(report) => report.status === status,
+		);
+		if (sectionReports.length === 0) {
+			continue;
+		}
+
+		lines.push('', title);
+
+		if (status === 'matched') {
+			lines.push(
+				...sectionReports.map(
+					(report) =>
+						`  ${getLabel(report).padEnd(width)}  ${formatMatch(report)}`,
+				),
+			);
+		} else {
+			lines.push(...formatNames(sectionReports));
+		}
+	}
+
+	return lines.join('\n');
+}
@@ -0,0 +1,36 @@
+import {describe, expect, it} from 'vitest';
+import {diffLines, formatDiff} from '../diff.js';
+
+describe('diff', () => {
+	describe('diffLines', () => {
+		it('should mark the added and removed lines', () => {
+			expect(diffLines('a\nb\nc', 'a\nc\nd')).toEqual([
+				{type: 'unchanged', text: 'a'},
+				{type: 'removed', text: 'b'},
+				{type: 'unchanged', text: 'c'},
+				{type: 'added', text: 'd'},
+			]);
+		});
+	});
+
+	describe('formatDiff', () => {
+		it('should be empty for equal texts', () => {
+			expect(formatDiff('a\nb', 'a\nb')).toBe('');
+		});
+
+		it('should show the changed lines with their context', () => {
+			expect(formatDiff('a\nb\nc\nd', 'a\nb\nC\nd', 1)).toBe(
+				'@@ -2,3 +2,3 @@\n b\n-c\n+C\n d',
+			);
+		});
+
+		it('should split distant changes into hunks', () => {
+			const previous = ['1', '2', '3', '4', '5', '6', '7', '8'].join('\n');
+			const next = ['one', '2', '3', '4', '5', '6', '7', 'eight'].join('\n');
+
+			expect(formatDiff(previous, next, 1)).toBe(
+				'@@ -1,2 +1,2 @@\n-1\n+one\n 2\n@@ -7,2 +7,2 @@\n 7\n-8\n+eight',
+			);
+		});
+	});
+});
@@ -0,0 +1,36 @@
+/**
+ * A parsed semantic version
+ */
+export type Version = {
+	major: number;
+	minor: number;
+	patch: number;
+};
+
+/**
+ * Parse the first version number found in a version or range string
+ * e.g. "19.0.0", "^18.2.0", ">=3.11", "~> 5.0" or "v22"
+ * @param value Version or version range
+ * @returns The parsed version, or undefined if the value has no version number
+ */
+export function parseVersion(value: string): Version | undefined {
+	const match = /(\d+)(?:\.(\d+))?(?:\.(\d+))?/.exec(value);
+	if (!match) {
+		return undefined;
+	}
+
+	return {
+		major: Number.parseInt(match[1], 10),
+		minor: match[2] ? Number.parseInt(match[2], 10) : 0,
+		patch: match[3] ? Number.parseInt(match[3], 10) : 0,
+	};
+}
+
+/**
+ * Get the major version of a version or range string
+ * @param value Version or version range
+ * @returns The major version, or undefined if the value has no version number
+ */
+export function getMajorVersion(value: string): number | undefined {
+	return parseVersion(value)?.major;
+}